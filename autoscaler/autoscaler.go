@@ -0,0 +1,582 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package autoscaler scales pools of ephemeral, just-in-time configured
+// self-hosted GitHub Actions runners to the jobs waiting for them.
+package autoscaler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/google/go-github/v56/internal/labels"
+)
+
+const (
+	defaultNamePrefix         = "autoscaled"
+	defaultIdleTimeout        = 10 * time.Minute
+	defaultOfflineGracePeriod = 5 * time.Minute
+
+	// maxJobQueueTime is how long GitHub keeps a job queued before it is
+	// cancelled. Queued jobs older than this were missed by the autoscaler.
+	maxJobQueueTime = 24 * time.Hour
+)
+
+// Provisioner starts and stops the machines that host the ephemeral
+// self-hosted runners of an Autoscaler.
+type Provisioner interface {
+	// StartRunner starts a machine that runs the runner with
+	// runner.EncodedJITConfig, for example with
+	// "./run.sh --jitconfig $CONFIG". It should return once the machine is
+	// starting; the runner registers itself when it comes up.
+	StartRunner(ctx context.Context, runner *Runner) error
+
+	// StopRunner stops the machine hosting runner. It is called after the
+	// runner ran its job, and when it is removed as idle, offline or
+	// orphaned. Runners that the provisioner does not know of, such as
+	// those started before a restart of the autoscaler, should be ignored.
+	StopRunner(ctx context.Context, runner *Runner) error
+}
+
+// Runner is an ephemeral runner started by an Autoscaler.
+type Runner struct {
+	ID   int64
+	Name string
+
+	// Pool is the name of the Pool the runner belongs to. It is empty
+	// for orphaned runners found by Autoscaler.Reconcile.
+	Pool   string
+	Labels []string
+
+	// EncodedJITConfig is the just-in-time configuration the runner must be
+	// started with. It is only set in calls to StartRunner.
+	EncodedJITConfig string
+}
+
+// Pool is a set of identical runners that serve jobs with matching
+// labels.
+type Pool struct {
+	// Name identifies the pool to the Provisioner and is part of the
+	// names of its runners. It must be unique.
+	Name string
+
+	// Labels are the custom labels of the runners of the pool. A job is
+	// served by the first pool whose labels include all labels the job
+	// runs on, other than "self-hosted", compared case-insensitively.
+	Labels []string
+
+	// MaxRunners is the maximum number of runners in the pool. Zero means
+	// no limit.
+	MaxRunners int
+}
+
+// Options specifies the parameters to New.
+type Options struct {
+	// Pools are the runner pools to scale. At least one is required.
+	Pools []*Pool
+
+	// RunnerGroupID is the runner group runners are added to. It defaults
+	// to the default runner group.
+	RunnerGroupID int64
+
+	// WorkFolder is the working directory of runners, relative to the
+	// runner installation. It defaults to "_work".
+	WorkFolder string
+
+	// NamePrefix is the prefix of the names of runners started by the
+	// autoscaler. Registered runners with this prefix that the autoscaler
+	// does not know of are removed as orphans, so it must not be shared
+	// with other autoscalers or runners. It defaults to "autoscaled".
+	NamePrefix string
+
+	// IdleTimeout is how long a runner may wait for a job while there are
+	// no queued jobs for its pool before it is removed. It defaults to ten
+	// minutes.
+	IdleTimeout time.Duration
+
+	// OfflineGracePeriod is how long a runner may be offline or not yet
+	// registered before it is removed. It defaults to five minutes.
+	OfflineGracePeriod time.Duration
+}
+
+// PoolMetrics are the metrics of a runner pool.
+type PoolMetrics struct {
+	// QueuedJobs is the queue depth: the number of jobs for the pool that
+	// are waiting for a runner.
+	QueuedJobs     int
+	InProgressJobs int
+
+	// Runners is the number of runners started and not yet removed,
+	// IdleRunners how many of them are not running a job and
+	// StartingRunners the number of runners being started.
+	Runners         int
+	IdleRunners     int
+	StartingRunners int
+}
+
+// Metrics are the metrics of an Autoscaler.
+type Metrics struct {
+	// Pools holds the metrics of each pool, keyed by pool name.
+	Pools map[string]*PoolMetrics
+
+	// RunnersStarted, RunnersRemoved and FailedStarts are counted since
+	// the autoscaler was created.
+	RunnersStarted int
+	RunnersRemoved int
+	FailedStarts   int
+}
+
+// Autoscaler scales pools of ephemeral, just-in-time configured
+// self-hosted runners of an organization or repository to the queue of
+// jobs waiting for them.
+//
+// Jobs are tracked from workflow_job webhook events passed to
+// HandleWorkflowJobEvent, which starts runners for queued jobs. Jobs are
+// not listed from the API, so a job whose queued event is missed is not
+// served. Reconcile should be called periodically to remove runners that
+// are orphaned, offline or idle, including those left behind by missed
+// in_progress and completed events. An Autoscaler is safe for
+// concurrent use.
+type Autoscaler struct {
+	client      *github.Client
+	owner, repo string
+	provisioner Provisioner
+	opts        Options
+	now         func() time.Time
+
+	mu         sync.Mutex
+	seq        int
+	queued     map[int64]*autoscalerJob
+	inProgress map[int64]*autoscalerJob
+	runners    map[string]*autoscaledRunner
+	starting   map[string]int
+	// startingNames are the names of the runners being started, which may
+	// be registered before they are added to runners.
+	startingNames  map[string]bool
+	runnersStarted int
+	runnersRemoved int
+	failedStarts   int
+}
+
+type autoscalerJob struct {
+	pool     string
+	queuedAt time.Time
+}
+
+type autoscaledRunner struct {
+	runner       *Runner
+	startedAt    time.Time
+	idleSince    time.Time
+	offlineSince time.Time
+	jobID        int64
+}
+
+// New returns an Autoscaler for the runners of repository owner/repo, or of
+// organization owner if repo is empty.
+func New(client *github.Client, owner, repo string, provisioner Provisioner, opts *Options) (*Autoscaler, error) {
+	if provisioner == nil {
+		return nil, errors.New("a runner provisioner must be provided")
+	}
+	if opts == nil || len(opts.Pools) == 0 {
+		return nil, errors.New("at least one runner pool must be provided")
+	}
+	names := make(map[string]bool)
+	for _, p := range opts.Pools {
+		switch {
+		case p.Name == "":
+			return nil, errors.New("runner pools must have a name")
+		case names[p.Name]:
+			return nil, fmt.Errorf("duplicate runner pool %q", p.Name)
+		case len(p.Labels) == 0:
+			return nil, fmt.Errorf("runner pool %q has no labels", p.Name)
+		}
+		names[p.Name] = true
+	}
+
+	a := &Autoscaler{
+		client:        client,
+		owner:         owner,
+		repo:          repo,
+		provisioner:   provisioner,
+		opts:          *opts,
+		now:           time.Now,
+		queued:        make(map[int64]*autoscalerJob),
+		inProgress:    make(map[int64]*autoscalerJob),
+		runners:       make(map[string]*autoscaledRunner),
+		starting:      make(map[string]int),
+		startingNames: make(map[string]bool),
+	}
+	if a.opts.RunnerGroupID == 0 {
+		a.opts.RunnerGroupID = 1
+	}
+	if a.opts.NamePrefix == "" {
+		a.opts.NamePrefix = defaultNamePrefix
+	}
+	if a.opts.IdleTimeout <= 0 {
+		a.opts.IdleTimeout = defaultIdleTimeout
+	}
+	if a.opts.OfflineGracePeriod <= 0 {
+		a.opts.OfflineGracePeriod = defaultOfflineGracePeriod
+	}
+	return a, nil
+}
+
+// HandleWorkflowJobEvent updates the job queue from event and starts
+// runners for queued jobs that no idle runner is available for. Events
+// for jobs that no pool serves are ignored. The runner of a completed job
+// is stopped, as ephemeral runners only run a single job.
+func (a *Autoscaler) HandleWorkflowJobEvent(ctx context.Context, event *github.WorkflowJobEvent) error {
+	job := event.GetWorkflowJob()
+	if job == nil {
+		return nil
+	}
+	pool := a.poolFor(job.Labels)
+	if pool == nil {
+		return nil
+	}
+
+	var stop *Runner
+	a.mu.Lock()
+	id := job.GetID()
+	switch event.GetAction() {
+	case "queued":
+		if a.inProgress[id] == nil {
+			a.queued[id] = &autoscalerJob{pool: pool.Name, queuedAt: a.now()}
+		}
+	case "in_progress":
+		delete(a.queued, id)
+		a.inProgress[id] = &autoscalerJob{pool: pool.Name}
+		if r := a.runners[job.GetRunnerName()]; r != nil {
+			r.jobID = id
+		}
+	case "completed":
+		delete(a.queued, id)
+		delete(a.inProgress, id)
+		if r := a.runners[job.GetRunnerName()]; r != nil {
+			delete(a.runners, job.GetRunnerName())
+			a.runnersRemoved++
+			stop = r.runner
+		}
+	}
+	a.mu.Unlock()
+
+	var err error
+	if stop != nil {
+		// The runner has removed its own registration.
+		err = a.provisioner.StopRunner(ctx, stop)
+	}
+	if serr := a.scale(ctx); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// Reconcile compares the registered runners with the runners the
+// autoscaler started and cleans up after failures and missed in_progress
+// and completed events:
+//
+//   - runners that are offline, or not registered, for longer than
+//     OfflineGracePeriod are removed and stopped;
+//   - idle runners beyond the number of queued jobs of their pool are
+//     removed and stopped after IdleTimeout;
+//   - registered runners with the autoscaler's name prefix that it does
+//     not know of, and that are not busy, are removed as orphans and
+//     passed to StopRunner with an empty pool;
+//   - queued jobs older than GitHub's 24 hour queue limit are forgotten.
+//
+// Finally, runners are started for the known queued jobs as in
+// HandleWorkflowJobEvent, which retries starts that failed. Reconcile does
+// not list jobs, so jobs whose queued event was missed are not recovered.
+// Reconcile carries on after a failure to remove or stop a runner and
+// returns the first error.
+func (a *Autoscaler) Reconcile(ctx context.Context) error {
+	registered, err := a.listRunners(ctx)
+	if err != nil {
+		return err
+	}
+
+	type removal struct {
+		runner     *Runner
+		registered bool
+	}
+	var removals []removal
+
+	a.mu.Lock()
+	now := a.now()
+	for id, job := range a.queued {
+		if now.Sub(job.queuedAt) > maxJobQueueTime {
+			delete(a.queued, id)
+		}
+	}
+
+	queued := make(map[string]int)
+	for _, job := range a.queued {
+		queued[job.pool]++
+	}
+	idle := make(map[string]int)
+	for _, r := range a.runners {
+		if r.jobID == 0 {
+			idle[r.runner.Pool]++
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, reg := range registered {
+		name := reg.GetName()
+		if !strings.HasPrefix(name, a.opts.NamePrefix+"-") {
+			continue
+		}
+		seen[name] = true
+		r := a.runners[name]
+		if r == nil {
+			if !reg.GetBusy() && !a.startingNames[name] {
+				removals = append(removals, removal{&Runner{ID: reg.GetID(), Name: name}, true})
+			}
+			continue
+		}
+
+		remove := false
+		if reg.GetStatus() == "offline" {
+			if r.offlineSince.IsZero() {
+				r.offlineSince = now
+			}
+			remove = now.Sub(r.offlineSince) >= a.opts.OfflineGracePeriod
+		} else {
+			r.offlineSince = time.Time{}
+			pool := r.runner.Pool
+			if r.jobID == 0 && !reg.GetBusy() && idle[pool] > queued[pool] && now.Sub(r.idleSince) >= a.opts.IdleTimeout {
+				remove = true
+			}
+		}
+		if remove {
+			if r.jobID == 0 {
+				idle[r.runner.Pool]--
+			}
+			delete(a.runners, name)
+			removals = append(removals, removal{r.runner, true})
+		}
+	}
+	for name, r := range a.runners {
+		if !seen[name] && now.Sub(r.startedAt) >= a.opts.OfflineGracePeriod {
+			delete(a.runners, name)
+			removals = append(removals, removal{r.runner, false})
+		}
+	}
+	a.runnersRemoved += len(removals)
+	a.mu.Unlock()
+
+	var firstErr error
+	for _, rm := range removals {
+		if rm.registered {
+			if _, err := a.removeRunner(ctx, rm.runner.ID); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if err := a.provisioner.StopRunner(ctx, rm.runner); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := a.scale(ctx); firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// Metrics returns the current metrics of the autoscaler.
+func (a *Autoscaler) Metrics() *Metrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	m := &Metrics{
+		Pools:          make(map[string]*PoolMetrics),
+		RunnersStarted: a.runnersStarted,
+		RunnersRemoved: a.runnersRemoved,
+		FailedStarts:   a.failedStarts,
+	}
+	for _, p := range a.opts.Pools {
+		m.Pools[p.Name] = &PoolMetrics{StartingRunners: a.starting[p.Name]}
+	}
+	for _, job := range a.queued {
+		m.Pools[job.pool].QueuedJobs++
+	}
+	for _, job := range a.inProgress {
+		m.Pools[job.pool].InProgressJobs++
+	}
+	for _, r := range a.runners {
+		pm := m.Pools[r.runner.Pool]
+		pm.Runners++
+		if r.jobID == 0 {
+			pm.IdleRunners++
+		}
+	}
+	return m
+}
+
+// poolFor returns the pool that serves jobs running on jobLabels, or nil.
+func (a *Autoscaler) poolFor(jobLabels []string) *Pool {
+	if len(jobLabels) == 0 {
+		return nil
+	}
+	for _, p := range a.opts.Pools {
+		ok := true
+		for _, l := range jobLabels {
+			if !strings.EqualFold(l, "self-hosted") && !labels.ContainsFold(p.Labels, l) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// scale starts runners for the queued jobs of each pool that exceed its
+// idle and starting runners, within the limit of the pool. Failures to
+// start a runner are counted and the first one is returned.
+func (a *Autoscaler) scale(ctx context.Context) error {
+	var start []*Runner
+
+	a.mu.Lock()
+	queued := make(map[string]int)
+	for _, job := range a.queued {
+		queued[job.pool]++
+	}
+	available := make(map[string]int)
+	total := make(map[string]int)
+	for _, r := range a.runners {
+		total[r.runner.Pool]++
+		if r.jobID == 0 {
+			available[r.runner.Pool]++
+		}
+	}
+	for _, p := range a.opts.Pools {
+		n := queued[p.Name] - available[p.Name] - a.starting[p.Name]
+		if p.MaxRunners > 0 {
+			if room := p.MaxRunners - total[p.Name] - a.starting[p.Name]; n > room {
+				n = room
+			}
+		}
+		for i := 0; i < n; i++ {
+			a.seq++
+			r := &Runner{
+				Name:   fmt.Sprintf("%v-%v-%v-%v", a.opts.NamePrefix, p.Name, a.now().Unix(), a.seq),
+				Pool:   p.Name,
+				Labels: append([]string(nil), p.Labels...),
+			}
+			start = append(start, r)
+			a.starting[p.Name]++
+			a.startingNames[r.Name] = true
+		}
+	}
+	a.mu.Unlock()
+
+	var firstErr error
+	for _, r := range start {
+		err := a.start(ctx, r)
+
+		a.mu.Lock()
+		a.starting[r.Pool]--
+		delete(a.startingNames, r.Name)
+		if err != nil {
+			a.failedStarts++
+		} else {
+			now := a.now()
+			a.runners[r.Name] = &autoscaledRunner{runner: r, startedAt: now, idleSince: now}
+			a.runnersStarted++
+		}
+		a.mu.Unlock()
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// start registers r with a just-in-time configuration and starts it. If
+// the provisioner fails to start it, its registration is removed again.
+func (a *Autoscaler) start(ctx context.Context, r *Runner) error {
+	req := &github.GenerateJITConfigRequest{Name: r.Name, RunnerGroupID: a.opts.RunnerGroupID, Labels: r.Labels}
+	if a.opts.WorkFolder != "" {
+		req.WorkFolder = github.String(a.opts.WorkFolder)
+	}
+
+	var config *github.JITRunnerConfig
+	err := github.RetryOnRateLimit(ctx, func() (resp *github.Response, err error) {
+		if a.repo == "" {
+			config, resp, err = a.client.Actions.GenerateOrgJITConfig(ctx, a.owner, req)
+		} else {
+			config, resp, err = a.client.Actions.GenerateRepoJITConfig(ctx, a.owner, a.repo, req)
+		}
+		return resp, err
+	})
+	if err != nil {
+		return err
+	}
+	r.ID = config.GetRunner().GetID()
+	r.EncodedJITConfig = config.GetEncodedJITConfig()
+
+	err = a.provisioner.StartRunner(ctx, r)
+	r.EncodedJITConfig = ""
+	if err != nil {
+		// The start failed already; a leftover registration is cleaned up
+		// by Reconcile.
+		_, _ = a.removeRunner(ctx, r.ID)
+		return err
+	}
+	return nil
+}
+
+func (a *Autoscaler) listRunners(ctx context.Context) ([]*github.Runner, error) {
+	var all []*github.Runner
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var (
+			runners *github.Runners
+			resp    *github.Response
+		)
+		err := github.RetryOnRateLimit(ctx, func() (_ *github.Response, err error) {
+			if a.repo == "" {
+				runners, resp, err = a.client.Actions.ListOrganizationRunners(ctx, a.owner, opts)
+			} else {
+				runners, resp, err = a.client.Actions.ListRunners(ctx, a.owner, a.repo, opts)
+			}
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, runners.Runners...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (a *Autoscaler) removeRunner(ctx context.Context, runnerID int64) (*github.Response, error) {
+	var resp *github.Response
+	err := github.RetryOnRateLimit(ctx, func() (_ *github.Response, err error) {
+		if a.repo == "" {
+			resp, err = a.client.Actions.RemoveOrganizationRunner(ctx, a.owner, runnerID)
+		} else {
+			resp, err = a.client.Actions.RemoveRunner(ctx, a.owner, a.repo, runnerID)
+		}
+		return resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// The runner has already removed itself.
+		return resp, nil
+	}
+	return resp, err
+}
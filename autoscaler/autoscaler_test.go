@@ -0,0 +1,408 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autoscaler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v56/github"
+)
+
+// setup sets up a test HTTP server along with a github.Client that is
+// configured to talk to it.
+func setup(t *testing.T) (*github.Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = u
+	return client, mux
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	if got := r.Method; got != want {
+		t.Errorf("Request method: %v, want %v", got, want)
+	}
+}
+
+func testBody(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Error reading request body: %v", err)
+	}
+	if got := string(b); got != want {
+		t.Errorf("request Body is %s, want %s", got, want)
+	}
+}
+
+func assertNilError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// testRunnerServer fakes the self-hosted runner endpoints of an
+// organization.
+type testRunnerServer struct {
+	mu      sync.Mutex
+	nextID  int64
+	runners map[int64]*github.Runner
+	removed []int64
+}
+
+func newTestRunnerServer(t *testing.T, mux *http.ServeMux) *testRunnerServer {
+	t.Helper()
+	s := &testRunnerServer{nextID: 100, runners: make(map[int64]*github.Runner)}
+
+	mux.HandleFunc("/orgs/o/actions/runners/generate-jitconfig", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var req github.GenerateJITConfigRequest
+		assertNilError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.RunnerGroupID != 1 || len(req.Labels) == 0 {
+			t.Errorf("GenerateOrgJITConfig request = %+v", req)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.nextID++
+		runner := &github.Runner{ID: github.Int64(s.nextID), Name: github.String(req.Name), Status: github.String("offline"), Busy: github.Bool(false)}
+		s.runners[s.nextID] = runner
+		assertNilError(t, json.NewEncoder(w).Encode(&github.JITRunnerConfig{Runner: runner, EncodedJITConfig: github.String("config-" + req.Name)}))
+	})
+	mux.HandleFunc("/orgs/o/actions/runners", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		list := &github.Runners{}
+		for _, r := range s.runners {
+			list.Runners = append(list.Runners, r)
+		}
+		list.TotalCount = len(list.Runners)
+		assertNilError(t, json.NewEncoder(w).Encode(list))
+	})
+	mux.HandleFunc("/orgs/o/actions/runners/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/orgs/o/actions/runners/"), 10, 64)
+		assertNilError(t, err)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.runners[id] == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.runners, id)
+		s.removed = append(s.removed, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return s
+}
+
+// set updates the runner called name, or adds it with id if it does not
+// exist.
+func (s *testRunnerServer) set(id int64, name, status string, busy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.runners {
+		if r.GetName() == name {
+			r.Status, r.Busy = github.String(status), github.Bool(busy)
+			return
+		}
+	}
+	s.runners[id] = &github.Runner{ID: github.Int64(id), Name: github.String(name), Status: github.String(status), Busy: github.Bool(busy)}
+}
+
+func (s *testRunnerServer) takeRemoved() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := s.removed
+	s.removed = nil
+	return removed
+}
+
+func testJobEvent(action string, id int64, runnerName string, labels ...string) *github.WorkflowJobEvent {
+	job := &github.WorkflowJob{ID: github.Int64(id), Labels: labels}
+	if runnerName != "" {
+		job.RunnerName = github.String(runnerName)
+	}
+	return &github.WorkflowJobEvent{Action: github.String(action), WorkflowJob: job}
+}
+
+func testRunnerNames(runners []*Runner) []string {
+	var names []string
+	for _, r := range runners {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestNew_invalid(t *testing.T) {
+	client := github.NewClient(nil)
+	p := &FakeProvisioner{}
+	tests := []struct {
+		provisioner Provisioner
+		opts        *Options
+	}{
+		{nil, &Options{Pools: []*Pool{{Name: "a", Labels: []string{"a"}}}}},
+		{p, nil},
+		{p, &Options{Pools: []*Pool{{Labels: []string{"a"}}}}},
+		{p, &Options{Pools: []*Pool{{Name: "a"}}}},
+		{p, &Options{Pools: []*Pool{{Name: "a", Labels: []string{"a"}}, {Name: "a", Labels: []string{"b"}}}}},
+	}
+	for i, tt := range tests {
+		if _, err := New(client, "o", "", tt.provisioner, tt.opts); err == nil {
+			t.Errorf("#%v: New returned nil error", i)
+		}
+	}
+}
+
+func TestAutoscaler_HandleWorkflowJobEvent(t *testing.T) {
+	client, mux := setup(t)
+	newTestRunnerServer(t, mux)
+
+	p := &FakeProvisioner{}
+	a, err := New(client, "o", "", p, &Options{
+		Pools: []*Pool{
+			{Name: "linux", Labels: []string{"linux", "x64"}},
+			{Name: "gpu", Labels: []string{"linux", "gpu"}, MaxRunners: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	ctx := context.Background()
+	events := []*github.WorkflowJobEvent{
+		testJobEvent("queued", 1, "", "self-hosted", "Linux"),
+		testJobEvent("queued", 2, "", "self-hosted", "gpu"),
+		testJobEvent("queued", 3, "", "gpu"),
+		testJobEvent("queued", 4, "", "windows"),
+		testJobEvent("queued", 5, "", "ubuntu-latest"),
+	}
+	for _, e := range events {
+		if err := a.HandleWorkflowJobEvent(ctx, e); err != nil {
+			t.Fatalf("HandleWorkflowJobEvent returned error: %v", err)
+		}
+	}
+
+	running := p.Running()
+	want := []*Runner{
+		{ID: 102, Name: "autoscaled-gpu-1700000000-2", Pool: "gpu", Labels: []string{"linux", "gpu"}, EncodedJITConfig: "config-autoscaled-gpu-1700000000-2"},
+		{ID: 101, Name: "autoscaled-linux-1700000000-1", Pool: "linux", Labels: []string{"linux", "x64"}, EncodedJITConfig: "config-autoscaled-linux-1700000000-1"},
+	}
+	if !cmp.Equal(running, want) {
+		t.Fatalf("running runners diff (-want +got):\n%v", cmp.Diff(want, running))
+	}
+
+	linux := "autoscaled-linux-1700000000-1"
+	assertNilError(t, a.HandleWorkflowJobEvent(ctx, testJobEvent("in_progress", 1, linux, "self-hosted", "linux")))
+
+	wantMetrics := &Metrics{
+		Pools: map[string]*PoolMetrics{
+			"linux": {InProgressJobs: 1, Runners: 1},
+			"gpu":   {QueuedJobs: 2, Runners: 1, IdleRunners: 1},
+		},
+		RunnersStarted: 2,
+	}
+	if got := a.Metrics(); !cmp.Equal(got, wantMetrics) {
+		t.Errorf("Metrics diff (-want +got):\n%v", cmp.Diff(wantMetrics, got))
+	}
+
+	assertNilError(t, a.HandleWorkflowJobEvent(ctx, testJobEvent("completed", 1, linux, "self-hosted", "linux")))
+	if got, want := testRunnerNames(p.Running()), []string{"autoscaled-gpu-1700000000-2"}; !cmp.Equal(got, want) {
+		t.Errorf("running runners = %v, want %v", got, want)
+	}
+	if m := a.Metrics(); m.RunnersRemoved != 1 || m.Pools["linux"].InProgressJobs != 0 || m.Pools["linux"].Runners != 0 {
+		t.Errorf("Metrics after completion = %+v", m)
+	}
+}
+
+func TestAutoscaler_Reconcile(t *testing.T) {
+	client, mux := setup(t)
+	server := newTestRunnerServer(t, mux)
+
+	p := &FakeProvisioner{}
+	a, err := New(client, "o", "", p, &Options{
+		Pools:              []*Pool{{Name: "linux", Labels: []string{"linux"}}},
+		IdleTimeout:        10 * time.Minute,
+		OfflineGracePeriod: 5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	a.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for id := int64(1); id <= 3; id++ {
+		assertNilError(t, a.HandleWorkflowJobEvent(ctx, testJobEvent("queued", id, "", "linux")))
+	}
+	if got := len(p.Running()); got != 3 {
+		t.Fatalf("%v runners running, want 3", got)
+	}
+
+	// Runner 101 comes online and stays idle, 102 stays offline and 103
+	// removes its registration without the autoscaler noticing. An orphan
+	// from an earlier autoscaler and a foreign runner are registered.
+	server.set(101, "autoscaled-linux-1700000000-1", "online", false)
+	server.mu.Lock()
+	delete(server.runners, 103)
+	server.mu.Unlock()
+	server.set(7, "autoscaled-linux-1600000000-1", "online", false)
+	server.set(8, "autoscaled-linux-1600000000-2", "online", true)
+	server.set(9, "build-box", "offline", false)
+
+	assertNilError(t, a.Reconcile(ctx))
+	if got, want := server.takeRemoved(), []int64{7}; !cmp.Equal(got, want) {
+		t.Errorf("removed runners = %v, want %v", got, want)
+	}
+	if got := len(p.Running()); got != 3 {
+		t.Errorf("%v runners running, want 3", got)
+	}
+
+	// The jobs are cancelled before they run.
+	for id := int64(1); id <= 3; id++ {
+		assertNilError(t, a.HandleWorkflowJobEvent(ctx, testJobEvent("completed", id, "", "linux")))
+	}
+	now = now.Add(6 * time.Minute)
+	assertNilError(t, a.Reconcile(ctx))
+	if got, want := server.takeRemoved(), []int64{102}; !cmp.Equal(got, want) {
+		t.Errorf("removed runners = %v, want %v", got, want)
+	}
+	if got, want := testRunnerNames(p.Running()), []string{"autoscaled-linux-1700000000-1"}; !cmp.Equal(got, want) {
+		t.Errorf("running runners = %v, want %v", got, want)
+	}
+
+	now = now.Add(5 * time.Minute)
+	assertNilError(t, a.Reconcile(ctx))
+	if got, want := server.takeRemoved(), []int64{101}; !cmp.Equal(got, want) {
+		t.Errorf("removed runners = %v, want %v", got, want)
+	}
+	if got := p.Running(); len(got) != 0 {
+		t.Errorf("running runners = %v, want none", testRunnerNames(got))
+	}
+
+	m := a.Metrics()
+	if m.RunnersStarted != 3 || m.RunnersRemoved != 4 || m.Pools["linux"].Runners != 0 {
+		t.Errorf("Metrics = %+v", m)
+	}
+}
+
+func TestAutoscaler_startFailure(t *testing.T) {
+	client, mux := setup(t)
+	server := newTestRunnerServer(t, mux)
+
+	p := &FakeProvisioner{StartErr: errors.New("no capacity")}
+	a, err := New(client, "o", "", p, &Options{
+		Pools: []*Pool{{Name: "linux", Labels: []string{"linux"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := a.HandleWorkflowJobEvent(ctx, testJobEvent("queued", 1, "", "linux")); err != p.StartErr {
+		t.Errorf("HandleWorkflowJobEvent returned %v, want %v", err, p.StartErr)
+	}
+	if got, want := server.takeRemoved(), []int64{101}; !cmp.Equal(got, want) {
+		t.Errorf("removed runners = %v, want %v", got, want)
+	}
+	m := a.Metrics()
+	if m.FailedStarts != 1 || m.Pools["linux"].QueuedJobs != 1 || m.Pools["linux"].StartingRunners != 0 {
+		t.Errorf("Metrics = %+v", m)
+	}
+
+	// The next reconciliation retries.
+	p.StartErr = nil
+	assertNilError(t, a.Reconcile(ctx))
+	if got := testRunnerNames(p.Running()); len(got) != 1 {
+		t.Errorf("running runners = %v, want one", got)
+	}
+}
+
+func TestAutoscaler_repository(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/repos/o/r/actions/runners/generate-jitconfig", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"ci-linux-1700000000-1","runner_group_id":2,"work_folder":"w","labels":["linux"]}`+"\n")
+		fmt.Fprint(w, `{"runner":{"id":5},"encoded_jit_config":"c"}`)
+	})
+
+	p := &FakeProvisioner{}
+	a, err := New(client, "o", "r", p, &Options{
+		Pools:         []*Pool{{Name: "linux", Labels: []string{"linux"}}},
+		RunnerGroupID: 2,
+		WorkFolder:    "w",
+		NamePrefix:    "ci",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	assertNilError(t, a.HandleWorkflowJobEvent(context.Background(), testJobEvent("queued", 1, "", "linux")))
+	if got := p.Running(); len(got) != 1 || got[0].ID != 5 {
+		t.Errorf("running runners = %+v", got)
+	}
+}
+
+// reconcilingProvisioner runs a reconciliation while a runner is starting,
+// after its just-in-time configuration was generated.
+type reconcilingProvisioner struct {
+	FakeProvisioner
+	a   *Autoscaler
+	err error
+}
+
+func (p *reconcilingProvisioner) StartRunner(ctx context.Context, runner *Runner) error {
+	p.err = p.a.Reconcile(ctx)
+	return p.FakeProvisioner.StartRunner(ctx, runner)
+}
+
+func TestAutoscaler_reconcileWhileStarting(t *testing.T) {
+	client, mux := setup(t)
+	server := newTestRunnerServer(t, mux)
+
+	p := &reconcilingProvisioner{}
+	a, err := New(client, "o", "", p, &Options{
+		Pools: []*Pool{{Name: "linux", Labels: []string{"linux"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.a = a
+
+	assertNilError(t, a.HandleWorkflowJobEvent(context.Background(), testJobEvent("queued", 1, "", "linux")))
+	assertNilError(t, p.err)
+	if got := server.takeRemoved(); len(got) != 0 {
+		t.Errorf("removed runners = %v, want none", got)
+	}
+	if got := p.Running(); len(got) != 1 {
+		t.Errorf("running runners = %v, want one", testRunnerNames(got))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autoscaler
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// FakeProvisioner is a Provisioner that only records which
+// runners are running, for testing autoscalers without machines.
+type FakeProvisioner struct {
+	// StartErr, if set, is returned by StartRunner.
+	StartErr error
+
+	mu      sync.Mutex
+	running map[string]*Runner
+}
+
+// StartRunner records runner as running.
+func (p *FakeProvisioner) StartRunner(ctx context.Context, runner *Runner) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.StartErr != nil {
+		return p.StartErr
+	}
+	if p.running == nil {
+		p.running = make(map[string]*Runner)
+	}
+	r := *runner
+	p.running[r.Name] = &r
+	return nil
+}
+
+// StopRunner records runner as no longer running.
+func (p *FakeProvisioner) StopRunner(ctx context.Context, runner *Runner) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.running, runner.Name)
+	return nil
+}
+
+// Running returns the running runners, sorted by name.
+func (p *FakeProvisioner) Running() []*Runner {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var runners []*Runner
+	for _, r := range p.running {
+		runners = append(runners, r)
+	}
+	sort.Slice(runners, func(i, j int) bool { return runners[i].Name < runners[j].Name })
+	return runners
+}
@@ -150,6 +150,18 @@ func (s *ActionsService) DownloadArtifact(ctx context.Context, owner, repo strin
 	return parsedURL, newResponse(resp), nil
 }
 
+// DownloadArtifactToFile downloads the zip archive of an artifact to path,
+// resuming interrupted transfers and verifying the checksum in opts, if
+// any. The file at path is only replaced once the download is complete.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/artifacts#download-an-artifact
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/artifacts/{artifact_id}/{archive_format}
+func (s *ActionsService) DownloadArtifactToFile(ctx context.Context, owner, repo string, artifactID int64, path string, opts *DownloadToFileOptions) error {
+	u := fmt.Sprintf("repos/%v/%v/actions/artifacts/%v/zip", owner, repo, artifactID)
+	return s.client.downloadToFile(ctx, u, path, opts)
+}
+
 // DeleteArtifact deletes a workflow run artifact.
 //
 // GitHub API docs: https://docs.github.com/rest/actions/artifacts#delete-an-artifact
@@ -0,0 +1,262 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArtifactSizeMismatchError is returned when a downloaded artifact does
+// not have the size reported in Artifact.SizeInBytes.
+type ArtifactSizeMismatchError struct {
+	Name string
+	Want int64
+	Got  int64
+}
+
+func (e *ArtifactSizeMismatchError) Error() string {
+	return fmt.Sprintf("artifact %q is %v bytes, want %v", e.Name, e.Got, e.Want)
+}
+
+// ExtractArtifact downloads the zip archive of artifact and extracts it
+// into dir, which is created if needed. The archive is checked against
+// artifact.SizeInBytes, which GitHub reports either as the size of the
+// archive or, for artifacts uploaded by older versions of
+// actions/upload-artifact, as the total size of its files.
+//
+// The archive is rejected before anything is extracted if it has entries
+// with paths outside dir or entries other than directories and regular
+// files.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/artifacts#download-an-artifact
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/artifacts/{artifact_id}/{archive_format}
+func (s *ActionsService) ExtractArtifact(ctx context.Context, owner, repo string, artifact *Artifact, dir string) error {
+	if err := checkArtifactDownloadable(artifact); err != nil {
+		return err
+	}
+	u := fmt.Sprintf("repos/%v/%v/actions/artifacts/%v/zip", owner, repo, artifact.GetID())
+	zr, size, _, cleanup, err := s.client.downloadZip(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := checkArtifactArchive(artifact, zr, size); err != nil {
+		return err
+	}
+	return extractZip(zr, dir)
+}
+
+// GetArtifactFS downloads the zip archive of artifact into memory and
+// returns its contents as a read-only file system. The archive is checked
+// against artifact.SizeInBytes as described in ExtractArtifact.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/artifacts#download-an-artifact
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/artifacts/{artifact_id}/{archive_format}
+func (s *ActionsService) GetArtifactFS(ctx context.Context, owner, repo string, artifact *Artifact) (fs.FS, error) {
+	if err := checkArtifactDownloadable(artifact); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("repos/%v/%v/actions/artifacts/%v/zip", owner, repo, artifact.GetID())
+	resp, err := s.client.openDownload(ctx, u, &DownloadToFileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkArtifactArchive(artifact, zr, int64(len(data))); err != nil {
+		return nil, err
+	}
+	return zr, nil
+}
+
+// FindLatestArtifact returns the artifact called name from the latest
+// successful run of the workflow in workflowFileName on branch that still
+// has it. Runs whose artifact is missing or has expired are skipped. An
+// error is returned if no successful run has the artifact.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/artifacts#list-workflow-run-artifacts
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#list-workflow-runs-for-a-workflow
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/artifacts
+//meta:operation GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs
+func (s *ActionsService) FindLatestArtifact(ctx context.Context, owner, repo, workflowFileName, branch, name string) (*Artifact, error) {
+	opts := &ListWorkflowRunsOptions{
+		Branch:      branch,
+		Status:      "success",
+		ListOptions: ListOptions{PerPage: 100},
+	}
+	var found bool
+	for {
+		runs, resp, err := s.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFileName, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs.WorkflowRuns {
+			found = true
+			artifact, err := s.findRunArtifact(ctx, owner, repo, run.GetID(), name)
+			if err != nil {
+				return nil, err
+			}
+			if artifact != nil {
+				return artifact, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if !found {
+		return nil, fmt.Errorf("workflow %v has no successful run on branch %v", workflowFileName, branch)
+	}
+	return nil, fmt.Errorf("no successful run of workflow %v on branch %v has an unexpired artifact %q", workflowFileName, branch, name)
+}
+
+// findRunArtifact returns the unexpired artifact called name of the
+// workflow run runID, or nil if it has none.
+func (s *ActionsService) findRunArtifact(ctx context.Context, owner, repo string, runID int64, name string) (*Artifact, error) {
+	opts := &ListOptions{PerPage: 100}
+	for {
+		list, resp, err := s.ListWorkflowRunArtifacts(ctx, owner, repo, runID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range list.Artifacts {
+			if a.GetName() == name && !a.GetExpired() {
+				return a, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// checkArtifactDownloadable returns an error if artifact cannot be
+// downloaded.
+func checkArtifactDownloadable(artifact *Artifact) error {
+	switch {
+	case artifact == nil || artifact.ID == nil:
+		return errors.New("an artifact with an ID must be provided")
+	case artifact.GetExpired():
+		return fmt.Errorf("artifact %q has expired", artifact.GetName())
+	}
+	return nil
+}
+
+// checkArtifactArchive checks the downloaded archive of artifact, which is
+// size bytes long, against the size GitHub reports, and checks that all of
+// its entries are directories or regular files with valid paths.
+func checkArtifactArchive(artifact *Artifact, zr *zip.Reader, size int64) error {
+	var total int64
+	for _, f := range zr.File {
+		if !fs.ValidPath(strings.TrimSuffix(f.Name, "/")) || strings.Contains(f.Name, `\`) {
+			return fmt.Errorf("artifact entry %q has an invalid path", f.Name)
+		}
+		if m := f.Mode(); !m.IsDir() && !m.IsRegular() {
+			return fmt.Errorf("artifact entry %q is not a regular file", f.Name)
+		}
+		total += int64(f.UncompressedSize64)
+	}
+
+	if artifact.SizeInBytes == nil {
+		return nil
+	}
+	if want := artifact.GetSizeInBytes(); want != size && want != total {
+		return &ArtifactSizeMismatchError{Name: artifact.GetName(), Want: want, Got: size}
+	}
+	return nil
+}
+
+// extractZip extracts the entries of zr, whose paths have been validated,
+// into dir. As in extractTarball, entries are not extracted through
+// symbolic links that already exist below dir.
+func extractZip(zr *zip.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		name := strings.TrimSuffix(f.Name, "/")
+		target := filepath.Join(root, filepath.FromSlash(name))
+		mode := f.Mode()
+		dirName := path.Dir(name)
+		if mode.IsDir() {
+			dirName = name
+		}
+		if err := checkArchiveDir(root, dirName); err != nil {
+			return fmt.Errorf("artifact entry %q: %v", f.Name, err)
+		}
+		if mode.IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		perm := fs.FileMode(0o644)
+		if mode&0o111 != 0 {
+			perm = 0o755
+		}
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := extractZipFile(f, target, perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string, perm fs.FileMode) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, rc)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
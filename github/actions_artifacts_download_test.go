@@ -0,0 +1,289 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+)
+
+// testArtifactZip returns a zip archive with the given entries. Entries
+// whose name ends in "/" are directories.
+func testArtifactZip(t *testing.T, entries ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e[0], Method: zip.Deflate}
+		if e[0] == "bin/tool" {
+			hdr.SetMode(0o755)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestActionsService_ExtractArtifact(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	archive := testArtifactZip(t,
+		[2]string{"dist/", ""},
+		[2]string{"dist/app.js", "console.log(1)"},
+		[2]string{"bin/tool", "#!/bin/sh"},
+	)
+	mux.HandleFunc("/repos/o/r/actions/artifacts/1/zip", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Redirect(w, r, serverURL+baseURLPath+"/storage/artifact.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/artifact.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	artifact := &Artifact{ID: Int64(1), Name: String("build"), SizeInBytes: Int64(int64(len(archive)))}
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", artifact, dir); err != nil {
+		t.Fatalf("Actions.ExtractArtifact returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "dist", "app.js"))
+	if err != nil || string(got) != "console.log(1)" {
+		t.Errorf("dist/app.js = %q, %v; want console.log(1)", got, err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "bin", "tool"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o755 {
+		t.Errorf("bin/tool has mode %v, want 0755", info.Mode().Perm())
+	}
+
+	// Artifacts uploaded by older versions of actions/upload-artifact
+	// report the total size of their files.
+	artifact.SizeInBytes = Int64(int64(len("console.log(1)") + len("#!/bin/sh")))
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", artifact, dir); err != nil {
+		t.Errorf("Actions.ExtractArtifact with uncompressed size returned error: %v", err)
+	}
+
+	artifact.SizeInBytes = Int64(3)
+	err = client.Actions.ExtractArtifact(ctx, "o", "r", artifact, dir)
+	var sizeErr *ArtifactSizeMismatchError
+	if !errors.As(err, &sizeErr) || sizeErr.Want != 3 || sizeErr.Got != int64(len(archive)) {
+		t.Errorf("Actions.ExtractArtifact returned %v, want an ArtifactSizeMismatchError", err)
+	}
+
+	const methodName = "ExtractArtifact"
+	testBadOptions(t, methodName, func() (err error) {
+		return client.Actions.ExtractArtifact(ctx, "\n", "\n", &Artifact{ID: Int64(1)}, dir)
+	})
+}
+
+func TestActionsService_ExtractArtifact_invalidPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testArtifactZip(t, [2]string{"ok.txt", "ok"}, [2]string{"../escape.txt", "bad"})
+	mux.HandleFunc("/repos/o/r/actions/artifacts/1/zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "out")
+	ctx := context.Background()
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", &Artifact{ID: Int64(1)}, dir); err == nil {
+		t.Fatal("Actions.ExtractArtifact returned nil error")
+	}
+	for _, name := range []string{filepath.Join(dir, "ok.txt"), filepath.Join(parent, "escape.txt")} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Errorf("%v was extracted", name)
+		}
+	}
+}
+
+func TestActionsService_ExtractArtifact_existingSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testArtifactZip(t, [2]string{"sub/evil.txt", "bad"}, [2]string{"link/", ""})
+	mux.HandleFunc("/repos/o/r/actions/artifacts/1/zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "out")
+	elsewhere := filepath.Join(parent, "elsewhere")
+	for _, d := range []string{dir, elsewhere} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(elsewhere, filepath.Join(dir, "sub")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(elsewhere, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", &Artifact{ID: Int64(1)}, dir); err == nil {
+		t.Error("Actions.ExtractArtifact returned nil error, want error")
+	}
+	if _, err := os.Lstat(filepath.Join(elsewhere, "evil.txt")); err == nil {
+		t.Error("Actions.ExtractArtifact wrote through a symbolic link")
+	}
+
+	// A directory entry that is a symbolic link is rejected too.
+	archive = testArtifactZip(t, [2]string{"link/", ""})
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", &Artifact{ID: Int64(1)}, dir); err == nil {
+		t.Error("Actions.ExtractArtifact returned nil error for a directory that is a symbolic link, want error")
+	}
+}
+
+func TestActionsService_ExtractArtifact_expired(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	artifact := &Artifact{ID: Int64(1), Name: String("build"), Expired: Bool(true)}
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", artifact, t.TempDir()); err == nil {
+		t.Error("Actions.ExtractArtifact returned nil error for an expired artifact")
+	}
+	if err := client.Actions.ExtractArtifact(ctx, "o", "r", nil, t.TempDir()); err == nil {
+		t.Error("Actions.ExtractArtifact returned nil error for a nil artifact")
+	}
+}
+
+func TestActionsService_GetArtifactFS(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testArtifactZip(t,
+		[2]string{"dist/app.js", "console.log(1)"},
+		[2]string{"report.txt", "ok"},
+	)
+	mux.HandleFunc("/repos/o/r/actions/artifacts/1/zip", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write(archive)
+	})
+
+	ctx := context.Background()
+	fsys, err := client.Actions.GetArtifactFS(ctx, "o", "r", &Artifact{ID: Int64(1), SizeInBytes: Int64(int64(len(archive)))})
+	if err != nil {
+		t.Fatalf("Actions.GetArtifactFS returned error: %v", err)
+	}
+	if err := fstest.TestFS(fsys, "dist/app.js", "report.txt"); err != nil {
+		t.Error(err)
+	}
+	if got, err := fs.ReadFile(fsys, "report.txt"); err != nil || string(got) != "ok" {
+		t.Errorf("report.txt = %q, %v; want ok", got, err)
+	}
+
+	const methodName = "GetArtifactFS"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.GetArtifactFS(ctx, "\n", "\n", &Artifact{ID: Int64(1)})
+		return err
+	})
+}
+
+func TestActionsService_FindLatestArtifact(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/workflows/build.yml/runs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("page") == "2" {
+			testFormValues(t, r, values{"branch": "main", "status": "success", "per_page": "100", "page": "2"})
+			fmt.Fprint(w, `{"total_count":3,"workflow_runs":[{"id":7}]}`)
+			return
+		}
+		testFormValues(t, r, values{"branch": "main", "status": "success", "per_page": "100"})
+		w.Header().Set("Link", `<https://api.github.com/repos/o/r/actions/workflows/build.yml/runs?page=2>; rel="next"`)
+		fmt.Fprint(w, `{"total_count":3,"workflow_runs":[{"id":9},{"id":8}]}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/9/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"artifacts":[{"id":5,"name":"coverage"}]}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/8/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"artifacts":[{"id":4,"name":"dist","expired":true}]}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/7/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("page") == "2" {
+			fmt.Fprint(w, `{"artifacts":[{"id":2,"name":"dist"},{"id":3,"name":"old","expired":true}]}`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/o/r/actions/runs/7/artifacts?page=2>; rel="next"`)
+		fmt.Fprint(w, `{"artifacts":[{"id":1,"name":"coverage"}]}`)
+	})
+
+	ctx := context.Background()
+	artifact, err := client.Actions.FindLatestArtifact(ctx, "o", "r", "build.yml", "main", "dist")
+	if err != nil {
+		t.Fatalf("Actions.FindLatestArtifact returned error: %v", err)
+	}
+	if artifact.GetID() != 2 {
+		t.Errorf("Actions.FindLatestArtifact returned artifact %v, want 2", artifact.GetID())
+	}
+
+	artifact, err = client.Actions.FindLatestArtifact(ctx, "o", "r", "build.yml", "main", "coverage")
+	if err != nil {
+		t.Fatalf("Actions.FindLatestArtifact returned error: %v", err)
+	}
+	if artifact.GetID() != 5 {
+		t.Errorf("Actions.FindLatestArtifact returned artifact %v, want 5", artifact.GetID())
+	}
+
+	for _, name := range []string{"old", "missing"} {
+		if _, err := client.Actions.FindLatestArtifact(ctx, "o", "r", "build.yml", "main", name); err == nil {
+			t.Errorf("Actions.FindLatestArtifact(%q) returned nil error", name)
+		}
+	}
+
+	const methodName = "FindLatestArtifact"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.FindLatestArtifact(ctx, "\n", "\n", "\n", "main", "dist")
+		return err
+	})
+}
+
+func TestActionsService_FindLatestArtifact_noRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/workflows/build.yml/runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count":0,"workflow_runs":[]}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.Actions.FindLatestArtifact(ctx, "o", "r", "build.yml", "main", "dist"); err == nil {
+		t.Error("Actions.FindLatestArtifact returned nil error")
+	}
+}
@@ -247,3 +247,102 @@ func (s *ActionsService) GetTotalCacheUsageForEnterprise(ctx context.Context, en
 
 	return cacheUsage, res, err
 }
+
+// ActionsCacheUsagePolicy represents the GitHub Actions cache usage policy of a repository.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#get-github-actions-cache-usage-policy-for-a-repository
+type ActionsCacheUsagePolicy struct {
+	RepoCacheSizeLimitInGB *int `json:"repo_cache_size_limit_in_gb,omitempty"`
+}
+
+// EnterpriseActionsCacheUsagePolicy represents the GitHub Actions cache usage policy of an enterprise.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#get-github-actions-cache-usage-policy-for-an-enterprise
+type EnterpriseActionsCacheUsagePolicy struct {
+	// RepoCacheSizeLimitInGB is the default size limit for the sum of all caches in a repository.
+	RepoCacheSizeLimitInGB *int `json:"repo_cache_size_limit_in_gb,omitempty"`
+	// MaxRepoCacheSizeLimitInGB is the maximum size limit that can be set for the sum of all caches in a repository.
+	MaxRepoCacheSizeLimitInGB *int `json:"max_repo_cache_size_limit_in_gb,omitempty"`
+}
+
+// GetCacheUsagePolicyForRepo gets the GitHub Actions cache usage policy for a repository.
+//
+// Permissions: You must authenticate using an access token with the repo scope to use this endpoint.
+// GitHub Apps must have the actions:read permission to use this endpoint.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#get-github-actions-cache-usage-policy-for-a-repository
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/cache/usage-policy
+func (s *ActionsService) GetCacheUsagePolicyForRepo(ctx context.Context, owner, repo string) (*ActionsCacheUsagePolicy, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/cache/usage-policy", owner, repo)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy := new(ActionsCacheUsagePolicy)
+	res, err := s.client.Do(ctx, req, policy)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return policy, res, err
+}
+
+// UpdateCacheUsagePolicyForRepo sets the GitHub Actions cache usage policy for a repository.
+//
+// Permissions: You must authenticate using an access token with the repo scope to use this endpoint.
+// GitHub Apps must have the actions:write permission to use this endpoint.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#set-github-actions-cache-usage-policy-for-a-repository
+//
+//meta:operation PATCH /repos/{owner}/{repo}/actions/cache/usage-policy
+func (s *ActionsService) UpdateCacheUsagePolicyForRepo(ctx context.Context, owner, repo string, policy *ActionsCacheUsagePolicy) (*Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/cache/usage-policy", owner, repo)
+	req, err := s.client.NewRequest("PATCH", u, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// GetCacheUsagePolicyForEnterprise gets the GitHub Actions cache usage policy for an enterprise.
+//
+// Permissions: You must authenticate using an access token with the "admin:enterprise" scope to use this endpoint.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#get-github-actions-cache-usage-policy-for-an-enterprise
+//
+//meta:operation GET /enterprises/{enterprise}/actions/cache/usage-policy
+func (s *ActionsService) GetCacheUsagePolicyForEnterprise(ctx context.Context, enterprise string) (*EnterpriseActionsCacheUsagePolicy, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/cache/usage-policy", enterprise)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy := new(EnterpriseActionsCacheUsagePolicy)
+	res, err := s.client.Do(ctx, req, policy)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return policy, res, err
+}
+
+// UpdateCacheUsagePolicyForEnterprise sets the GitHub Actions cache usage policy for an enterprise.
+//
+// Permissions: You must authenticate using an access token with the "admin:enterprise" scope to use this endpoint.
+//
+// GitHub API docs: https://docs.github.com/enterprise-server@3.10/rest/actions/cache#set-github-actions-cache-usage-policy-for-an-enterprise
+//
+//meta:operation PATCH /enterprises/{enterprise}/actions/cache/usage-policy
+func (s *ActionsService) UpdateCacheUsagePolicyForEnterprise(ctx context.Context, enterprise string, policy *EnterpriseActionsCacheUsagePolicy) (*Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/cache/usage-policy", enterprise)
+	req, err := s.client.NewRequest("PATCH", u, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+// PruneCachesOptions specifies the parameters to ActionsService.PruneCaches.
+//
+// KeyPrefix and Refs select the caches that may be deleted. Of those,
+// OlderThan and MaxSizeInBytes choose the ones that are deleted; if neither
+// is set, all selected caches are deleted.
+type PruneCachesOptions struct {
+	// KeyPrefix selects the caches whose key starts with it.
+	KeyPrefix string
+
+	// Refs selects the caches whose ref matches any of these patterns, in
+	// the syntax of path.Match, such as "refs/pull/123/merge" for the
+	// caches of a closed pull request.
+	Refs []string
+
+	// OlderThan deletes the selected caches that have not been accessed
+	// for at least this long.
+	OlderThan time.Duration
+
+	// MaxSizeInBytes deletes the least recently accessed selected caches
+	// until the total size of all caches of the repository is at most
+	// this many bytes.
+	MaxSizeInBytes int64
+
+	// DryRun reports the caches that would be deleted without deleting
+	// them.
+	DryRun bool
+}
+
+// PruneCachesResult is the outcome of pruning caches.
+type PruneCachesResult struct {
+	// Deleted are the caches that were deleted, or would be deleted in a
+	// dry run, least recently accessed first.
+	Deleted []*ActionsCache
+
+	// DeletedSizeInBytes is the total size of the caches in Deleted.
+	DeletedSizeInBytes int64
+
+	// Errors holds the errors deleting caches, keyed by cache ID. Caches
+	// that failed are not included in Deleted.
+	Errors map[int64]error
+}
+
+// PruneCaches deletes GitHub Actions caches of a repository by age, key
+// prefix, ref or to get under a size budget, as specified by opts. A failure
+// to delete one cache does not stop the others from being deleted; it is
+// recorded in the Errors of the result.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/cache#delete-a-github-actions-cache-for-a-repository-using-a-cache-id
+// GitHub API docs: https://docs.github.com/rest/actions/cache#get-github-actions-cache-usage-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/actions/cache#list-github-actions-caches-for-a-repository
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/cache/usage
+//meta:operation GET /repos/{owner}/{repo}/actions/caches
+//meta:operation DELETE /repos/{owner}/{repo}/actions/caches/{cache_id}
+func (s *ActionsService) PruneCaches(ctx context.Context, owner, repo string, opts *PruneCachesOptions) (*PruneCachesResult, error) {
+	if opts == nil || opts.KeyPrefix == "" && len(opts.Refs) == 0 && opts.OlderThan <= 0 && opts.MaxSizeInBytes <= 0 {
+		return nil, errors.New("caches to prune must be specified")
+	}
+	for _, ref := range opts.Refs {
+		if _, err := path.Match(ref, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// Caches are listed most recently accessed first and pruned in
+	// reverse order.
+	var (
+		caches []*ActionsCache
+		total  int64
+	)
+	lo := &ActionsCacheListOptions{
+		ListOptions: ListOptions{PerPage: 100},
+		Sort:        String("last_accessed_at"),
+		Direction:   String("desc"),
+	}
+	if opts.KeyPrefix != "" {
+		lo.Key = String(opts.KeyPrefix)
+	}
+	for {
+		var (
+			list *ActionsCacheList
+			resp *Response
+		)
+		err := RetryOnRateLimit(ctx, func() (_ *Response, err error) {
+			list, resp, err = s.ListCaches(ctx, owner, repo, lo)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		caches = append(caches, list.ActionsCaches...)
+		if resp.NextPage == 0 {
+			break
+		}
+		lo.Page = resp.NextPage
+	}
+	if opts.MaxSizeInBytes > 0 {
+		if opts.KeyPrefix != "" {
+			// The listing above only includes caches with the prefix.
+			usage, _, err := s.GetCacheUsageForRepo(ctx, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			total = usage.ActiveCachesSizeInBytes
+		} else {
+			for _, c := range caches {
+				total += c.GetSizeInBytes()
+			}
+		}
+	}
+
+	var cutoff time.Time
+	if opts.OlderThan > 0 {
+		cutoff = time.Now().Add(-opts.OlderThan)
+	}
+	result := &PruneCachesResult{Errors: make(map[int64]error)}
+	for i := len(caches) - 1; i >= 0; i-- {
+		c := caches[i]
+		if !opts.selects(c) {
+			continue
+		}
+		var prune bool
+		switch {
+		case opts.OlderThan <= 0 && opts.MaxSizeInBytes <= 0:
+			prune = true
+		case opts.OlderThan > 0 && c.GetLastAccessedAt().Before(cutoff):
+			prune = true
+		case opts.MaxSizeInBytes > 0 && total > opts.MaxSizeInBytes:
+			prune = true
+		}
+		if !prune {
+			continue
+		}
+
+		if !opts.DryRun {
+			err := RetryOnRateLimit(ctx, func() (*Response, error) {
+				return s.DeleteCachesByID(ctx, owner, repo, c.GetID())
+			})
+			if err != nil {
+				result.Errors[c.GetID()] = err
+				continue
+			}
+		}
+		total -= c.GetSizeInBytes()
+		result.Deleted = append(result.Deleted, c)
+		result.DeletedSizeInBytes += c.GetSizeInBytes()
+	}
+	return result, nil
+}
+
+// selects reports whether cache is selected by the KeyPrefix and Refs of
+// opts.
+func (opts *PruneCachesOptions) selects(cache *ActionsCache) bool {
+	if !strings.HasPrefix(cache.GetKey(), opts.KeyPrefix) {
+		return false
+	}
+	if len(opts.Refs) == 0 {
+		return true
+	}
+	for _, ref := range opts.Refs {
+		if ok, _ := path.Match(ref, cache.GetRef()); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testPruneCachesMux(t *testing.T, mux *http.ServeMux, deleted map[int64]bool) {
+	t.Helper()
+
+	now := time.Now()
+	type cache struct {
+		id       int64
+		key, ref string
+		accessed time.Duration
+		size     int64
+	}
+	pages := [][]cache{
+		{
+			{1, "npm-a", "refs/heads/main", time.Hour, 100},
+			{2, "go-a", "refs/pull/7/merge", 2 * time.Hour, 200},
+		},
+		{
+			{3, "npm-b", "refs/pull/7/merge", 48 * time.Hour, 300},
+			{4, "npm-c", "refs/heads/main", 72 * time.Hour, 400},
+		},
+	}
+
+	mux.HandleFunc("/repos/o/r/actions/caches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.FormValue("sort") + " " + r.FormValue("direction"); got != "last_accessed_at desc" {
+			t.Errorf("caches listed by %q", got)
+		}
+		page := pages[0]
+		if r.FormValue("page") == "2" {
+			page = pages[1]
+		} else {
+			w.Header().Set("Link", `</repos/o/r/actions/caches?page=2>; rel="next"`)
+		}
+		var caches []string
+		for _, c := range page {
+			if !strings.HasPrefix(c.key, r.FormValue("key")) {
+				continue
+			}
+			caches = append(caches, fmt.Sprintf(`{"id":%v,"key":%q,"ref":%q,"last_accessed_at":%q,"size_in_bytes":%v}`,
+				c.id, c.key, c.ref, now.Add(-c.accessed).Format(time.RFC3339), c.size))
+		}
+		fmt.Fprintf(w, `{"total_count":4,"actions_caches":[%v]}`, strings.Join(caches, ","))
+	})
+	mux.HandleFunc("/repos/o/r/actions/cache/usage", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"full_name":"o/r","active_caches_size_in_bytes":1000,"active_caches_count":4}`)
+	})
+	for _, id := range []int64{1, 2, 3, 4} {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/repos/o/r/actions/caches/%v", id), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "DELETE")
+			if id == 4 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			deleted[id] = true
+		})
+	}
+}
+
+func prunedCacheIDs(result *PruneCachesResult) []int64 {
+	var ids []int64
+	for _, c := range result.Deleted {
+		ids = append(ids, c.GetID())
+	}
+	return ids
+}
+
+func TestActionsService_PruneCaches(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	deleted := make(map[int64]bool)
+	testPruneCachesMux(t, mux, deleted)
+
+	ctx := context.Background()
+	result, err := client.Actions.PruneCaches(ctx, "o", "r", &PruneCachesOptions{OlderThan: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Actions.PruneCaches returned error: %v", err)
+	}
+
+	if want := map[int64]bool{3: true}; !cmp.Equal(deleted, want) {
+		t.Errorf("deleted caches = %v, want %v", deleted, want)
+	}
+	if got, want := prunedCacheIDs(result), []int64{3}; !cmp.Equal(got, want) {
+		t.Errorf("Deleted = %v, want %v", got, want)
+	}
+	if result.DeletedSizeInBytes != 300 {
+		t.Errorf("DeletedSizeInBytes = %v, want 300", result.DeletedSizeInBytes)
+	}
+	if len(result.Errors) != 1 || result.Errors[4] == nil {
+		t.Errorf("Errors = %v, want an error for cache 4", result.Errors)
+	}
+
+	const methodName = "PruneCaches"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.PruneCaches(ctx, "\n", "\n", &PruneCachesOptions{OlderThan: time.Hour})
+		return err
+	})
+}
+
+func TestActionsService_PruneCaches_dryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	deleted := make(map[int64]bool)
+	testPruneCachesMux(t, mux, deleted)
+
+	tests := []struct {
+		opts *PruneCachesOptions
+		want []int64
+	}{
+		{&PruneCachesOptions{Refs: []string{"refs/pull/7/*"}}, []int64{3, 2}},
+		{&PruneCachesOptions{MaxSizeInBytes: 500}, []int64{4, 3}},
+		{&PruneCachesOptions{MaxSizeInBytes: 100, OlderThan: 24 * time.Hour, Refs: []string{"refs/heads/*"}}, []int64{4, 1}},
+		{&PruneCachesOptions{KeyPrefix: "npm-", MaxSizeInBytes: 700}, []int64{4}},
+		{&PruneCachesOptions{KeyPrefix: "go-"}, []int64{2}},
+	}
+	for i, tt := range tests {
+		tt.opts.DryRun = true
+		result, err := client.Actions.PruneCaches(context.Background(), "o", "r", tt.opts)
+		if err != nil {
+			t.Fatalf("#%v: Actions.PruneCaches returned error: %v", i, err)
+		}
+		if got := prunedCacheIDs(result); !cmp.Equal(got, tt.want) {
+			t.Errorf("#%v: Deleted = %v, want %v", i, got, tt.want)
+		}
+	}
+	if len(deleted) != 0 {
+		t.Errorf("caches were deleted in a dry run: %v", deleted)
+	}
+}
+
+func TestActionsService_PruneCaches_invalidOptions(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	for _, opts := range []*PruneCachesOptions{
+		nil,
+		{DryRun: true},
+		{Refs: []string{"refs/pull/["}},
+	} {
+		if _, err := client.Actions.PruneCaches(ctx, "o", "r", opts); err == nil {
+			t.Errorf("PruneCaches(%+v) returned nil error", opts)
+		}
+	}
+}
@@ -645,3 +645,157 @@ func TestTotalCacheUsage_Marshal(t *testing.T) {
 
 	testJSONMarshal(t, u, want)
 }
+
+func TestActionsService_GetCacheUsagePolicyForRepo(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/cache/usage-policy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"repo_cache_size_limit_in_gb":14}`)
+	})
+
+	ctx := context.Background()
+	policy, _, err := client.Actions.GetCacheUsagePolicyForRepo(ctx, "o", "r")
+	if err != nil {
+		t.Errorf("Actions.GetCacheUsagePolicyForRepo returned error: %v", err)
+	}
+
+	want := &ActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: Int(14)}
+	if !cmp.Equal(policy, want) {
+		t.Errorf("Actions.GetCacheUsagePolicyForRepo returned %+v, want %+v", policy, want)
+	}
+
+	const methodName = "GetCacheUsagePolicyForRepo"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.GetCacheUsagePolicyForRepo(ctx, "\n", "\n")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.GetCacheUsagePolicyForRepo(ctx, "o", "r")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_UpdateCacheUsagePolicyForRepo(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/cache/usage-policy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"repo_cache_size_limit_in_gb":20}`+"\n")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	policy := &ActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: Int(20)}
+	_, err := client.Actions.UpdateCacheUsagePolicyForRepo(ctx, "o", "r", policy)
+	if err != nil {
+		t.Errorf("Actions.UpdateCacheUsagePolicyForRepo returned error: %v", err)
+	}
+
+	const methodName = "UpdateCacheUsagePolicyForRepo"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.UpdateCacheUsagePolicyForRepo(ctx, "\n", "\n", policy)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Actions.UpdateCacheUsagePolicyForRepo(ctx, "o", "r", policy)
+	})
+}
+
+func TestActionsService_GetCacheUsagePolicyForEnterprise(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/cache/usage-policy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"repo_cache_size_limit_in_gb":10,"max_repo_cache_size_limit_in_gb":15}`)
+	})
+
+	ctx := context.Background()
+	policy, _, err := client.Actions.GetCacheUsagePolicyForEnterprise(ctx, "e")
+	if err != nil {
+		t.Errorf("Actions.GetCacheUsagePolicyForEnterprise returned error: %v", err)
+	}
+
+	want := &EnterpriseActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: Int(10), MaxRepoCacheSizeLimitInGB: Int(15)}
+	if !cmp.Equal(policy, want) {
+		t.Errorf("Actions.GetCacheUsagePolicyForEnterprise returned %+v, want %+v", policy, want)
+	}
+
+	const methodName = "GetCacheUsagePolicyForEnterprise"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.GetCacheUsagePolicyForEnterprise(ctx, "\n")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.GetCacheUsagePolicyForEnterprise(ctx, "e")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_UpdateCacheUsagePolicyForEnterprise(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/cache/usage-policy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"repo_cache_size_limit_in_gb":10,"max_repo_cache_size_limit_in_gb":15}`+"\n")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	policy := &EnterpriseActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: Int(10), MaxRepoCacheSizeLimitInGB: Int(15)}
+	_, err := client.Actions.UpdateCacheUsagePolicyForEnterprise(ctx, "e", policy)
+	if err != nil {
+		t.Errorf("Actions.UpdateCacheUsagePolicyForEnterprise returned error: %v", err)
+	}
+
+	const methodName = "UpdateCacheUsagePolicyForEnterprise"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.UpdateCacheUsagePolicyForEnterprise(ctx, "\n", policy)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Actions.UpdateCacheUsagePolicyForEnterprise(ctx, "e", policy)
+	})
+}
+
+func TestActionsCacheUsagePolicy_Marshal(t *testing.T) {
+	testJSONMarshal(t, &ActionsCacheUsagePolicy{}, "{}")
+
+	u := &ActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: Int(14)}
+
+	want := `{
+		"repo_cache_size_limit_in_gb": 14
+	}`
+
+	testJSONMarshal(t, u, want)
+}
+
+func TestEnterpriseActionsCacheUsagePolicy_Marshal(t *testing.T) {
+	testJSONMarshal(t, &EnterpriseActionsCacheUsagePolicy{}, "{}")
+
+	u := &EnterpriseActionsCacheUsagePolicy{
+		RepoCacheSizeLimitInGB:    Int(10),
+		MaxRepoCacheSizeLimitInGB: Int(15),
+	}
+
+	want := `{
+		"repo_cache_size_limit_in_gb": 10,
+		"max_repo_cache_size_limit_in_gb": 15
+	}`
+
+	testJSONMarshal(t, u, want)
+}
@@ -0,0 +1,474 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultActionsOIDCIssuer is the issuer of the OIDC tokens GitHub Actions
+// issues to workflows on github.com.
+const DefaultActionsOIDCIssuer = "https://token.actions.githubusercontent.com"
+
+const (
+	defaultOIDCKeyCacheTTL = time.Hour
+	defaultOIDCLeeway      = time.Minute
+
+	// minOIDCKeyRefetchInterval limits how often tokens signed with an
+	// unknown key ID cause the keys to be fetched again.
+	minOIDCKeyRefetchInterval = time.Minute
+
+	maxOIDCDocumentSize = 1 << 20
+)
+
+// ActionsOIDCVerifierOptions specifies the parameters to
+// NewActionsOIDCVerifier.
+type ActionsOIDCVerifierOptions struct {
+	// Issuer is the expected issuer of tokens. Its OpenID configuration is
+	// fetched from Issuer + "/.well-known/openid-configuration". It
+	// defaults to DefaultActionsOIDCIssuer; on GitHub Enterprise Server it
+	// is "https://HOSTNAME/_services/token", and in tests it can be a
+	// local stand-in.
+	Issuer string
+
+	// Audiences are the accepted audiences. A token must be issued for at
+	// least one of them. At least one audience is required.
+	Audiences []string
+
+	// HTTPClient is used to fetch the OpenID configuration and keys of the
+	// issuer. It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// KeyCacheTTL is how long the keys of the issuer are cached. It
+	// defaults to one hour. Keys are fetched again sooner if a token is
+	// signed by a key that is not cached, at most once a minute. If the
+	// keys cannot be fetched again, the cached keys are used until a
+	// later fetch succeeds, which is tried at most once a minute.
+	KeyCacheTTL time.Duration
+
+	// Leeway is the allowed clock skew when checking the expiry, not
+	// before and issued at times of tokens. It defaults to one minute.
+	Leeway time.Duration
+}
+
+// ActionsOIDCClaims are the claims of an OIDC token issued by GitHub
+// Actions.
+//
+// GitHub docs: https://docs.github.com/actions/deployment/security-hardening-your-deployments/about-security-hardening-with-openid-connect#understanding-the-oidc-token
+type ActionsOIDCClaims struct {
+	Issuer    string    `json:"iss"`
+	Subject   string    `json:"sub"`
+	Audience  []string  `json:"-"`
+	ExpiresAt Timestamp `json:"exp"`
+	NotBefore Timestamp `json:"nbf"`
+	IssuedAt  Timestamp `json:"iat"`
+	ID        string    `json:"jti"`
+
+	Actor                string `json:"actor"`
+	ActorID              string `json:"actor_id"`
+	BaseRef              string `json:"base_ref"`
+	Environment          string `json:"environment"`
+	EventName            string `json:"event_name"`
+	HeadRef              string `json:"head_ref"`
+	JobWorkflowRef       string `json:"job_workflow_ref"`
+	JobWorkflowSHA       string `json:"job_workflow_sha"`
+	Ref                  string `json:"ref"`
+	RefProtected         string `json:"ref_protected"`
+	RefType              string `json:"ref_type"`
+	Repository           string `json:"repository"`
+	RepositoryID         string `json:"repository_id"`
+	RepositoryOwner      string `json:"repository_owner"`
+	RepositoryOwnerID    string `json:"repository_owner_id"`
+	RepositoryVisibility string `json:"repository_visibility"`
+	RunAttempt           string `json:"run_attempt"`
+	RunID                string `json:"run_id"`
+	RunNumber            string `json:"run_number"`
+	RunnerEnvironment    string `json:"runner_environment"`
+	SHA                  string `json:"sha"`
+	Workflow             string `json:"workflow"`
+	WorkflowRef          string `json:"workflow_ref"`
+	WorkflowSHA          string `json:"workflow_sha"`
+
+	// Raw holds all claims of the token, including custom ones.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The audience of a token may be a single string or an array of strings.
+func (c *ActionsOIDCClaims) UnmarshalJSON(data []byte) error {
+	type aliasClaims ActionsOIDCClaims
+	aux := struct {
+		*aliasClaims
+		Audience json.RawMessage `json:"aud"`
+	}{aliasClaims: (*aliasClaims)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.Audience = nil
+	if len(aux.Audience) > 0 && aux.Audience[0] == '[' {
+		if err := json.Unmarshal(aux.Audience, &c.Audience); err != nil {
+			return err
+		}
+	} else if len(aux.Audience) > 0 {
+		var aud string
+		if err := json.Unmarshal(aux.Audience, &aud); err != nil {
+			return err
+		}
+		c.Audience = []string{aud}
+	}
+	return json.Unmarshal(data, &c.Raw)
+}
+
+// ActionsOIDCVerifier verifies OIDC tokens issued by GitHub Actions, for
+// services that grant workflows access based on their identity. It is safe
+// for concurrent use.
+type ActionsOIDCVerifier struct {
+	issuer     string
+	audiences  []string
+	httpClient *http.Client
+	ttl        time.Duration
+	leeway     time.Duration
+	now        func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetchErr    error
+	// fetching is closed when the fetch in progress, if any, completes.
+	fetching chan struct{}
+}
+
+// NewActionsOIDCVerifier returns a verifier for tokens issued by
+// opts.Issuer for one of opts.Audiences. Keys are fetched when the first
+// token is verified.
+func NewActionsOIDCVerifier(opts *ActionsOIDCVerifierOptions) (*ActionsOIDCVerifier, error) {
+	if opts == nil || len(opts.Audiences) == 0 {
+		return nil, errors.New("at least one audience must be provided")
+	}
+	v := &ActionsOIDCVerifier{
+		issuer:     strings.TrimSuffix(opts.Issuer, "/"),
+		audiences:  opts.Audiences,
+		httpClient: opts.HTTPClient,
+		ttl:        opts.KeyCacheTTL,
+		leeway:     opts.Leeway,
+		now:        time.Now,
+	}
+	if v.issuer == "" {
+		v.issuer = DefaultActionsOIDCIssuer
+	}
+	if v.httpClient == nil {
+		v.httpClient = http.DefaultClient
+	}
+	if v.ttl <= 0 {
+		v.ttl = defaultOIDCKeyCacheTTL
+	}
+	if v.leeway <= 0 {
+		v.leeway = defaultOIDCLeeway
+	}
+	return v, nil
+}
+
+// Verify checks the RS256 signature of token against the keys of the
+// issuer and checks its issuer, audience, expiry, not before and issued at
+// claims. It returns the claims of a valid token.
+func (v *ActionsOIDCVerifier) Verify(ctx context.Context, token string) (*ActionsOIDCClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("oidc: malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("oidc: malformed token header: %v", err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("oidc: unsupported signing algorithm %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("oidc: malformed token signature: %v", err)
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, errors.New("oidc: invalid token signature")
+	}
+
+	claims := new(ActionsOIDCClaims)
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return nil, fmt.Errorf("oidc: malformed token claims: %v", err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *ActionsOIDCVerifier) checkClaims(c *ActionsOIDCClaims) error {
+	if c.Issuer != v.issuer {
+		return fmt.Errorf("oidc: token issued by %q, want %q", c.Issuer, v.issuer)
+	}
+	ok := false
+	for _, aud := range c.Audience {
+		if containsString(v.audiences, aud) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("oidc: token audience %q is not accepted", c.Audience)
+	}
+
+	now := v.now()
+	switch {
+	case c.ExpiresAt.IsZero():
+		return errors.New("oidc: token has no expiry")
+	case !now.Before(c.ExpiresAt.Add(v.leeway)):
+		return fmt.Errorf("oidc: token expired at %v", c.ExpiresAt)
+	case !c.NotBefore.IsZero() && now.Before(c.NotBefore.Add(-v.leeway)):
+		return fmt.Errorf("oidc: token is not valid before %v", c.NotBefore)
+	case !c.IssuedAt.IsZero() && now.Before(c.IssuedAt.Add(-v.leeway)):
+		return fmt.Errorf("oidc: token issued in the future at %v", c.IssuedAt)
+	}
+	return nil
+}
+
+// key returns the key with ID kid, fetching the keys of the issuer if they
+// are not cached, have expired, or do not include kid. Concurrent callers
+// share a single fetch, and the lock is not held while fetching.
+func (v *ActionsOIDCVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	for {
+		v.mu.Lock()
+		now := v.now()
+		key, ok := v.keys[kid]
+		stale := v.keys == nil || now.Sub(v.fetchedAt) >= v.ttl
+		if ok && !stale {
+			v.mu.Unlock()
+			return key, nil
+		}
+
+		// Failed fetches and fetches for unknown key IDs are limited to
+		// one a minute.
+		canFetch := stale && v.fetchErr == nil || now.Sub(v.attemptedAt) >= minOIDCKeyRefetchInterval
+		if !canFetch {
+			cached, err := v.keys != nil, v.fetchErr
+			v.mu.Unlock()
+			switch {
+			case ok:
+				return key, nil
+			case !cached && err != nil:
+				return nil, err
+			}
+			return nil, fmt.Errorf("oidc: token signed by unknown key %q", kid)
+		}
+
+		if wait := v.fetching; wait != nil {
+			v.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-wait:
+			}
+			continue
+		}
+		done := make(chan struct{})
+		v.fetching = done
+		v.mu.Unlock()
+
+		keys, err := v.fetchKeys(ctx)
+
+		v.mu.Lock()
+		// A fetch abandoned by its caller does not count as an attempt.
+		if ctx.Err() == nil {
+			v.attemptedAt, v.fetchErr = now, err
+			if err == nil {
+				v.keys, v.fetchedAt = keys, now
+			}
+		}
+		v.fetching = nil
+		close(done)
+		v.mu.Unlock()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+	}
+}
+
+// fetchKeys fetches the RSA keys of the issuer, keyed by key ID.
+func (v *ActionsOIDCVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var config struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.fetchJSON(ctx, v.issuer+"/.well-known/openid-configuration", &config); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != v.issuer {
+		return nil, fmt.Errorf("oidc: configuration is for issuer %q, want %q", config.Issuer, v.issuer)
+	}
+	if config.JWKSURI == "" {
+		return nil, errors.New("oidc: configuration has no jwks_uri")
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := v.fetchJSON(ctx, config.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: malformed key %q: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("oidc: malformed exponent of key %q", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func (v *ActionsOIDCVerifier) fetchJSON(ctx context.Context, u string, out interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: fetching %v: unexpected status code: %v", u, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOIDCDocumentSize))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("oidc: decoding %v: %v", u, err)
+	}
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ActionsOIDCPolicy restricts which workflows are accepted based on the
+// claims of their OIDC tokens. Each field lists patterns, in the syntax of
+// path.Match, one of which the corresponding claim must match; an empty
+// field accepts any value. As in path.Match, "*" does not match "/", so
+// "refs/heads/*" does not match "refs/heads/feature/x".
+type ActionsOIDCPolicy struct {
+	Subject         []string
+	Repository      []string
+	RepositoryOwner []string
+	Ref             []string
+	RefType         []string
+	Environment     []string
+	EventName       []string
+	WorkflowRef     []string
+	JobWorkflowRef  []string
+}
+
+// ActionsOIDCPolicyError is returned by ActionsOIDCPolicy.Check when a
+// claim does not match the policy.
+type ActionsOIDCPolicyError struct {
+	Claim    string
+	Value    string
+	Patterns []string
+}
+
+func (e *ActionsOIDCPolicyError) Error() string {
+	return fmt.Sprintf("oidc: claim %v %q does not match any of %q", e.Claim, e.Value, e.Patterns)
+}
+
+// Check returns an *ActionsOIDCPolicyError for the first claim of c that
+// does not match p, or nil if c is accepted.
+func (p *ActionsOIDCPolicy) Check(c *ActionsOIDCClaims) error {
+	checks := []struct {
+		claim    string
+		value    string
+		patterns []string
+	}{
+		{"sub", c.Subject, p.Subject},
+		{"repository", c.Repository, p.Repository},
+		{"repository_owner", c.RepositoryOwner, p.RepositoryOwner},
+		{"ref", c.Ref, p.Ref},
+		{"ref_type", c.RefType, p.RefType},
+		{"environment", c.Environment, p.Environment},
+		{"event_name", c.EventName, p.EventName},
+		{"workflow_ref", c.WorkflowRef, p.WorkflowRef},
+		{"job_workflow_ref", c.JobWorkflowRef, p.JobWorkflowRef},
+	}
+	for _, check := range checks {
+		if len(check.patterns) > 0 && !matchAnyPattern(check.patterns, check.value) {
+			return &ActionsOIDCPolicyError{Claim: check.claim, Value: check.value, Patterns: check.patterns}
+		}
+	}
+	return nil
+}
+
+// Allows reports whether c is accepted by p.
+func (p *ActionsOIDCPolicy) Allows(c *ActionsOIDCClaims) bool {
+	return p.Check(c) == nil
+}
+
+func matchAnyPattern(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, s); ok {
+			return true
+		}
+	}
+	return false
+}
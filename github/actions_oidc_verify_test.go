@@ -0,0 +1,426 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testOIDCIssuer is a local stand-in for the GitHub Actions OIDC issuer.
+type testOIDCIssuer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	keys       map[string]*rsa.PrivateKey
+	jwksServed int
+	// jwksFail makes the keys endpoint fail, and jwksBlock, if set, is
+	// received from before the keys are served.
+	jwksFail  bool
+	jwksBlock chan struct{}
+}
+
+func newTestOIDCIssuer(t *testing.T) *testOIDCIssuer {
+	t.Helper()
+	iss := &testOIDCIssuer{keys: make(map[string]*rsa.PrivateKey)}
+	iss.addKey(t, "k1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"issuer":%q,"jwks_uri":%q}`, iss.URL, iss.URL+"/.well-known/jwks")
+	})
+	mux.HandleFunc("/.well-known/jwks", func(w http.ResponseWriter, r *http.Request) {
+		iss.mu.Lock()
+		block := iss.jwksBlock
+		iss.mu.Unlock()
+		if block != nil {
+			<-block
+		}
+
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		iss.jwksServed++
+		if iss.jwksFail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var keys []map[string]string
+		keys = append(keys, map[string]string{"kty": "EC", "kid": "ec"})
+		for kid, k := range iss.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+			})
+		}
+		assertNilError(t, json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys}))
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *testOIDCIssuer) addKey(t *testing.T, kid string) {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertNilError(t, err)
+	iss.mu.Lock()
+	iss.keys[kid] = k
+	iss.mu.Unlock()
+}
+
+// sign returns a token with claims signed by the key kid.
+func (iss *testOIDCIssuer) sign(t *testing.T, kid, alg string, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assertNilError(t, err)
+	payload, err := json.Marshal(claims)
+	assertNilError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	iss.mu.Lock()
+	key := iss.keys[kid]
+	iss.mu.Unlock()
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assertNilError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (iss *testOIDCIssuer) claims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":              iss.URL,
+		"aud":              "https://github.com/octo-org",
+		"sub":              "repo:octo-org/octo-repo:environment:prod",
+		"exp":              now.Add(5 * time.Minute).Unix(),
+		"nbf":              now.Add(-time.Minute).Unix(),
+		"iat":              now.Unix(),
+		"jti":              "example-id",
+		"ref":              "refs/heads/main",
+		"ref_type":         "branch",
+		"repository":       "octo-org/octo-repo",
+		"repository_owner": "octo-org",
+		"environment":      "prod",
+		"event_name":       "push",
+		"workflow_ref":     "octo-org/octo-repo/.github/workflows/deploy.yml@refs/heads/main",
+		"job_workflow_ref": "octo-org/workflows/.github/workflows/deploy.yml@refs/tags/v1",
+		"run_id":           "42",
+		"custom":           "value",
+	}
+}
+
+func TestActionsOIDCVerifier_Verify(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{
+		Issuer:    iss.URL + "/",
+		Audiences: []string{"sts.example.com", "https://github.com/octo-org"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	v.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	claims, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now)))
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	want := &ActionsOIDCClaims{
+		Issuer:          iss.URL,
+		Subject:         "repo:octo-org/octo-repo:environment:prod",
+		Audience:        []string{"https://github.com/octo-org"},
+		ExpiresAt:       Timestamp{now.Add(5 * time.Minute)},
+		NotBefore:       Timestamp{now.Add(-time.Minute)},
+		IssuedAt:        Timestamp{now},
+		ID:              "example-id",
+		Environment:     "prod",
+		EventName:       "push",
+		JobWorkflowRef:  "octo-org/workflows/.github/workflows/deploy.yml@refs/tags/v1",
+		Ref:             "refs/heads/main",
+		RefType:         "branch",
+		Repository:      "octo-org/octo-repo",
+		RepositoryOwner: "octo-org",
+		RunID:           "42",
+		WorkflowRef:     "octo-org/octo-repo/.github/workflows/deploy.yml@refs/heads/main",
+	}
+	if claims.Raw["custom"] != "value" {
+		t.Errorf("Raw = %v, want the custom claim", claims.Raw)
+	}
+	claims.Raw = nil
+	if !cmp.Equal(claims, want) {
+		t.Errorf("Verify returned diff (-want +got):\n%v", cmp.Diff(want, claims))
+	}
+
+	// Keys are cached.
+	if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if iss.jwksServed != 1 {
+		t.Errorf("keys were fetched %v times, want 1", iss.jwksServed)
+	}
+}
+
+func TestActionsOIDCVerifier_Verify_invalid(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL, Audiences: []string{"https://github.com/octo-org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	v.now = func() time.Time { return now }
+
+	claims := func(f func(map[string]interface{})) map[string]interface{} {
+		c := iss.claims(now)
+		f(c)
+		return c
+	}
+	valid := iss.sign(t, "k1", "RS256", iss.claims(now))
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"malformed", "a.b", "malformed token"},
+		{"algorithm", iss.sign(t, "k1", "RS512", iss.claims(now)), `unsupported signing algorithm "RS512"`},
+		{"unknown key", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"k9"}`)) + "." + parts[1] + "." + parts[2], `unknown key "k9"`},
+		{"signature", parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"x"}`)) + "." + parts[2], "invalid token signature"},
+		{"issuer", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { c["iss"] = DefaultActionsOIDCIssuer })), "token issued by"},
+		{"audience", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { c["aud"] = []string{"a", "b"} })), `audience ["a" "b"] is not accepted`},
+		{"expired", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { c["exp"] = now.Add(-2 * time.Minute).Unix() })), "token expired"},
+		{"no expiry", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { delete(c, "exp") })), "no expiry"},
+		{"not before", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { c["nbf"] = now.Add(2 * time.Minute).Unix() })), "not valid before"},
+		{"issued at", iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) { c["iat"] = now.Add(2 * time.Minute).Unix() })), "issued in the future"},
+	}
+	for _, tt := range tests {
+		_, err := v.Verify(context.Background(), tt.token)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: Verify returned %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	// Within the leeway, tokens are accepted.
+	token := iss.sign(t, "k1", "RS256", claims(func(c map[string]interface{}) {
+		c["exp"] = now.Add(-30 * time.Second).Unix()
+		c["aud"] = []string{"x", "https://github.com/octo-org"}
+	}))
+	if _, err := v.Verify(context.Background(), token); err != nil {
+		t.Errorf("Verify returned error within leeway: %v", err)
+	}
+}
+
+func TestActionsOIDCVerifier_keyRotation(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL, Audiences: []string{"https://github.com/octo-org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	v.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	// A token signed by a new key is rejected until the keys may be
+	// fetched again.
+	iss.addKey(t, "k2")
+	if _, err := v.Verify(ctx, iss.sign(t, "k2", "RS256", iss.claims(now))); err == nil {
+		t.Error("Verify returned nil error for a new key within the refetch interval")
+	}
+	now = now.Add(time.Minute)
+	if _, err := v.Verify(ctx, iss.sign(t, "k2", "RS256", iss.claims(now))); err != nil {
+		t.Errorf("Verify returned error after key rotation: %v", err)
+	}
+	if iss.jwksServed != 2 {
+		t.Errorf("keys were fetched %v times, want 2", iss.jwksServed)
+	}
+
+	// Expired caches are refreshed.
+	now = now.Add(time.Hour)
+	if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+	if iss.jwksServed != 3 {
+		t.Errorf("keys were fetched %v times, want 3", iss.jwksServed)
+	}
+}
+
+func TestActionsOIDCVerifier_refreshFailure(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL, Audiences: []string{"https://github.com/octo-org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	v.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	// Expired keys are still used while they cannot be fetched, and the
+	// fetch is retried at most once a minute.
+	iss.mu.Lock()
+	iss.jwksFail = true
+	iss.mu.Unlock()
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+			t.Errorf("Verify returned error with a failing issuer: %v", err)
+		}
+	}
+	if iss.jwksServed != 2 {
+		t.Errorf("keys were fetched %v times, want 2", iss.jwksServed)
+	}
+
+	iss.mu.Lock()
+	iss.jwksFail = false
+	iss.mu.Unlock()
+	now = now.Add(time.Minute)
+	if _, err := v.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(now))); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+	if iss.jwksServed != 3 {
+		t.Errorf("keys were fetched %v times, want 3", iss.jwksServed)
+	}
+
+	// Without cached keys, the failure is reported.
+	iss.mu.Lock()
+	iss.jwksFail = true
+	iss.mu.Unlock()
+	v2, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL, Audiences: []string{"https://github.com/octo-org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Verify(ctx, iss.sign(t, "k1", "RS256", iss.claims(time.Now()))); err == nil {
+		t.Error("Verify returned nil error without keys")
+	}
+}
+
+func TestActionsOIDCVerifier_concurrentFetch(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	block := make(chan struct{})
+	iss.jwksBlock = block
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL, Audiences: []string{"https://github.com/octo-org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := iss.sign(t, "k1", "RS256", iss.claims(time.Now()))
+	errs := make(chan error)
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, err := v.Verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	// A caller giving up does not wait for the fetch in progress.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := v.Verify(ctx, token); err == nil {
+		t.Error("Verify returned nil error for a canceled context")
+	}
+
+	close(block)
+	for i := 0; i < 5; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Verify returned error: %v", err)
+		}
+	}
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	if iss.jwksServed != 1 {
+		t.Errorf("keys were fetched %v times, want 1", iss.jwksServed)
+	}
+}
+
+func TestActionsOIDCVerifier_issuerMismatch(t *testing.T) {
+	iss := newTestOIDCIssuer(t)
+	v, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{Issuer: iss.URL + "/other", Audiences: []string{"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(context.Background(), iss.sign(t, "k1", "RS256", iss.claims(time.Now()))); err == nil {
+		t.Error("Verify returned nil error for an issuer without configuration")
+	}
+
+	if _, err := NewActionsOIDCVerifier(&ActionsOIDCVerifierOptions{}); err == nil {
+		t.Error("NewActionsOIDCVerifier returned nil error without audiences")
+	}
+}
+
+func TestActionsOIDCPolicy_Check(t *testing.T) {
+	claims := &ActionsOIDCClaims{
+		Subject:         "repo:octo-org/octo-repo:environment:prod",
+		Repository:      "octo-org/octo-repo",
+		RepositoryOwner: "octo-org",
+		Ref:             "refs/heads/main",
+		RefType:         "branch",
+		Environment:     "prod",
+		EventName:       "push",
+		WorkflowRef:     "octo-org/octo-repo/.github/workflows/deploy.yml@refs/heads/main",
+		JobWorkflowRef:  "octo-org/workflows/.github/workflows/deploy.yml@refs/tags/v1",
+	}
+
+	tests := []struct {
+		policy *ActionsOIDCPolicy
+		want   error
+	}{
+		{&ActionsOIDCPolicy{}, nil},
+		{&ActionsOIDCPolicy{
+			RepositoryOwner: []string{"octo-org"},
+			Repository:      []string{"octo-org/*"},
+			Ref:             []string{"refs/tags/v*", "refs/heads/main"},
+			Environment:     []string{"prod", "staging"},
+			JobWorkflowRef:  []string{"octo-org/workflows/.github/workflows/deploy.yml@refs/tags/*"},
+		}, nil},
+		{
+			&ActionsOIDCPolicy{Repository: []string{"octo-org/*"}, Ref: []string{"refs/heads/release/*"}},
+			&ActionsOIDCPolicyError{Claim: "ref", Value: "refs/heads/main", Patterns: []string{"refs/heads/release/*"}},
+		},
+		{
+			&ActionsOIDCPolicy{Repository: []string{"*"}},
+			&ActionsOIDCPolicyError{Claim: "repository", Value: "octo-org/octo-repo", Patterns: []string{"*"}},
+		},
+	}
+	for i, tt := range tests {
+		err := tt.policy.Check(claims)
+		if !cmp.Equal(err, tt.want) {
+			t.Errorf("#%v: Check returned %v, want %v", i, err, tt.want)
+		}
+		if got := tt.policy.Allows(claims); got != (tt.want == nil) {
+			t.Errorf("#%v: Allows returned %v", i, got)
+		}
+	}
+
+	want := `oidc: claim ref "refs/heads/main" does not match any of ["refs/heads/release/*"]`
+	if got := tests[2].policy.Check(claims).Error(); got != want {
+		t.Errorf("Error = %q, want %q", got, want)
+	}
+}
@@ -8,6 +8,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
 // RunnerApplicationDownload represents a binary for the self-hosted runner application that can be downloaded.
@@ -145,6 +146,18 @@ type RunnerLabels struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// RunnerLabelsList represents the labels of a self-hosted runner.
+type RunnerLabelsList struct {
+	TotalCount int             `json:"total_count"`
+	Labels     []*RunnerLabels `json:"labels"`
+}
+
+// runnerLabelsRequest represents the body of a request to add or set the
+// custom labels of a self-hosted runner.
+type runnerLabelsRequest struct {
+	Labels []string `json:"labels"`
+}
+
 // Runners represents a collection of self-hosted runners for a repository.
 type Runners struct {
 	TotalCount int       `json:"total_count"`
@@ -369,3 +382,130 @@ func (s *ActionsService) RemoveOrganizationRunner(ctx context.Context, owner str
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// ListRunnerLabels lists all labels for a self-hosted runner in a repository.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#list-labels-for-a-self-hosted-runner-for-a-repository
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runners/{runner_id}/labels
+func (s *ActionsService) ListRunnerLabels(ctx context.Context, owner, repo string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels", owner, repo, runnerID)
+	return s.client.runnerLabels(ctx, "GET", u, nil)
+}
+
+// AddRunnerLabels adds custom labels to a self-hosted runner in a repository.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#add-custom-labels-to-a-self-hosted-runner-for-a-repository
+//
+//meta:operation POST /repos/{owner}/{repo}/actions/runners/{runner_id}/labels
+func (s *ActionsService) AddRunnerLabels(ctx context.Context, owner, repo string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels", owner, repo, runnerID)
+	return s.client.runnerLabels(ctx, "POST", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// SetRunnerLabels replaces all custom labels of a self-hosted runner in a repository.
+// Default labels, such as "self-hosted", are kept.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-a-repository
+//
+//meta:operation PUT /repos/{owner}/{repo}/actions/runners/{runner_id}/labels
+func (s *ActionsService) SetRunnerLabels(ctx context.Context, owner, repo string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels", owner, repo, runnerID)
+	return s.client.runnerLabels(ctx, "PUT", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// RemoveRunnerLabels removes all custom labels from a self-hosted runner in a repository
+// and returns the labels it has left.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#remove-all-custom-labels-from-a-self-hosted-runner-for-a-repository
+//
+//meta:operation DELETE /repos/{owner}/{repo}/actions/runners/{runner_id}/labels
+func (s *ActionsService) RemoveRunnerLabels(ctx context.Context, owner, repo string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels", owner, repo, runnerID)
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
+
+// RemoveRunnerLabel removes a custom label from a self-hosted runner in a repository
+// and returns the labels it has left.
+//
+// Note: the label name is URL path escaped for you. See: https://pkg.go.dev/net/url#PathEscape .
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#remove-a-custom-label-from-a-self-hosted-runner-for-a-repository
+//
+//meta:operation DELETE /repos/{owner}/{repo}/actions/runners/{runner_id}/labels/{name}
+func (s *ActionsService) RemoveRunnerLabel(ctx context.Context, owner, repo string, runnerID int64, name string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels/%v", owner, repo, runnerID, url.PathEscape(name))
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
+
+// ListOrganizationRunnerLabels lists all labels for a self-hosted runner in an organization.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#list-labels-for-a-self-hosted-runner-for-an-organization
+//
+//meta:operation GET /orgs/{org}/actions/runners/{runner_id}/labels
+func (s *ActionsService) ListOrganizationRunnerLabels(ctx context.Context, org string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels", org, runnerID)
+	return s.client.runnerLabels(ctx, "GET", u, nil)
+}
+
+// AddOrganizationRunnerLabels adds custom labels to a self-hosted runner in an organization.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#add-custom-labels-to-a-self-hosted-runner-for-an-organization
+//
+//meta:operation POST /orgs/{org}/actions/runners/{runner_id}/labels
+func (s *ActionsService) AddOrganizationRunnerLabels(ctx context.Context, org string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels", org, runnerID)
+	return s.client.runnerLabels(ctx, "POST", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// SetOrganizationRunnerLabels replaces all custom labels of a self-hosted runner in an organization.
+// Default labels, such as "self-hosted", are kept.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-an-organization
+//
+//meta:operation PUT /orgs/{org}/actions/runners/{runner_id}/labels
+func (s *ActionsService) SetOrganizationRunnerLabels(ctx context.Context, org string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels", org, runnerID)
+	return s.client.runnerLabels(ctx, "PUT", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// RemoveOrganizationRunnerLabels removes all custom labels from a self-hosted runner in an organization
+// and returns the labels it has left.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#remove-all-custom-labels-from-a-self-hosted-runner-for-an-organization
+//
+//meta:operation DELETE /orgs/{org}/actions/runners/{runner_id}/labels
+func (s *ActionsService) RemoveOrganizationRunnerLabels(ctx context.Context, org string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels", org, runnerID)
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
+
+// RemoveOrganizationRunnerLabel removes a custom label from a self-hosted runner in an organization
+// and returns the labels it has left.
+//
+// Note: the label name is URL path escaped for you. See: https://pkg.go.dev/net/url#PathEscape .
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#remove-a-custom-label-from-a-self-hosted-runner-for-an-organization
+//
+//meta:operation DELETE /orgs/{org}/actions/runners/{runner_id}/labels/{name}
+func (s *ActionsService) RemoveOrganizationRunnerLabel(ctx context.Context, org string, runnerID int64, name string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels/%v", org, runnerID, url.PathEscape(name))
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
+
+// runnerLabels sends a request to a runner labels endpoint and returns the
+// labels of the runner from its response.
+func (c *Client) runnerLabels(ctx context.Context, method, u string, body interface{}) (*RunnerLabelsList, *Response, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels := new(RunnerLabelsList)
+	resp, err := c.Do(ctx, req, labels)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return labels, resp, nil
+}
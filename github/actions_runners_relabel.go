@@ -0,0 +1,249 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v56/internal/labels"
+)
+
+// RunnerSelector selects self-hosted runners. A runner is selected if it
+// matches all of the fields that are set.
+type RunnerSelector struct {
+	// Labels are labels the runner must all have. Labels are compared
+	// case-insensitively, as on GitHub.
+	Labels []string
+
+	// NamePattern is a pattern, in the syntax of path.Match, that the name
+	// of the runner must match, such as "build-*".
+	NamePattern string
+
+	// Status is "online" or "offline".
+	Status string
+
+	// Busy selects runners that are, or are not, running a job.
+	Busy *bool
+}
+
+// Matches reports whether runner is selected by sel.
+func (sel *RunnerSelector) Matches(runner *Runner) bool {
+	if sel.NamePattern != "" {
+		if ok, _ := path.Match(sel.NamePattern, runner.GetName()); !ok {
+			return false
+		}
+	}
+	if sel.Status != "" && sel.Status != runner.GetStatus() {
+		return false
+	}
+	if sel.Busy != nil && *sel.Busy != runner.GetBusy() {
+		return false
+	}
+	for _, want := range sel.Labels {
+		found := false
+		for _, l := range runner.Labels {
+			if strings.EqualFold(l.GetName(), want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// RelabelRunnersOptions specifies the parameters to
+// ActionsService.RelabelRunners, ActionsService.RelabelOrganizationRunners
+// and EnterpriseService.RelabelRunners.
+type RelabelRunnersOptions struct {
+	// Selector selects the runners to relabel. The zero value selects all
+	// runners.
+	Selector RunnerSelector
+
+	// Add are the custom labels to add to each selected runner.
+	Add []string
+
+	// Remove are the custom labels to remove from each selected runner.
+	// Default labels, such as "self-hosted", cannot be removed and are
+	// ignored.
+	Remove []string
+
+	// DryRun reports the changes that would be made without making them.
+	DryRun bool
+}
+
+// RunnerRelabel describes the change of the custom labels of a runner.
+type RunnerRelabel struct {
+	Runner *Runner
+	Before []string
+	After  []string
+}
+
+// RelabelRunnersResult is the outcome of relabeling runners.
+type RelabelRunnersResult struct {
+	// Changes are the runners whose custom labels were changed, or would
+	// be changed in a dry run. Selected runners that already had the
+	// desired labels are not included.
+	Changes []*RunnerRelabel
+
+	// Errors holds the errors setting the labels of runners, keyed by
+	// runner ID. Runners that failed are not included in Changes.
+	Errors map[int64]error
+}
+
+// RelabelRunners adds and removes custom labels of the self-hosted runners
+// of a repository that are selected by opts.Selector. A failure to relabel
+// one runner does not stop the others from being relabeled; it is recorded
+// in the Errors of the result.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#list-self-hosted-runners-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-a-repository
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runners
+//meta:operation PUT /repos/{owner}/{repo}/actions/runners/{runner_id}/labels
+func (s *ActionsService) RelabelRunners(ctx context.Context, owner, repo string, opts *RelabelRunnersOptions) (*RelabelRunnersResult, error) {
+	return relabelRunners(ctx, opts,
+		func(lo *ListOptions) (*Runners, *Response, error) {
+			return s.ListRunners(ctx, owner, repo, lo)
+		},
+		func(runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+			return s.SetRunnerLabels(ctx, owner, repo, runnerID, labels)
+		})
+}
+
+// RelabelOrganizationRunners adds and removes custom labels of the
+// self-hosted runners of an organization that are selected by
+// opts.Selector. See RelabelRunners.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#list-self-hosted-runners-for-an-organization
+// GitHub API docs: https://docs.github.com/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-an-organization
+//
+//meta:operation GET /orgs/{org}/actions/runners
+//meta:operation PUT /orgs/{org}/actions/runners/{runner_id}/labels
+func (s *ActionsService) RelabelOrganizationRunners(ctx context.Context, org string, opts *RelabelRunnersOptions) (*RelabelRunnersResult, error) {
+	return relabelRunners(ctx, opts,
+		func(lo *ListOptions) (*Runners, *Response, error) {
+			return s.ListOrganizationRunners(ctx, org, lo)
+		},
+		func(runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+			return s.SetOrganizationRunnerLabels(ctx, org, runnerID, labels)
+		})
+}
+
+// RelabelRunners adds and removes custom labels of the self-hosted runners
+// of an enterprise that are selected by opts.Selector. See
+// ActionsService.RelabelRunners.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#list-self-hosted-runners-for-an-enterprise
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation GET /enterprises/{enterprise}/actions/runners
+//meta:operation PUT /enterprises/{enterprise}/actions/runners/{runner_id}/labels
+func (s *EnterpriseService) RelabelRunners(ctx context.Context, enterprise string, opts *RelabelRunnersOptions) (*RelabelRunnersResult, error) {
+	return relabelRunners(ctx, opts,
+		func(lo *ListOptions) (*Runners, *Response, error) {
+			return s.ListRunners(ctx, enterprise, lo)
+		},
+		func(runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+			return s.SetRunnerLabels(ctx, enterprise, runnerID, labels)
+		})
+}
+
+// relabelRunners lists all runners with list and sets the custom labels of
+// the selected ones with set.
+func relabelRunners(ctx context.Context, opts *RelabelRunnersOptions,
+	list func(*ListOptions) (*Runners, *Response, error),
+	set func(runnerID int64, labels []string) (*RunnerLabelsList, *Response, error),
+) (*RelabelRunnersResult, error) {
+	if opts == nil || len(opts.Add) == 0 && len(opts.Remove) == 0 {
+		return nil, errors.New("labels to add or remove must be provided")
+	}
+	for _, a := range opts.Add {
+		if labels.ContainsFold(opts.Remove, a) {
+			return nil, fmt.Errorf("label %q is both added and removed", a)
+		}
+	}
+
+	var selected []*Runner
+	lo := &ListOptions{PerPage: 100}
+	for {
+		var (
+			runners *Runners
+			resp    *Response
+		)
+		err := RetryOnRateLimit(ctx, func() (_ *Response, err error) {
+			runners, resp, err = list(lo)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runners.Runners {
+			if opts.Selector.Matches(r) {
+				selected = append(selected, r)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		lo.Page = resp.NextPage
+	}
+
+	result := &RelabelRunnersResult{Errors: make(map[int64]error)}
+	for _, r := range selected {
+		before, after, changed := relabel(r, opts.Add, opts.Remove)
+		if !changed {
+			continue
+		}
+		if !opts.DryRun {
+			err := RetryOnRateLimit(ctx, func() (*Response, error) {
+				_, resp, err := set(r.GetID(), after)
+				return resp, err
+			})
+			if err != nil {
+				result.Errors[r.GetID()] = err
+				continue
+			}
+		}
+		result.Changes = append(result.Changes, &RunnerRelabel{Runner: r, Before: before, After: after})
+	}
+	return result, nil
+}
+
+// relabel returns the custom labels of runner before and after removing
+// the labels in remove and adding those in add, and whether they differ.
+func relabel(runner *Runner, add, remove []string) (before, after []string, changed bool) {
+	var all []string
+	for _, l := range runner.Labels {
+		all = append(all, l.GetName())
+		if l.GetType() == "custom" {
+			before = append(before, l.GetName())
+		}
+	}
+
+	after = []string{}
+	for _, l := range before {
+		if labels.ContainsFold(remove, l) {
+			changed = true
+			continue
+		}
+		after = append(after, l)
+	}
+	for _, l := range add {
+		if labels.ContainsFold(all, l) || labels.ContainsFold(after, l) {
+			continue
+		}
+		changed = true
+		after = append(after, l)
+	}
+	return before, after, changed
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunnerSelector_Matches(t *testing.T) {
+	runner := &Runner{
+		Name:   String("build-01"),
+		Status: String("online"),
+		Busy:   Bool(false),
+		Labels: []*RunnerLabels{
+			{Name: String("self-hosted"), Type: String("read-only")},
+			{Name: String("GPU"), Type: String("custom")},
+		},
+	}
+
+	tests := []struct {
+		sel  RunnerSelector
+		want bool
+	}{
+		{RunnerSelector{}, true},
+		{RunnerSelector{NamePattern: "build-*"}, true},
+		{RunnerSelector{NamePattern: "deploy-*"}, false},
+		{RunnerSelector{Status: "online", Busy: Bool(false)}, true},
+		{RunnerSelector{Status: "offline"}, false},
+		{RunnerSelector{Busy: Bool(true)}, false},
+		{RunnerSelector{Labels: []string{"gpu", "Self-Hosted"}}, true},
+		{RunnerSelector{Labels: []string{"gpu", "arm64"}}, false},
+	}
+	for i, tt := range tests {
+		if got := tt.sel.Matches(runner); got != tt.want {
+			t.Errorf("#%v: Matches = %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func testRelabelRunnersMux(t *testing.T, mux *http.ServeMux, prefix string, set map[string][]string) {
+	t.Helper()
+
+	mux.HandleFunc(prefix+"/actions/runners", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.FormValue("page") {
+		case "":
+			w.Header().Set("Link", `<`+prefix+`/actions/runners?page=2>; rel="next"`)
+			fmt.Fprint(w, `{"total_count":3,"runners":[
+				{"id":1,"name":"build-1","labels":[{"name":"self-hosted","type":"read-only"},{"name":"old","type":"custom"}]},
+				{"id":2,"name":"deploy-1","labels":[{"name":"self-hosted","type":"read-only"},{"name":"old","type":"custom"}]}
+			]}`)
+		case "2":
+			fmt.Fprint(w, `{"total_count":3,"runners":[
+				{"id":3,"name":"build-2","labels":[{"name":"self-hosted","type":"read-only"},{"name":"New","type":"custom"}]},
+				{"id":4,"name":"build-3","labels":[{"name":"self-hosted","type":"read-only"},{"name":"old","type":"custom"},{"name":"x","type":"custom"}]}
+			]}`)
+		}
+	})
+	for _, id := range []int{1, 2, 3, 4} {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("%v/actions/runners/%v/labels", prefix, id), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PUT")
+			if id == 4 {
+				http.Error(w, `{"message":"Validation Failed"}`, http.StatusUnprocessableEntity)
+				return
+			}
+			var body runnerLabelsRequest
+			assertNilError(t, json.NewDecoder(r.Body).Decode(&body))
+			set[fmt.Sprint(id)] = body.Labels
+			fmt.Fprint(w, `{"total_count":0,"labels":[]}`)
+		})
+	}
+}
+
+func TestActionsService_RelabelOrganizationRunners(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	set := make(map[string][]string)
+	testRelabelRunnersMux(t, mux, "/orgs/o", set)
+
+	ctx := context.Background()
+	opts := &RelabelRunnersOptions{
+		Selector: RunnerSelector{NamePattern: "build-*"},
+		Add:      []string{"new", "self-hosted"},
+		Remove:   []string{"OLD"},
+	}
+	result, err := client.Actions.RelabelOrganizationRunners(ctx, "o", opts)
+	if err != nil {
+		t.Fatalf("Actions.RelabelOrganizationRunners returned error: %v", err)
+	}
+
+	wantSet := map[string][]string{"1": {"new"}}
+	if !cmp.Equal(set, wantSet) {
+		t.Errorf("labels set = %v, want %v", set, wantSet)
+	}
+	if len(result.Changes) != 1 {
+		t.Fatalf("Changes = %+v, want one change", result.Changes)
+	}
+	if c := result.Changes[0]; c.Runner.GetID() != 1 || !cmp.Equal(c.Before, []string{"old"}) || !cmp.Equal(c.After, []string{"new"}) {
+		t.Errorf("Changes[0] = %+v", c)
+	}
+	if len(result.Errors) != 1 || result.Errors[4] == nil {
+		t.Errorf("Errors = %v, want an error for runner 4", result.Errors)
+	}
+
+	const methodName = "RelabelOrganizationRunners"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.RelabelOrganizationRunners(ctx, "\n", opts)
+		return err
+	})
+}
+
+func TestActionsService_RelabelRunners_dryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	set := make(map[string][]string)
+	testRelabelRunnersMux(t, mux, "/repos/o/r", set)
+
+	ctx := context.Background()
+	result, err := client.Actions.RelabelRunners(ctx, "o", "r", &RelabelRunnersOptions{Remove: []string{"old"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Actions.RelabelRunners returned error: %v", err)
+	}
+	if len(set) != 0 {
+		t.Errorf("labels were set in a dry run: %v", set)
+	}
+	var got [][]string
+	for _, c := range result.Changes {
+		got = append(got, c.After)
+	}
+	if want := [][]string{{}, {}, {"x"}}; !cmp.Equal(got, want) {
+		t.Errorf("Changes after = %v, want %v", got, want)
+	}
+}
+
+func TestEnterpriseService_RelabelRunners(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	set := make(map[string][]string)
+	testRelabelRunnersMux(t, mux, "/enterprises/e", set)
+
+	ctx := context.Background()
+	_, err := client.Enterprise.RelabelRunners(ctx, "e", &RelabelRunnersOptions{
+		Selector: RunnerSelector{Labels: []string{"old"}, NamePattern: "deploy-*"},
+		Add:      []string{"prod"},
+	})
+	if err != nil {
+		t.Fatalf("Enterprise.RelabelRunners returned error: %v", err)
+	}
+	if want := map[string][]string{"2": {"old", "prod"}}; !cmp.Equal(set, want) {
+		t.Errorf("labels set = %v, want %v", set, want)
+	}
+}
+
+func TestRelabelRunners_invalidOptions(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	for _, opts := range []*RelabelRunnersOptions{
+		nil,
+		{},
+		{Add: []string{"a"}, Remove: []string{"A"}},
+	} {
+		if _, err := client.Actions.RelabelRunners(ctx, "o", "r", opts); err == nil {
+			t.Errorf("RelabelRunners(%+v) returned nil error", opts)
+		}
+	}
+}
@@ -729,3 +729,417 @@ func TestRemoveToken_Marshal(t *testing.T) {
 
 	testJSONMarshal(t, u, want)
 }
+
+func TestActionsService_ListRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.ListRunnerLabels(ctx, "o", "r", 23)
+	if err != nil {
+		t.Errorf("Actions.ListRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.ListRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "ListRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.ListRunnerLabels(ctx, "\n", "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.ListRunnerLabels(ctx, "o", "r", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_AddRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.AddRunnerLabels(ctx, "o", "r", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Actions.AddRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.AddRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "AddRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.AddRunnerLabels(ctx, "\n", "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.AddRunnerLabels(ctx, "o", "r", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_SetRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.SetRunnerLabels(ctx, "o", "r", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Actions.SetRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.SetRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "SetRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.SetRunnerLabels(ctx, "\n", "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.SetRunnerLabels(ctx, "o", "r", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_RemoveRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.RemoveRunnerLabels(ctx, "o", "r", 23)
+	if err != nil {
+		t.Errorf("Actions.RemoveRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.RemoveRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.RemoveRunnerLabels(ctx, "\n", "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.RemoveRunnerLabels(ctx, "o", "r", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_RemoveRunnerLabel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runners/23/labels/my label", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.RemoveRunnerLabel(ctx, "o", "r", 23, "my label")
+	if err != nil {
+		t.Errorf("Actions.RemoveRunnerLabel returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.RemoveRunnerLabel returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveRunnerLabel"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.RemoveRunnerLabel(ctx, "\n", "\n", 23, "my label")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.RemoveRunnerLabel(ctx, "o", "r", 23, "my label")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_ListOrganizationRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.ListOrganizationRunnerLabels(ctx, "o", 23)
+	if err != nil {
+		t.Errorf("Actions.ListOrganizationRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.ListOrganizationRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "ListOrganizationRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.ListOrganizationRunnerLabels(ctx, "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.ListOrganizationRunnerLabels(ctx, "o", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_AddOrganizationRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.AddOrganizationRunnerLabels(ctx, "o", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Actions.AddOrganizationRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.AddOrganizationRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "AddOrganizationRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.AddOrganizationRunnerLabels(ctx, "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.AddOrganizationRunnerLabels(ctx, "o", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_SetOrganizationRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.SetOrganizationRunnerLabels(ctx, "o", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Actions.SetOrganizationRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.SetOrganizationRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "SetOrganizationRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.SetOrganizationRunnerLabels(ctx, "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.SetOrganizationRunnerLabels(ctx, "o", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_RemoveOrganizationRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.RemoveOrganizationRunnerLabels(ctx, "o", 23)
+	if err != nil {
+		t.Errorf("Actions.RemoveOrganizationRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.RemoveOrganizationRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveOrganizationRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.RemoveOrganizationRunnerLabels(ctx, "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.RemoveOrganizationRunnerLabels(ctx, "o", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_RemoveOrganizationRunnerLabel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/actions/runners/23/labels/my label", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Actions.RemoveOrganizationRunnerLabel(ctx, "o", 23, "my label")
+	if err != nil {
+		t.Errorf("Actions.RemoveOrganizationRunnerLabel returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Actions.RemoveOrganizationRunnerLabel returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveOrganizationRunnerLabel"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.RemoveOrganizationRunnerLabel(ctx, "\n", 23, "my label")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.RemoveOrganizationRunnerLabel(ctx, "o", 23, "my label")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
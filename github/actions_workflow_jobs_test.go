@@ -60,6 +60,49 @@ func TestActionsService_ListWorkflowJobs(t *testing.T) {
 	})
 }
 
+func TestActionsService_ListWorkflowJobsAttempt(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/29679449/attempts/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"per_page": "2", "page": "2"})
+		fmt.Fprint(w, `{"total_count":4,"jobs":[{"id":399444496,"run_id":29679449,"run_attempt":1},{"id":399444497,"run_id":29679449,"run_attempt":1}]}`)
+	})
+
+	opts := &ListOptions{Page: 2, PerPage: 2}
+	ctx := context.Background()
+	jobs, _, err := client.Actions.ListWorkflowJobsAttempt(ctx, "o", "r", 29679449, 1, opts)
+	if err != nil {
+		t.Errorf("Actions.ListWorkflowJobsAttempt returned error: %v", err)
+	}
+
+	want := &Jobs{
+		TotalCount: Int(4),
+		Jobs: []*WorkflowJob{
+			{ID: Int64(399444496), RunID: Int64(29679449), RunAttempt: Int64(1)},
+			{ID: Int64(399444497), RunID: Int64(29679449), RunAttempt: Int64(1)},
+		},
+	}
+	if !cmp.Equal(jobs, want) {
+		t.Errorf("Actions.ListWorkflowJobsAttempt returned %+v, want %+v", jobs, want)
+	}
+
+	const methodName = "ListWorkflowJobsAttempt"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.ListWorkflowJobsAttempt(ctx, "\n", "\n", 29679449, 1, opts)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.ListWorkflowJobsAttempt(ctx, "o", "r", 29679449, 1, opts)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
 func TestActionsService_ListWorkflowJobs_Filter(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -0,0 +1,541 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WorkflowLogLine is a line of a workflow job log.
+type WorkflowLogLine struct {
+	// Time is the time the runner recorded the line, if it did.
+	Time time.Time
+
+	// Text is the line without its timestamp.
+	Text string
+
+	// Command is the log annotation or workflow command the line starts
+	// with, such as "group", "endgroup", "error", "warning", "notice",
+	// "debug" or "command", or empty for plain output. Both the
+	// "##[error]..." form written by the runner and the
+	// "::error file=...::..." form written by workflow steps are
+	// recognized.
+	Command string
+
+	// Message is the text following the command, or Text if there is none.
+	Message string
+
+	// Properties are the parameters of a workflow command, such as "file"
+	// and "line".
+	Properties map[string]string
+}
+
+// WorkflowStepLog is the log of one step of a workflow job.
+type WorkflowStepLog struct {
+	// Number and Name identify the step, matching TaskStep.Number and
+	// TaskStep.Name.
+	Number int64
+	Name   string
+
+	// Step is the step reported by the API, if it is known.
+	Step *TaskStep
+
+	Lines []*WorkflowLogLine
+}
+
+// WorkflowJobLog is the log of a workflow job.
+type WorkflowJobLog struct {
+	// Name is the name of the job.
+	Name string
+
+	// Job is the job reported by the API, if it is known.
+	Job *WorkflowJob
+
+	// Lines are all the lines of the job log.
+	Lines []*WorkflowLogLine
+
+	// Steps are the logs of the job's steps, ordered by number.
+	Steps []*WorkflowStepLog
+}
+
+// WorkflowRunLog is the log of a workflow run.
+type WorkflowRunLog struct {
+	// Jobs are the logs of the run's jobs, in the order GitHub lists them.
+	Jobs []*WorkflowJobLog
+}
+
+// Annotations returns the lines of l that are error, warning or notice
+// annotations.
+func (l *WorkflowStepLog) Annotations() []*WorkflowLogLine {
+	var lines []*WorkflowLogLine
+	for _, line := range l.Lines {
+		switch line.Command {
+		case "error", "warning", "notice":
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Excerpt returns up to n lines of l that explain a failure: the lines
+// leading up to the last error annotation, or the last lines of the step
+// if there is none.
+func (l *WorkflowStepLog) Excerpt(n int) []*WorkflowLogLine {
+	end := len(l.Lines)
+	for i := len(l.Lines) - 1; i >= 0; i-- {
+		if l.Lines[i].Command == "error" {
+			end = i + 1
+			break
+		}
+	}
+	start := end - n
+	if start < 0 {
+		start = 0
+	}
+	return l.Lines[start:end]
+}
+
+// Step returns the log of the step with the given number, or nil.
+func (l *WorkflowJobLog) Step(number int64) *WorkflowStepLog {
+	for _, s := range l.Steps {
+		if s.Number == number {
+			return s
+		}
+	}
+	return nil
+}
+
+// WorkflowLogReader reads the lines of a workflow job log one at a time.
+type WorkflowLogReader struct {
+	scanner *bufio.Scanner
+	closer  io.Closer
+	first   bool
+}
+
+// NewWorkflowLogReader returns a WorkflowLogReader that reads the log r.
+func NewWorkflowLogReader(r io.Reader) *WorkflowLogReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lr := &WorkflowLogReader{scanner: scanner, first: true}
+	if c, ok := r.(io.Closer); ok {
+		lr.closer = c
+	}
+	return lr
+}
+
+// Next returns the next line of the log, or io.EOF at its end.
+func (r *WorkflowLogReader) Next() (*WorkflowLogLine, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	text := r.scanner.Text()
+	if r.first {
+		text = strings.TrimPrefix(text, "\ufeff")
+		r.first = false
+	}
+	return ParseWorkflowLogLine(text), nil
+}
+
+// Close closes the underlying log if it is an io.Closer.
+func (r *WorkflowLogReader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// readAll returns all remaining lines of the log.
+func (r *WorkflowLogReader) readAll() ([]*WorkflowLogLine, error) {
+	var lines []*WorkflowLogLine
+	for {
+		line, err := r.Next()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+}
+
+// ParseWorkflowLogLine parses a line of a workflow job log, such as
+// "2023-10-01T12:00:00.1234567Z ##[error]Process completed with exit code 1.".
+func ParseWorkflowLogLine(s string) *WorkflowLogLine {
+	s = strings.TrimSuffix(s, "\r")
+	line := &WorkflowLogLine{Text: s}
+	if i := strings.IndexByte(s, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, s[:i]); err == nil {
+			line.Time = t
+			line.Text = s[i+1:]
+		}
+	}
+	line.Message = line.Text
+
+	switch text := line.Text; {
+	case strings.HasPrefix(text, "##["):
+		if end := strings.IndexByte(text, ']'); end > 0 {
+			line.Command = text[3:end]
+			line.Message = text[end+1:]
+		}
+	case strings.HasPrefix(text, "::"):
+		end := strings.Index(text[2:], "::")
+		if end < 0 {
+			break
+		}
+		spec := text[2 : 2+end]
+		name := spec
+		if i := strings.IndexByte(spec, ' '); i >= 0 {
+			name = spec[:i]
+			line.Properties = parseWorkflowCommandProperties(spec[i+1:])
+		}
+		if name == "" || strings.ContainsAny(name, ",=") {
+			break
+		}
+		line.Command = name
+		line.Message = unescapeWorkflowCommandData(text[2+end+2:])
+	}
+	return line
+}
+
+// parseWorkflowCommandProperties parses the comma separated key=value
+// properties of a workflow command.
+func parseWorkflowCommandProperties(s string) map[string]string {
+	props := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		v := unescapeWorkflowCommandData(parts[1])
+		v = strings.NewReplacer("%3A", ":", "%2C", ",").Replace(v)
+		props[strings.TrimSpace(parts[0])] = v
+	}
+	return props
+}
+
+// unescapeWorkflowCommandData reverses the escaping of workflow command
+// messages.
+func unescapeWorkflowCommandData(s string) string {
+	return strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%").Replace(s)
+}
+
+// DownloadWorkflowRunLogs downloads and parses the logs of all jobs of a
+// workflow run. The logs are split per step as GitHub stores them, and
+// matched by name to the jobs and steps of the run's latest attempt.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#list-jobs-for-a-workflow-run
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#download-workflow-run-logs
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/logs
+func (s *ActionsService) DownloadWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64) (*WorkflowRunLog, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/logs", owner, repo, runID)
+	run, resp, err := s.downloadWorkflowRunLogs(ctx, u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var jobs []*WorkflowJob
+	opts := &ListWorkflowJobsOptions{ListOptions: ListOptions{PerPage: 100}}
+	for {
+		list, listResp, err := s.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+		if err != nil {
+			return nil, listResp, err
+		}
+		jobs = append(jobs, list.Jobs...)
+		if listResp.NextPage == 0 {
+			break
+		}
+		opts.Page = listResp.NextPage
+	}
+	run.matchJobs(jobs)
+	return run, resp, nil
+}
+
+// DownloadWorkflowRunAttemptLogs downloads and parses the logs of all jobs
+// of an attempt of a workflow run. The logs are split per step as GitHub
+// stores them, and matched by name to the jobs and steps of the attempt.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#list-jobs-for-a-workflow-run-attempt
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#download-workflow-run-attempt-logs
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/attempts/{attempt_number}/jobs
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/attempts/{attempt_number}/logs
+func (s *ActionsService) DownloadWorkflowRunAttemptLogs(ctx context.Context, owner, repo string, runID int64, attemptNumber int) (*WorkflowRunLog, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/attempts/%v/logs", owner, repo, runID, attemptNumber)
+	run, resp, err := s.downloadWorkflowRunLogs(ctx, u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var jobs []*WorkflowJob
+	opts := &ListOptions{PerPage: 100}
+	for {
+		list, listResp, err := s.ListWorkflowJobsAttempt(ctx, owner, repo, runID, int64(attemptNumber), opts)
+		if err != nil {
+			return nil, listResp, err
+		}
+		jobs = append(jobs, list.Jobs...)
+		if listResp.NextPage == 0 {
+			break
+		}
+		opts.Page = listResp.NextPage
+	}
+	run.matchJobs(jobs)
+	return run, resp, nil
+}
+
+func (s *ActionsService) downloadWorkflowRunLogs(ctx context.Context, u string) (*WorkflowRunLog, *Response, error) {
+	zr, _, resp, cleanup, err := s.client.downloadZip(ctx, u)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer cleanup()
+	run, err := parseWorkflowRunLogArchive(zr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return run, resp, nil
+}
+
+// matchJobs sets the Job of each job log and the Step of each step log
+// from the jobs reported by the API. Jobs are matched by name, in order
+// for jobs that share a name. Steps are matched by number, or by name if
+// no step has their number.
+func (l *WorkflowRunLog) matchJobs(jobs []*WorkflowJob) {
+	used := make(map[*WorkflowJob]bool)
+	for _, jl := range l.Jobs {
+		for _, j := range jobs {
+			if !used[j] && workflowLogNamesMatch(jl.Name, j.GetName()) {
+				used[j] = true
+				jl.Job = j
+				break
+			}
+		}
+		if jl.Job == nil {
+			continue
+		}
+		for _, sl := range jl.Steps {
+			sl.Step = findTaskStep(jl.Job.Steps, sl.Number, sl.Name)
+		}
+	}
+}
+
+// findTaskStep returns the step with the given number, or else the step
+// called name, or nil.
+func findTaskStep(steps []*TaskStep, number int64, name string) *TaskStep {
+	for _, step := range steps {
+		if step.GetNumber() == number {
+			return step
+		}
+	}
+	for _, step := range steps {
+		if workflowLogNamesMatch(name, step.GetName()) {
+			return step
+		}
+	}
+	return nil
+}
+
+// workflowLogNamesMatch reports whether logName, the name of a job or step
+// in a log archive, refers to the job or step called name. Characters that
+// are not allowed in file names are left out or replaced in log archive
+// names, so they are ignored along with white space.
+func workflowLogNamesMatch(logName, name string) bool {
+	clean := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	return clean(logName) == clean(name)
+}
+
+// parseWorkflowRunLogArchive parses the zip archive of a run's logs. It
+// holds a "<n>_<job>.txt" file with the full log of each job, and a
+// "<job>/<step number>_<step>.txt" file with the log of each step.
+func parseWorkflowRunLogArchive(zr *zip.Reader) (*WorkflowRunLog, error) {
+	type orderedJob struct {
+		order int
+		log   *WorkflowJobLog
+	}
+	jobs := make(map[string]*orderedJob)
+	job := func(name string) *orderedJob {
+		j, ok := jobs[name]
+		if !ok {
+			j = &orderedJob{order: -1, log: &WorkflowJobLog{Name: name}}
+			jobs[name] = j
+		}
+		return j
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || path.Ext(f.Name) != ".txt" {
+			continue
+		}
+		dir, base := path.Split(f.Name)
+		number, name := splitNumberedLogName(strings.TrimSuffix(base, ".txt"))
+		if number < 0 {
+			continue
+		}
+
+		lines, err := readZipWorkflowLog(f)
+		if err != nil {
+			return nil, err
+		}
+		if dir == "" {
+			j := job(name)
+			j.order = int(number)
+			j.log.Lines = lines
+			continue
+		}
+		j := job(strings.TrimSuffix(dir, "/"))
+		j.log.Steps = append(j.log.Steps, &WorkflowStepLog{Number: number, Name: name, Lines: lines})
+	}
+
+	ordered := make([]*orderedJob, 0, len(jobs))
+	for _, j := range jobs {
+		sort.Slice(j.log.Steps, func(a, b int) bool { return j.log.Steps[a].Number < j.log.Steps[b].Number })
+		if j.log.Lines == nil {
+			for _, step := range j.log.Steps {
+				j.log.Lines = append(j.log.Lines, step.Lines...)
+			}
+		}
+		ordered = append(ordered, j)
+	}
+	sort.Slice(ordered, func(a, b int) bool {
+		if ordered[a].order != ordered[b].order {
+			return ordered[a].order < ordered[b].order
+		}
+		return ordered[a].log.Name < ordered[b].log.Name
+	})
+
+	run := &WorkflowRunLog{}
+	for _, j := range ordered {
+		run.Jobs = append(run.Jobs, j.log)
+	}
+	return run, nil
+}
+
+// splitNumberedLogName splits a log file name such as "2_Run tests" into
+// its number and name. The number is -1 if there is none.
+func splitNumberedLogName(s string) (int64, string) {
+	parts := strings.SplitN(s, "_", 2)
+	if len(parts) != 2 {
+		return -1, s
+	}
+	n, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return -1, s
+	}
+	return n, parts[1]
+}
+
+func readZipWorkflowLog(f *zip.File) ([]*WorkflowLogLine, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return NewWorkflowLogReader(rc).readAll()
+}
+
+// OpenWorkflowJobLogs opens the plain text log of a workflow job for
+// reading line by line. It is the caller's responsibility to close the
+// reader.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#download-job-logs-for-a-workflow-run
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs
+func (s *ActionsService) OpenWorkflowJobLogs(ctx context.Context, owner, repo string, jobID int64) (*WorkflowLogReader, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/jobs/%v/logs", owner, repo, jobID)
+	resp, err := s.client.openDownload(ctx, u, &DownloadToFileOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	response := newResponse(resp)
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, response, err
+	}
+	return NewWorkflowLogReader(resp.Body), response, nil
+}
+
+// DownloadWorkflowJobLogs downloads and parses the log of a workflow job.
+// The log is split into the job's steps using the start and completion
+// times of each step.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#download-job-logs-for-a-workflow-run
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#get-a-job-for-a-workflow-run
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}
+//meta:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs
+func (s *ActionsService) DownloadWorkflowJobLogs(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJobLog, *Response, error) {
+	job, resp, err := s.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	r, resp, err := s.OpenWorkflowJobLogs(ctx, owner, repo, jobID)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer r.Close()
+	lines, err := r.readAll()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	log := &WorkflowJobLog{Name: job.GetName(), Job: job, Lines: lines}
+	log.Steps = splitWorkflowJobLog(lines, job.Steps)
+	return log, resp, nil
+}
+
+// splitWorkflowJobLog assigns each line of a job log to the step that was
+// running when it was written.
+func splitWorkflowJobLog(lines []*WorkflowLogLine, steps []*TaskStep) []*WorkflowStepLog {
+	var logs []*WorkflowStepLog
+	for _, step := range steps {
+		if step.StartedAt == nil {
+			// The step was skipped or has not started.
+			continue
+		}
+		logs = append(logs, &WorkflowStepLog{Number: step.GetNumber(), Name: step.GetName(), Step: step})
+	}
+	sort.SliceStable(logs, func(a, b int) bool { return logs[a].Number < logs[b].Number })
+	if len(logs) == 0 {
+		return nil
+	}
+
+	current := 0
+	for _, line := range lines {
+		if !line.Time.IsZero() {
+			// Step times are reported to the second.
+			t := line.Time.Truncate(time.Second)
+			for current+1 < len(logs) && !logs[current+1].Step.GetStartedAt().After(t) {
+				current++
+			}
+		}
+		logs[current].Lines = append(logs[current].Lines, line)
+	}
+	return logs
+}
@@ -0,0 +1,339 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWorkflowLogLine(t *testing.T) {
+	ts := time.Date(2023, time.October, 1, 12, 0, 0, 123456700, time.UTC)
+	tests := []struct {
+		in   string
+		want *WorkflowLogLine
+	}{
+		{
+			in:   "2023-10-01T12:00:00.1234567Z hello world",
+			want: &WorkflowLogLine{Time: ts, Text: "hello world", Message: "hello world"},
+		},
+		{
+			in:   "no timestamp",
+			want: &WorkflowLogLine{Text: "no timestamp", Message: "no timestamp"},
+		},
+		{
+			in:   "2023-10-01T12:00:00.1234567Z ##[group]Run actions/checkout@v4\r",
+			want: &WorkflowLogLine{Time: ts, Text: "##[group]Run actions/checkout@v4", Command: "group", Message: "Run actions/checkout@v4"},
+		},
+		{
+			in:   "2023-10-01T12:00:00.1234567Z ##[error]Process completed with exit code 1.",
+			want: &WorkflowLogLine{Time: ts, Text: "##[error]Process completed with exit code 1.", Command: "error", Message: "Process completed with exit code 1."},
+		},
+		{
+			in: "2023-10-01T12:00:00.1234567Z ::warning file=a%2Cb.go,line=3,title=T%3A1::bad%0Anews 100%25",
+			want: &WorkflowLogLine{
+				Time:       ts,
+				Text:       "::warning file=a%2Cb.go,line=3,title=T%3A1::bad%0Anews 100%25",
+				Command:    "warning",
+				Message:    "bad\nnews 100%",
+				Properties: map[string]string{"file": "a,b.go", "line": "3", "title": "T:1"},
+			},
+		},
+		{
+			in:   "::endgroup::",
+			want: &WorkflowLogLine{Text: "::endgroup::", Command: "endgroup", Message: ""},
+		},
+		{
+			in:   ":: not a command",
+			want: &WorkflowLogLine{Text: ":: not a command", Message: ":: not a command"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ParseWorkflowLogLine(tt.in); !cmp.Equal(got, tt.want) {
+			t.Errorf("ParseWorkflowLogLine(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWorkflowStepLog_ExcerptAndAnnotations(t *testing.T) {
+	var lines []*WorkflowLogLine
+	for _, s := range []string{"a", "b", "##[warning]w", "c", "##[error]boom", "##[debug]after"} {
+		lines = append(lines, ParseWorkflowLogLine(s))
+	}
+	step := &WorkflowStepLog{Lines: lines}
+
+	var got []string
+	for _, l := range step.Excerpt(3) {
+		got = append(got, l.Text)
+	}
+	if want := []string{"##[warning]w", "c", "##[error]boom"}; !cmp.Equal(got, want) {
+		t.Errorf("Excerpt(3) = %v, want %v", got, want)
+	}
+
+	got = nil
+	for _, l := range step.Annotations() {
+		got = append(got, l.Command)
+	}
+	if want := []string{"warning", "error"}; !cmp.Equal(got, want) {
+		t.Errorf("Annotations = %v, want %v", got, want)
+	}
+
+	clean := &WorkflowStepLog{Lines: lines[:2]}
+	if got := clean.Excerpt(5); len(got) != 2 {
+		t.Errorf("Excerpt(5) of a clean step returned %v lines, want 2", len(got))
+	}
+}
+
+func TestWorkflowLogReader(t *testing.T) {
+	r := NewWorkflowLogReader(strings.NewReader("\ufeff2023-10-01T12:00:00Z first\n2023-10-01T12:00:01Z second\n"))
+	var got []string
+	for {
+		line, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		got = append(got, line.Text)
+	}
+	if want := []string{"first", "second"}; !cmp.Equal(got, want) {
+		t.Errorf("read %q, want %q", got, want)
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+func testLogArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestActionsService_DownloadWorkflowRunLogs(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	archive := testLogArchive(t, map[string]string{
+		"0_build.txt":               "\ufeff2023-10-01T12:00:00Z setup\n2023-10-01T12:00:01Z ##[error]failed\n",
+		"1_lint.txt":                "2023-10-01T12:00:00Z lint ok\n",
+		"build/1_Set up job.txt":    "\ufeff2023-10-01T12:00:00Z setup\n",
+		"build/10_Post job.txt":     "2023-10-01T12:00:02Z cleanup\n",
+		"build/2_Run make test.txt": "2023-10-01T12:00:01Z ##[error]failed\n",
+		"lint/1_Set up job.txt":     "2023-10-01T12:00:00Z lint ok\n",
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/logs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Redirect(w, r, serverURL+baseURLPath+"/storage/logs.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/logs.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("page") == "2" {
+			fmt.Fprint(w, `{"total_count":2,"jobs":[{"id":12,"name":"lint","steps":[{"number":1,"name":"Set up job"}]}]}`)
+			return
+		}
+		testFormValues(t, r, values{"per_page": "100"})
+		w.Header().Set("Link", `<https://api.github.com/repos/o/r/actions/runs/1/jobs?page=2>; rel="next"`)
+		fmt.Fprint(w, `{"total_count":2,"jobs":[{"id":11,"name":"build","steps":[
+			{"number":1,"name":"Set up job"},
+			{"number":2,"name":"Run make test","conclusion":"failure"},
+			{"number":10,"name":"Post job"}
+		]}]}`)
+	})
+
+	ctx := context.Background()
+	run, resp, err := client.Actions.DownloadWorkflowRunLogs(ctx, "o", "r", 1)
+	if err != nil {
+		t.Fatalf("Actions.DownloadWorkflowRunLogs returned error: %v", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Actions.DownloadWorkflowRunLogs returned response %+v, want 200 OK", resp)
+	}
+
+	if len(run.Jobs) != 2 || run.Jobs[0].Name != "build" || run.Jobs[1].Name != "lint" {
+		t.Fatalf("Actions.DownloadWorkflowRunLogs returned jobs %+v, want build and lint", run.Jobs)
+	}
+	build := run.Jobs[0]
+	if len(build.Lines) != 2 {
+		t.Errorf("build has %v lines, want 2", len(build.Lines))
+	}
+	var steps []string
+	for _, s := range build.Steps {
+		steps = append(steps, fmt.Sprintf("%v %v", s.Number, s.Name))
+	}
+	if want := []string{"1 Set up job", "2 Run make test", "10 Post job"}; !cmp.Equal(steps, want) {
+		t.Errorf("build steps = %v, want %v", steps, want)
+	}
+	if got := build.Step(2).Annotations(); len(got) != 1 || got[0].Message != "failed" {
+		t.Errorf("step 2 annotations = %+v, want the failure", got)
+	}
+	if got := build.Step(1).Lines[0].Text; got != "setup" {
+		t.Errorf("step 1 starts with %q, want setup", got)
+	}
+	if build.Step(3) != nil {
+		t.Error("Step(3) returned a step, want nil")
+	}
+	if build.Job.GetID() != 11 || run.Jobs[1].Job.GetID() != 12 {
+		t.Errorf("jobs were matched to %v and %v, want 11 and 12", build.Job.GetID(), run.Jobs[1].Job.GetID())
+	}
+	if got := build.Step(2).Step; got.GetConclusion() != "failure" {
+		t.Errorf("step 2 was matched to %+v, want the failed step", got)
+	}
+
+	const methodName = "DownloadWorkflowRunLogs"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.DownloadWorkflowRunLogs(ctx, "\n", "\n", 1)
+		return err
+	})
+}
+
+func TestActionsService_DownloadWorkflowRunAttemptLogs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testLogArchive(t, map[string]string{
+		"test  unit/1_Set up job.txt": "2023-10-01T12:00:00Z setup\n",
+		"test  unit/2_Run a b.txt":    "2023-10-01T12:00:01Z ok\n",
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/attempts/2/logs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write(archive)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/attempts/2/jobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"per_page": "100"})
+		fmt.Fprint(w, `{"total_count":1,"jobs":[{"id":7,"name":"test / unit","steps":[
+			{"number":1,"name":"Set up job"},
+			{"number":3,"name":"Run a/b"}
+		]}]}`)
+	})
+
+	ctx := context.Background()
+	run, _, err := client.Actions.DownloadWorkflowRunAttemptLogs(ctx, "o", "r", 1, 2)
+	if err != nil {
+		t.Fatalf("Actions.DownloadWorkflowRunAttemptLogs returned error: %v", err)
+	}
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Lines) != 2 || run.Jobs[0].Steps[0].Name != "Set up job" {
+		t.Fatalf("Actions.DownloadWorkflowRunAttemptLogs returned %+v", run.Jobs)
+	}
+	job := run.Jobs[0]
+	if job.Job.GetID() != 7 {
+		t.Errorf("job was matched to %+v, want job 7", job.Job)
+	}
+	if got := job.Step(2).Step.GetNumber(); got != 3 {
+		t.Errorf("step 2 was matched to step %v by name, want 3", got)
+	}
+
+	const methodName = "DownloadWorkflowRunAttemptLogs"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.DownloadWorkflowRunAttemptLogs(ctx, "\n", "\n", 1, 2)
+		return err
+	})
+}
+
+func TestActionsService_DownloadWorkflowJobLogs(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":2,"name":"build","steps":[
+			{"number":1,"name":"Set up job","started_at":"2023-10-01T12:00:00Z","completed_at":"2023-10-01T12:00:01Z"},
+			{"number":3,"name":"Post job","started_at":"2023-10-01T12:00:05Z","completed_at":"2023-10-01T12:00:06Z"},
+			{"number":2,"name":"Run make test","conclusion":"failure","started_at":"2023-10-01T12:00:01Z","completed_at":"2023-10-01T12:00:05Z"},
+			{"number":4,"name":"Skipped","conclusion":"skipped"}
+		]}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/jobs/2/logs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Redirect(w, r, serverURL+baseURLPath+"/storage/job.txt", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/job.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "\ufeff2023-10-01T12:00:00.5Z Current runner version\n"+
+			"2023-10-01T12:00:01.2Z ##[group]Run make test\n"+
+			"continued output\n"+
+			"2023-10-01T12:00:04.9Z ##[error]Process completed with exit code 2.\n"+
+			"2023-10-01T12:00:05.1Z Cleaning up orphan processes\n")
+	})
+
+	ctx := context.Background()
+	log, _, err := client.Actions.DownloadWorkflowJobLogs(ctx, "o", "r", 2)
+	if err != nil {
+		t.Fatalf("Actions.DownloadWorkflowJobLogs returned error: %v", err)
+	}
+	if log.Name != "build" || log.Job.GetID() != 2 || len(log.Lines) != 5 {
+		t.Errorf("Actions.DownloadWorkflowJobLogs returned %+v", log)
+	}
+
+	got := make(map[string][]string)
+	for _, s := range log.Steps {
+		for _, l := range s.Lines {
+			got[s.Name] = append(got[s.Name], l.Text)
+		}
+	}
+	want := map[string][]string{
+		"Set up job":    {"Current runner version"},
+		"Run make test": {"##[group]Run make test", "continued output", "##[error]Process completed with exit code 2."},
+		"Post job":      {"Cleaning up orphan processes"},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("step lines = %v, want %v", got, want)
+	}
+	if step := log.Step(2); step.Step.GetConclusion() != "failure" || step.Excerpt(1)[0].Command != "error" {
+		t.Errorf("step 2 = %+v, want the failed step", step)
+	}
+
+	const methodName = "DownloadWorkflowJobLogs"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.DownloadWorkflowJobLogs(ctx, "\n", "\n", 2)
+		return err
+	})
+}
+
+func TestActionsService_OpenWorkflowJobLogs_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/jobs/2/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	_, resp, err := client.Actions.OpenWorkflowJobLogs(ctx, "o", "r", 2)
+	if err == nil {
+		t.Error("Actions.OpenWorkflowJobLogs returned nil error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Actions.OpenWorkflowJobLogs returned response %+v, want 404", resp)
+	}
+}
@@ -105,6 +105,43 @@ type PendingDeploymentsRequest struct {
 	Comment string `json:"comment"`
 }
 
+// EnvironmentApproval represents a review of the deployments of a workflow
+// run to one or more environments.
+type EnvironmentApproval struct {
+	Environments []*Environment `json:"environments,omitempty"`
+	// State can be one of: "approved", "rejected", "pending".
+	State   *string `json:"state,omitempty"`
+	User    *User   `json:"user,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// PendingDeployment represents a deployment of a workflow run that is
+// waiting for protection rules to pass.
+type PendingDeployment struct {
+	Environment           *PendingDeploymentEnvironment `json:"environment,omitempty"`
+	WaitTimer             *int64                        `json:"wait_timer,omitempty"`
+	WaitTimerStartedAt    *Timestamp                    `json:"wait_timer_started_at,omitempty"`
+	CurrentUserCanApprove *bool                         `json:"current_user_can_approve,omitempty"`
+	Reviewers             []*RequiredReviewer           `json:"reviewers,omitempty"`
+}
+
+// PendingDeploymentEnvironment represents the environment of a pending deployment.
+type PendingDeploymentEnvironment struct {
+	ID      *int64  `json:"id,omitempty"`
+	NodeID  *string `json:"node_id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	URL     *string `json:"url,omitempty"`
+	HTMLURL *string `json:"html_url,omitempty"`
+}
+
+// ReviewCustomDeploymentProtectionRuleRequest specifies body parameters to ReviewCustomDeploymentProtectionRule.
+type ReviewCustomDeploymentProtectionRuleRequest struct {
+	EnvironmentName string `json:"environment_name"`
+	// State can be one of: "approved", "rejected".
+	State   string `json:"state"`
+	Comment string `json:"comment,omitempty"`
+}
+
 type ReferencedWorkflow struct {
 	Path *string `json:"path,omitempty"`
 	SHA  *string `json:"sha,omitempty"`
@@ -311,6 +348,41 @@ func (s *ActionsService) CancelWorkflowRunByID(ctx context.Context, owner, repo
 	return s.client.Do(ctx, req, nil)
 }
 
+// ForceCancelWorkflowRunByID cancels a workflow run by ID, bypassing any
+// conditions that would otherwise keep it running, such as always() steps.
+// It should only be used when CancelWorkflowRunByID fails to stop a run.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#force-cancel-a-workflow-run
+//
+//meta:operation POST /repos/{owner}/{repo}/actions/runs/{run_id}/force-cancel
+func (s *ActionsService) ForceCancelWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/force-cancel", owner, repo, runID)
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// ApproveWorkflowRunByID approves a workflow run for a pull request from a
+// public fork of a first-time contributor.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#approve-a-workflow-run-for-a-fork-pull-request
+//
+//meta:operation POST /repos/{owner}/{repo}/actions/runs/{run_id}/approve
+func (s *ActionsService) ApproveWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/approve", owner, repo, runID)
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
 // GetWorkflowRunLogs gets a redirect URL to download a plain text file of logs for a workflow run.
 //
 // GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#download-workflow-run-logs
@@ -408,3 +480,64 @@ func (s *ActionsService) PendingDeployments(ctx context.Context, owner, repo str
 
 	return deployments, resp, nil
 }
+
+// GetWorkflowRunReviewHistory gets the reviews of the deployments of a workflow run.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#get-the-review-history-for-a-workflow-run
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/approvals
+func (s *ActionsService) GetWorkflowRunReviewHistory(ctx context.Context, owner, repo string, runID int64) ([]*EnvironmentApproval, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/approvals", owner, repo, runID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var approvals []*EnvironmentApproval
+	resp, err := s.client.Do(ctx, req, &approvals)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return approvals, resp, nil
+}
+
+// GetPendingDeployments gets the deployments of a workflow run that are waiting for protection rules to pass.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#get-pending-deployments-for-a-workflow-run
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/pending_deployments
+func (s *ActionsService) GetPendingDeployments(ctx context.Context, owner, repo string, runID int64) ([]*PendingDeployment, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/pending_deployments", owner, repo, runID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var deployments []*PendingDeployment
+	resp, err := s.client.Do(ctx, req, &deployments)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deployments, resp, nil
+}
+
+// ReviewCustomDeploymentProtectionRule approves or rejects a custom deployment protection rule
+// that is gating the deployment of a workflow run to an environment.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#review-custom-deployment-protection-rules-for-a-workflow-run
+//
+//meta:operation POST /repos/{owner}/{repo}/actions/runs/{run_id}/deployment_protection_rule
+func (s *ActionsService) ReviewCustomDeploymentProtectionRule(ctx context.Context, owner, repo string, runID int64, request *ReviewCustomDeploymentProtectionRuleRequest) (*Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/deployment_protection_rule", owner, repo, runID)
+
+	req, err := s.client.NewRequest("POST", u, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -1296,3 +1296,228 @@ func TestActionService_PendingDeployments(t *testing.T) {
 		return resp, err
 	})
 }
+
+func TestActionsService_ForceCancelWorkflowRunByID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/3434/force-cancel", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	ctx := context.Background()
+	resp, err := client.Actions.ForceCancelWorkflowRunByID(ctx, "o", "r", 3434)
+	if _, ok := err.(*AcceptedError); !ok {
+		t.Errorf("Actions.ForceCancelWorkflowRunByID returned error: %v (want AcceptedError)", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Actions.ForceCancelWorkflowRunByID returned status: %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+
+	const methodName = "ForceCancelWorkflowRunByID"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.ForceCancelWorkflowRunByID(ctx, "\n", "\n", 3434)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Actions.ForceCancelWorkflowRunByID(ctx, "o", "r", 3434)
+	})
+}
+
+func TestActionsService_ApproveWorkflowRunByID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/3434/approve", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ctx := context.Background()
+	resp, err := client.Actions.ApproveWorkflowRunByID(ctx, "o", "r", 3434)
+	if err != nil {
+		t.Errorf("Actions.ApproveWorkflowRunByID returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Actions.ApproveWorkflowRunByID returned status: %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	const methodName = "ApproveWorkflowRunByID"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.ApproveWorkflowRunByID(ctx, "\n", "\n", 3434)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Actions.ApproveWorkflowRunByID(ctx, "o", "r", 3434)
+	})
+}
+
+func TestActionsService_GetWorkflowRunReviewHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/399444496/approvals", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"environments":[{"id":161088068,"name":"staging"}],"state":"approved","user":{"login":"octocat"},"comment":"Ship it!"}]`)
+	})
+
+	ctx := context.Background()
+	approvals, _, err := client.Actions.GetWorkflowRunReviewHistory(ctx, "o", "r", 399444496)
+	if err != nil {
+		t.Errorf("Actions.GetWorkflowRunReviewHistory returned error: %v", err)
+	}
+
+	want := []*EnvironmentApproval{
+		{
+			Environments: []*Environment{{ID: Int64(161088068), Name: String("staging")}},
+			State:        String("approved"),
+			User:         &User{Login: String("octocat")},
+			Comment:      String("Ship it!"),
+		},
+	}
+	if !cmp.Equal(approvals, want) {
+		t.Errorf("Actions.GetWorkflowRunReviewHistory returned %+v, want %+v", approvals, want)
+	}
+
+	const methodName = "GetWorkflowRunReviewHistory"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.GetWorkflowRunReviewHistory(ctx, "\n", "\n", 399444496)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.GetWorkflowRunReviewHistory(ctx, "o", "r", 399444496)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_GetPendingDeployments(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/399444496/pending_deployments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{
+			"environment":{"id":161088068,"node_id":"MDExOkVudmlyb25tZW50MTYxMDg4MDY4","name":"staging"},
+			"wait_timer":30,
+			"wait_timer_started_at":"2020-11-23T22:00:40Z",
+			"current_user_can_approve":true,
+			"reviewers":[{"type":"User","reviewer":{"login":"octocat","id":1}},{"type":"Team","reviewer":{"name":"Justice League","id":1}}]
+		}]`)
+	})
+
+	ctx := context.Background()
+	deployments, _, err := client.Actions.GetPendingDeployments(ctx, "o", "r", 399444496)
+	if err != nil {
+		t.Errorf("Actions.GetPendingDeployments returned error: %v", err)
+	}
+
+	want := []*PendingDeployment{
+		{
+			Environment: &PendingDeploymentEnvironment{
+				ID:     Int64(161088068),
+				NodeID: String("MDExOkVudmlyb25tZW50MTYxMDg4MDY4"),
+				Name:   String("staging"),
+			},
+			WaitTimer:             Int64(30),
+			WaitTimerStartedAt:    &Timestamp{time.Date(2020, time.November, 23, 22, 0, 40, 0, time.UTC)},
+			CurrentUserCanApprove: Bool(true),
+			Reviewers: []*RequiredReviewer{
+				{Type: String("User"), Reviewer: &User{Login: String("octocat"), ID: Int64(1)}},
+				{Type: String("Team"), Reviewer: &Team{Name: String("Justice League"), ID: Int64(1)}},
+			},
+		},
+	}
+	if !cmp.Equal(deployments, want) {
+		t.Errorf("Actions.GetPendingDeployments returned %+v, want %+v", deployments, want)
+	}
+
+	const methodName = "GetPendingDeployments"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Actions.GetPendingDeployments(ctx, "\n", "\n", 399444496)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Actions.GetPendingDeployments(ctx, "o", "r", 399444496)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestActionsService_ReviewCustomDeploymentProtectionRule(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := &ReviewCustomDeploymentProtectionRuleRequest{EnvironmentName: "production", State: "approved", Comment: "All tests passed"}
+
+	mux.HandleFunc("/repos/o/r/actions/runs/399444496/deployment_protection_rule", func(w http.ResponseWriter, r *http.Request) {
+		v := new(ReviewCustomDeploymentProtectionRuleRequest)
+		assertNilError(t, json.NewDecoder(r.Body).Decode(v))
+
+		testMethod(t, r, "POST")
+		if !cmp.Equal(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	resp, err := client.Actions.ReviewCustomDeploymentProtectionRule(ctx, "o", "r", 399444496, input)
+	if err != nil {
+		t.Errorf("Actions.ReviewCustomDeploymentProtectionRule returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Actions.ReviewCustomDeploymentProtectionRule returned status: %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	const methodName = "ReviewCustomDeploymentProtectionRule"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.ReviewCustomDeploymentProtectionRule(ctx, "\n", "\n", 399444496, input)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Actions.ReviewCustomDeploymentProtectionRule(ctx, "o", "r", 399444496, input)
+	})
+}
+
+func TestEnvironmentApproval_Marshal(t *testing.T) {
+	testJSONMarshal(t, &EnvironmentApproval{}, "{}")
+
+	u := &EnvironmentApproval{
+		Environments: []*Environment{{ID: Int64(1), Name: String("staging")}},
+		State:        String("rejected"),
+		User:         &User{Login: String("l")},
+		Comment:      String("c"),
+	}
+
+	want := `{
+		"environments": [{"id": 1, "name": "staging"}],
+		"state": "rejected",
+		"user": {"login": "l"},
+		"comment": "c"
+	}`
+
+	testJSONMarshal(t, u, want)
+}
+
+func TestReviewCustomDeploymentProtectionRuleRequest_Marshal(t *testing.T) {
+	u := &ReviewCustomDeploymentProtectionRuleRequest{EnvironmentName: "e", State: "rejected"}
+
+	want := `{
+		"environment_name": "e",
+		"state": "rejected"
+	}`
+
+	testJSONMarshal(t, u, want)
+}
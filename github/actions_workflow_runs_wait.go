@@ -0,0 +1,322 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultWorkflowPollInterval    = 5 * time.Second
+	defaultMaxWorkflowPollInterval = time.Minute
+
+	// workflowDispatchClockSkew is how long before the dispatch a run
+	// created by it may appear to have been created, as GitHub's clock may
+	// differ from the local one.
+	workflowDispatchClockSkew = time.Minute
+)
+
+// WaitForWorkflowRunOptions specifies optional parameters to
+// ActionsService.DispatchWorkflowByFileName, ActionsService.WaitForWorkflowRun
+// and ActionsService.DispatchWorkflowAndWait.
+type WaitForWorkflowRunOptions struct {
+	// Actor is the login of the user a dispatched run is expected to be
+	// triggered by. It defaults to the authenticated user, if the client
+	// authenticates as a user rather than as an app installation.
+	Actor string
+
+	// PollInterval is the delay between polls. It defaults to 5 seconds
+	// and doubles, up to MaxPollInterval, for every poll in which nothing
+	// changed.
+	PollInterval time.Duration
+
+	// MaxPollInterval is the longest delay between polls. It defaults to
+	// one minute.
+	MaxPollInterval time.Duration
+
+	// OnStatusChange, if set, is called whenever the status of the run or
+	// of one of its jobs changes, including when it is first observed.
+	OnStatusChange func(*WorkflowRunStatusChange)
+}
+
+// WorkflowRunStatusChange describes a change of the status of a workflow
+// run or of one of its jobs.
+type WorkflowRunStatusChange struct {
+	// Run is the workflow run as last fetched.
+	Run *WorkflowRun
+
+	// Job is the job whose status changed, or nil if the status of the run
+	// itself changed.
+	Job *WorkflowJob
+
+	// PreviousStatus is empty when the run or job is first observed.
+	PreviousStatus string
+	Status         string
+	Conclusion     string
+}
+
+// WorkflowRunResult is the outcome of a completed workflow run.
+type WorkflowRunResult struct {
+	Run *WorkflowRun
+
+	// Conclusion is the conclusion of the run, such as "success" or
+	// "failure".
+	Conclusion string
+
+	// Jobs are the jobs of the latest attempt of the run.
+	Jobs []*WorkflowJob
+
+	// FailedJobs are the jobs among Jobs that failed or timed out.
+	FailedJobs []*WorkflowJob
+}
+
+// DispatchWorkflowByFileName triggers a workflow_dispatch event for the
+// workflow in workflowFileName and returns the workflow run it created.
+//
+// The dispatch API does not return the run, so it is identified among the
+// workflow_dispatch runs of the workflow as the first new run whose head
+// SHA is that of event.Ref, whose actor is opts.Actor, and which was
+// created after the dispatch. If event.Ref is moved concurrently, the run
+// may not be found; ctx should therefore carry a deadline.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#list-workflow-runs-for-a-workflow
+// GitHub API docs: https://docs.github.com/rest/actions/workflows#create-a-workflow-dispatch-event
+// GitHub API docs: https://docs.github.com/rest/commits/commits#get-a-commit
+// GitHub API docs: https://docs.github.com/rest/users/users#get-the-authenticated-user
+//
+//meta:operation POST /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches
+//meta:operation GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs
+//meta:operation GET /repos/{owner}/{repo}/commits/{ref}
+//meta:operation GET /user
+func (s *ActionsService) DispatchWorkflowByFileName(ctx context.Context, owner, repo, workflowFileName string, event CreateWorkflowDispatchEventRequest, opts *WaitForWorkflowRunOptions) (*WorkflowRun, error) {
+	if opts == nil {
+		opts = &WaitForWorkflowRunOptions{}
+	}
+
+	sha, _, err := s.client.Repositories.GetCommitSHA1(ctx, owner, repo, event.Ref, "")
+	if err != nil {
+		return nil, err
+	}
+	actor := opts.Actor
+	if actor == "" {
+		// Installation tokens cannot fetch the authenticated user; the
+		// actor is then not checked.
+		if user, _, err := s.client.Users.Get(ctx, ""); err == nil {
+			actor = user.GetLogin()
+		}
+	}
+
+	listOpts := &ListWorkflowRunsOptions{
+		Actor:       actor,
+		Event:       "workflow_dispatch",
+		HeadSHA:     sha,
+		Created:     ">=" + time.Now().Add(-workflowDispatchClockSkew).UTC().Format(time.RFC3339),
+		ListOptions: ListOptions{PerPage: 100},
+	}
+	listRuns := func() (runs *WorkflowRuns, err error) {
+		err = RetryOnRateLimit(ctx, func() (resp *Response, err error) {
+			runs, resp, err = s.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFileName, listOpts)
+			return resp, err
+		})
+		return runs, err
+	}
+
+	// Runs matching the same criteria that already exist were not created
+	// by this dispatch.
+	existing, err := listRuns()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool)
+	for _, r := range existing.WorkflowRuns {
+		seen[r.GetID()] = true
+	}
+
+	if _, err := s.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowFileName, event); err != nil {
+		return nil, err
+	}
+
+	p := newWorkflowPoller(opts)
+	for {
+		if err := p.wait(ctx, false); err != nil {
+			return nil, err
+		}
+		runs, err := listRuns()
+		if err != nil {
+			return nil, err
+		}
+		// Runs are listed newest first.
+		var found *WorkflowRun
+		for _, r := range runs.WorkflowRuns {
+			if !seen[r.GetID()] {
+				found = r
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+}
+
+// WaitForWorkflowRun polls a workflow run and its jobs until the run
+// completes, reporting status changes to opts.OnStatusChange. A run that
+// completes unsuccessfully is not an error; its conclusion and failed jobs
+// are reported in the result.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#list-jobs-for-a-workflow-run
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#get-a-workflow-run
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs
+func (s *ActionsService) WaitForWorkflowRun(ctx context.Context, owner, repo string, runID int64, opts *WaitForWorkflowRunOptions) (*WorkflowRunResult, error) {
+	if opts == nil {
+		opts = &WaitForWorkflowRunOptions{}
+	}
+
+	runStatus := ""
+	jobStatus := make(map[int64]string)
+	p := newWorkflowPoller(opts)
+	for {
+		var run *WorkflowRun
+		err := RetryOnRateLimit(ctx, func() (resp *Response, err error) {
+			run, resp, err = s.GetWorkflowRunByID(ctx, owner, repo, runID)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		jobs, err := s.listAllWorkflowJobs(ctx, owner, repo, runID)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for _, job := range jobs {
+			previous, status := jobStatus[job.GetID()], job.GetStatus()
+			if status == previous {
+				continue
+			}
+			changed = true
+			jobStatus[job.GetID()] = status
+			if opts.OnStatusChange != nil {
+				opts.OnStatusChange(&WorkflowRunStatusChange{Run: run, Job: job, PreviousStatus: previous, Status: status, Conclusion: job.GetConclusion()})
+			}
+		}
+		if status := run.GetStatus(); status != runStatus {
+			changed = true
+			if opts.OnStatusChange != nil {
+				opts.OnStatusChange(&WorkflowRunStatusChange{Run: run, PreviousStatus: runStatus, Status: status, Conclusion: run.GetConclusion()})
+			}
+			runStatus = status
+		}
+
+		if runStatus == "completed" {
+			result := &WorkflowRunResult{Run: run, Conclusion: run.GetConclusion(), Jobs: jobs}
+			for _, job := range jobs {
+				switch job.GetConclusion() {
+				case "failure", "timed_out":
+					result.FailedJobs = append(result.FailedJobs, job)
+				}
+			}
+			return result, nil
+		}
+
+		if err := p.wait(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// DispatchWorkflowAndWait triggers a workflow_dispatch event for the
+// workflow in workflowFileName and waits for the run it created to
+// complete. See DispatchWorkflowByFileName and WaitForWorkflowRun.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-jobs#list-jobs-for-a-workflow-run
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#get-a-workflow-run
+// GitHub API docs: https://docs.github.com/rest/actions/workflow-runs#list-workflow-runs-for-a-workflow
+// GitHub API docs: https://docs.github.com/rest/actions/workflows#create-a-workflow-dispatch-event
+// GitHub API docs: https://docs.github.com/rest/commits/commits#get-a-commit
+// GitHub API docs: https://docs.github.com/rest/users/users#get-the-authenticated-user
+//
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}
+//meta:operation GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs
+//meta:operation POST /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches
+//meta:operation GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs
+//meta:operation GET /repos/{owner}/{repo}/commits/{ref}
+//meta:operation GET /user
+func (s *ActionsService) DispatchWorkflowAndWait(ctx context.Context, owner, repo, workflowFileName string, event CreateWorkflowDispatchEventRequest, opts *WaitForWorkflowRunOptions) (*WorkflowRunResult, error) {
+	run, err := s.DispatchWorkflowByFileName(ctx, owner, repo, workflowFileName, event, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.WaitForWorkflowRun(ctx, owner, repo, run.GetID(), opts)
+}
+
+// listAllWorkflowJobs returns all jobs of the latest attempt of a run.
+func (s *ActionsService) listAllWorkflowJobs(ctx context.Context, owner, repo string, runID int64) ([]*WorkflowJob, error) {
+	var all []*WorkflowJob
+	opts := &ListWorkflowJobsOptions{Filter: "latest", ListOptions: ListOptions{PerPage: 100}}
+	for {
+		var (
+			jobs *Jobs
+			resp *Response
+		)
+		err := RetryOnRateLimit(ctx, func() (_ *Response, err error) {
+			jobs, resp, err = s.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, jobs.Jobs...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// workflowPoller spaces out polls with exponential backoff.
+type workflowPoller struct {
+	interval time.Duration
+	max      time.Duration
+	next     time.Duration
+}
+
+func newWorkflowPoller(opts *WaitForWorkflowRunOptions) *workflowPoller {
+	p := &workflowPoller{interval: opts.PollInterval, max: opts.MaxPollInterval}
+	if p.interval <= 0 {
+		p.interval = defaultWorkflowPollInterval
+	}
+	if p.max <= 0 {
+		p.max = defaultMaxWorkflowPollInterval
+	}
+	if p.max < p.interval {
+		p.max = p.interval
+	}
+	p.next = p.interval
+	return p
+}
+
+// wait sleeps until the next poll. If something changed since the last
+// poll, the delay is reset; otherwise it is doubled.
+func (p *workflowPoller) wait(ctx context.Context, changed bool) error {
+	if changed {
+		p.next = p.interval
+	}
+	t := time.NewTimer(p.next)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+	}
+	if p.next *= 2; p.next > p.max {
+		p.next = p.max
+	}
+	return nil
+}
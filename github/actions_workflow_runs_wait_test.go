@@ -0,0 +1,199 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestActionsService_DispatchWorkflowByFileName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "abc123")
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"octocat"}`)
+	})
+
+	var mu sync.Mutex
+	dispatched := false
+	mux.HandleFunc("/repos/o/r/actions/workflows/deploy.yml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"ref":"main","inputs":{"env":"prod"}}`+"\n")
+		mu.Lock()
+		dispatched = true
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	polls := 0
+	mux.HandleFunc("/repos/o/r/actions/workflows/deploy.yml/runs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.FormValue("created"); !strings.HasPrefix(got, ">=") {
+			t.Errorf("created = %q, want a lower bound", got)
+		}
+		testFormValues(t, r, values{
+			"actor":    "octocat",
+			"event":    "workflow_dispatch",
+			"head_sha": "abc123",
+			"created":  r.FormValue("created"),
+			"per_page": "100",
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case !dispatched:
+			fmt.Fprint(w, `{"workflow_runs":[{"id":1}]}`)
+		case polls == 0:
+			polls++
+			fmt.Fprint(w, `{"workflow_runs":[{"id":1}]}`)
+		default:
+			fmt.Fprint(w, `{"workflow_runs":[{"id":3},{"id":2},{"id":1}]}`)
+		}
+	})
+
+	ctx := context.Background()
+	event := CreateWorkflowDispatchEventRequest{Ref: "main", Inputs: map[string]interface{}{"env": "prod"}}
+	run, err := client.Actions.DispatchWorkflowByFileName(ctx, "o", "r", "deploy.yml", event, &WaitForWorkflowRunOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Actions.DispatchWorkflowByFileName returned error: %v", err)
+	}
+	if run.GetID() != 2 {
+		t.Errorf("Actions.DispatchWorkflowByFileName returned run %v, want 2", run.GetID())
+	}
+
+	const methodName = "DispatchWorkflowByFileName"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.DispatchWorkflowByFileName(ctx, "\n", "\n", "\n", event, nil)
+		return err
+	})
+}
+
+func TestActionsService_WaitForWorkflowRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	poll := 0
+	runs := []string{
+		`{"id":1,"status":"queued"}`,
+		`{"id":1,"status":"in_progress"}`,
+		`{"id":1,"status":"in_progress"}`,
+		`{"id":1,"status":"completed","conclusion":"failure"}`,
+	}
+	jobs := []string{
+		`{"total_count":0,"jobs":[]}`,
+		`{"total_count":2,"jobs":[{"id":10,"status":"in_progress"},{"id":11,"status":"queued"}]}`,
+		`{"total_count":2,"jobs":[{"id":10,"status":"completed","conclusion":"success"},{"id":11,"status":"in_progress"}]}`,
+		`{"total_count":2,"jobs":[{"id":10,"status":"completed","conclusion":"success"},{"id":11,"status":"completed","conclusion":"failure"}]}`,
+	}
+	mux.HandleFunc("/repos/o/r/actions/runs/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprint(w, runs[poll])
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"filter": "latest", "per_page": "100"})
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprint(w, jobs[poll])
+		poll++
+	})
+
+	var changes []string
+	opts := &WaitForWorkflowRunOptions{
+		PollInterval: time.Millisecond,
+		OnStatusChange: func(c *WorkflowRunStatusChange) {
+			subject := "run"
+			if c.Job != nil {
+				subject = fmt.Sprintf("job %v", c.Job.GetID())
+			}
+			changes = append(changes, fmt.Sprintf("%v: %q -> %v %v", subject, c.PreviousStatus, c.Status, c.Conclusion))
+		},
+	}
+
+	ctx := context.Background()
+	result, err := client.Actions.WaitForWorkflowRun(ctx, "o", "r", 1, opts)
+	if err != nil {
+		t.Fatalf("Actions.WaitForWorkflowRun returned error: %v", err)
+	}
+
+	want := []string{
+		`run: "" -> queued `,
+		`job 10: "" -> in_progress `,
+		`job 11: "" -> queued `,
+		`run: "queued" -> in_progress `,
+		`job 10: "in_progress" -> completed success`,
+		`job 11: "queued" -> in_progress `,
+		`job 11: "in_progress" -> completed failure`,
+		`run: "in_progress" -> completed failure`,
+	}
+	if !cmp.Equal(changes, want) {
+		t.Errorf("status changes = %v, want %v", changes, want)
+	}
+	if result.Conclusion != "failure" || len(result.Jobs) != 2 {
+		t.Errorf("Actions.WaitForWorkflowRun returned %+v", result)
+	}
+	if len(result.FailedJobs) != 1 || result.FailedJobs[0].GetID() != 11 {
+		t.Errorf("FailedJobs = %+v, want job 11", result.FailedJobs)
+	}
+
+	const methodName = "WaitForWorkflowRun"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Actions.WaitForWorkflowRun(ctx, "\n", "\n", 1, nil)
+		return err
+	})
+}
+
+func TestActionsService_WaitForWorkflowRun_deadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"status":"in_progress"}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jobs":[]}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Actions.WaitForWorkflowRun(ctx, "o", "r", 1, &WaitForWorkflowRunOptions{PollInterval: time.Millisecond, MaxPollInterval: 5 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Actions.WaitForWorkflowRun returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWorkflowPoller_backoff(t *testing.T) {
+	p := newWorkflowPoller(&WaitForWorkflowRunOptions{PollInterval: time.Millisecond, MaxPollInterval: 3 * time.Millisecond})
+	ctx := context.Background()
+
+	var got []time.Duration
+	for _, changed := range []bool{false, false, false, true, false} {
+		if err := p.wait(ctx, changed); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p.next)
+	}
+	want := []time.Duration{2 * time.Millisecond, 3 * time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+	if !cmp.Equal(got, want) {
+		t.Errorf("poll intervals = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DownloadToFileOptions specifies optional parameters to the methods that
+// download content to a local file.
+type DownloadToFileOptions struct {
+	// Checksum is the expected digest of the content, written as
+	// "sha256:<hex>". The algorithm may be sha1, sha256 or sha512. If the
+	// prefix is omitted, the algorithm is inferred from the digest length.
+	// The destination is left untouched if the content does not match.
+	Checksum string
+
+	// MaxRetries is the number of times an interrupted download is
+	// resumed. A resumed download asks for the remaining bytes with an
+	// HTTP Range request and starts over if the server ignores it.
+	MaxRetries int
+
+	// MaxRedirects is the number of 301 Moved Permanently responses from
+	// the GitHub API that are followed.
+	MaxRedirects int
+
+	// FollowRedirectsClient is used to fetch the content when GitHub
+	// redirects to another location, such as a signed storage URL.
+	// It defaults to http.DefaultClient.
+	FollowRedirectsClient *http.Client
+
+	// Progress, if set, is called as content is written with the number
+	// of bytes received so far and the total size, or -1 if the size is
+	// unknown.
+	Progress func(received, total int64)
+}
+
+// ChecksumMismatchError is returned when downloaded content does not match
+// the expected checksum.
+type ChecksumMismatchError struct {
+	Algorithm string
+	Want      string
+	Got       string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%v checksum mismatch: got %v, want %v", e.Algorithm, e.Got, e.Want)
+}
+
+// downloadToFile downloads the content at the API path u to path. The file
+// is written to a temporary file in the same directory and renamed into
+// place once it is complete and verified.
+func (c *Client) downloadToFile(ctx context.Context, u, path string, opts *DownloadToFileOptions, reqOpts ...RequestOption) error {
+	if opts == nil {
+		opts = &DownloadToFileOptions{}
+	}
+
+	var (
+		newHash func() hash.Hash
+		algo    string
+		want    string
+	)
+	if opts.Checksum != "" {
+		var err error
+		if algo, want, newHash, err = parseChecksum(opts.Checksum); err != nil {
+			return err
+		}
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := os.CreateTemp(dir, "."+base+".part-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer func() {
+		// The temporary file is gone once it has been renamed.
+		f.Close()
+		os.Remove(tmp)
+	}()
+
+	var received int64
+	for attempt := 0; ; attempt++ {
+		err = c.downloadAttempt(ctx, u, f, &received, opts, reqOpts)
+		if err == nil {
+			break
+		}
+		if attempt >= opts.MaxRetries || ctx.Err() != nil || !retryableDownloadError(err) {
+			return err
+		}
+	}
+
+	if newHash != nil {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		h := newHash()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			return &ChecksumMismatchError{Algorithm: algo, Want: want, Got: got}
+		}
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// downloadAttempt fetches the content of u from offset *received onwards
+// and appends it to f, advancing *received as bytes are written.
+func (c *Client) downloadAttempt(ctx context.Context, u string, f *os.File, received *int64, opts *DownloadToFileOptions, reqOpts []RequestOption) error {
+	offset := *received
+	withRange := func(req *http.Request) {
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+	}
+
+	resp, err := c.openDownload(ctx, u, opts, append(reqOpts, withRange)...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// What was written so far cannot be resumed; start over.
+		*received = 0
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		return errors.New("download could not be resumed")
+	}
+	if err := CheckResponse(resp); err != nil {
+		return err
+	}
+
+	total := int64(-1)
+	if resp.StatusCode == http.StatusPartialContent {
+		start, size, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return err
+		}
+		if start != offset {
+			*received = 0
+			return fmt.Errorf("server resumed the download at byte %v, want %v", start, offset)
+		}
+		total = size
+	} else {
+		offset = 0
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+	}
+
+	*received = offset
+	if err := f.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, rerr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := f.Write(buf[:n]); err != nil {
+				return err
+			}
+			*received += int64(n)
+			if opts.Progress != nil {
+				opts.Progress(*received, total)
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+
+	if total >= 0 && *received != total {
+		return fmt.Errorf("download ended after %v of %v bytes", *received, total)
+	}
+	return nil
+}
+
+// openDownload requests the content at the API path u, following a
+// redirect to another location, such as a signed storage URL, with
+// opts.FollowRedirectsClient. The request options apply to both requests.
+// It is the caller's responsibility to close the response body.
+func (c *Client) openDownload(ctx context.Context, u string, opts *DownloadToFileOptions, reqOpts ...RequestOption) (*http.Response, error) {
+	resp, err := c.roundTripWithOptionalFollowRedirect(ctx, u, opts.MaxRedirects, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect:
+		loc := resp.Header.Get("Location")
+		_ = resp.Body.Close()
+
+		req, err := http.NewRequest("GET", loc, nil)
+		if err != nil {
+			return nil, err
+		}
+		req = withContext(ctx, req)
+		for _, opt := range reqOpts {
+			opt(req)
+		}
+		// The API's Accept header does not apply to the redirected location.
+		req.Header.Set("Accept", "*/*")
+
+		client := opts.FollowRedirectsClient
+		if client == nil {
+			client = http.DefaultClient
+		}
+		if resp, err = client.Do(req); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// retryableDownloadError reports whether a download that failed with err
+// may succeed if it is resumed. Errors reported by the server for the
+// request itself, rather than its transfer, are final.
+func retryableDownloadError(err error) bool {
+	var (
+		errResp   *ErrorResponse
+		rateLimit *RateLimitError
+		abuse     *AbuseRateLimitError
+	)
+	switch {
+	case errors.As(err, &rateLimit), errors.As(err, &abuse):
+		return false
+	case errors.As(err, &errResp):
+		return errResp.Response == nil || errResp.Response.StatusCode >= 500
+	}
+	return true
+}
+
+// parseContentRange parses a Content-Range header of the form
+// "bytes start-end/size". The size is -1 if it is given as "*".
+func parseContentRange(s string) (start, size int64, err error) {
+	bad := fmt.Errorf("invalid Content-Range %q", s)
+	if !strings.HasPrefix(s, "bytes ") {
+		return 0, 0, bad
+	}
+	parts := strings.SplitN(strings.TrimPrefix(s, "bytes "), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, bad
+	}
+	rng := strings.SplitN(parts[0], "-", 2)
+	if len(rng) != 2 {
+		return 0, 0, bad
+	}
+	if start, err = strconv.ParseInt(rng[0], 10, 64); err != nil {
+		return 0, 0, bad
+	}
+	if parts[1] == "*" {
+		return start, -1, nil
+	}
+	if size, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return 0, 0, bad
+	}
+	return start, size, nil
+}
+
+// parseChecksum splits a checksum of the form "algo:hex" or "hex" into its
+// algorithm, lower-case digest and hash constructor.
+func parseChecksum(s string) (algo, digest string, newHash func() hash.Hash, err error) {
+	digest = strings.ToLower(strings.TrimSpace(s))
+	if parts := strings.SplitN(digest, ":", 2); len(parts) == 2 {
+		algo, digest = parts[0], parts[1]
+	} else {
+		switch len(digest) {
+		case 2 * sha1.Size:
+			algo = "sha1"
+		case 2 * sha256.Size:
+			algo = "sha256"
+		case 2 * sha512.Size:
+			algo = "sha512"
+		}
+	}
+
+	var size int
+	switch algo {
+	case "sha1":
+		newHash, size = sha1.New, sha1.Size
+	case "sha256":
+		newHash, size = sha256.New, sha256.Size
+	case "sha512":
+		newHash, size = sha512.New, sha512.Size
+	default:
+		return "", "", nil, fmt.Errorf("unsupported checksum %q", s)
+	}
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != 2*size {
+		return "", "", nil, fmt.Errorf("invalid %v checksum %q", algo, s)
+	}
+	return algo, digest, newHash, nil
+}
+
+// parseChecksums parses a checksums file in the format written by
+// sha256sum and similar tools, one "<hex>  <name>" pair per line, and
+// returns the digests keyed by file name.
+func parseChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksums line %q", line)
+		}
+		// A leading "*" marks a file that was read in binary mode.
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums, scanner.Err()
+}
+
+// downloadZip downloads the zip archive at the API path u to a temporary
+// file and opens it. It returns the size of the archive, the response and
+// a function that closes and removes the file.
+func (c *Client) downloadZip(ctx context.Context, u string) (zr *zip.Reader, size int64, response *Response, cleanup func(), err error) {
+	resp, err := c.openDownload(ctx, u, &DownloadToFileOptions{})
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	response = newResponse(resp)
+	if err := CheckResponse(resp); err != nil {
+		return nil, 0, response, nil, err
+	}
+
+	f, size, err := spoolToTempFile(resp.Body, "")
+	if err != nil {
+		return nil, 0, response, nil, err
+	}
+	cleanup = func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	if zr, err = zip.NewReader(f, size); err != nil {
+		cleanup()
+		return nil, 0, response, nil, err
+	}
+	return zr, size, response, cleanup, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_downloadToFile_resume(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	const content = "0123456789abcdefghij"
+	sum := sha256.Sum256([]byte(content))
+
+	mux.HandleFunc("/repos/o/r/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept", defaultMediaType)
+		http.Redirect(w, r, serverURL+baseURLPath+"/storage/asset", http.StatusFound)
+	})
+	var ranges []string
+	mux.HandleFunc("/storage/asset", func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		ranges = append(ranges, rng)
+		if rng == "" {
+			// Promise the whole file but break off half way through.
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			fmt.Fprint(w, content[:8])
+			return
+		}
+		if rng != "bytes=8-" {
+			t.Errorf("Range header is %q, want bytes=8-", rng)
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 8-%d/%d", len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, content[8:])
+	})
+
+	path := filepath.Join(t.TempDir(), "asset")
+	var last int64
+	err := client.Repositories.DownloadReleaseAssetToFile(context.Background(), "o", "r", 1, path, &DownloadToFileOptions{
+		Checksum:   "sha256:" + hex.EncodeToString(sum[:]),
+		MaxRetries: 1,
+		Progress:   func(received, total int64) { last = received },
+	})
+	if err != nil {
+		t.Fatalf("Repositories.DownloadReleaseAssetToFile returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Errorf("downloaded file contains %q, want %q", got, content)
+	}
+	if want := []string{"", "bytes=8-"}; !cmp.Equal(ranges, want) {
+		t.Errorf("Range headers were %q, want %q", ranges, want)
+	}
+	if last != int64(len(content)) {
+		t.Errorf("Progress last reported %v bytes, want %v", last, len(content))
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("download left %v files behind, want only the destination", len(entries))
+	}
+}
+
+func TestClient_downloadToFile_restartWhenRangeIgnored(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/repos/o/r/zipball", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Length", "6")
+		if calls == 1 {
+			fmt.Fprint(w, "abc")
+			return
+		}
+		fmt.Fprint(w, "abcdef")
+	})
+
+	path := filepath.Join(t.TempDir(), "archive.zip")
+	err := client.Repositories.DownloadArchiveToFile(context.Background(), "o", "r", Zipball, nil, path, &DownloadToFileOptions{MaxRetries: 2})
+	if err != nil {
+		t.Fatalf("Repositories.DownloadArchiveToFile returned error: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "abcdef" {
+		t.Errorf("downloaded file contains %q, want %q", got, "abcdef")
+	}
+}
+
+func TestClient_downloadToFile_checksumMismatch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/artifacts/1/zip", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "tampered")
+	})
+
+	path := filepath.Join(t.TempDir(), "artifact.zip")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.Actions.DownloadArtifactToFile(context.Background(), "o", "r", 1, path, &DownloadToFileOptions{
+		Checksum: strings.Repeat("0", 64),
+	})
+	var mismatch *ChecksumMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Actions.DownloadArtifactToFile returned error %v, want ChecksumMismatchError", err)
+	}
+	if mismatch.Algorithm != "sha256" {
+		t.Errorf("ChecksumMismatchError.Algorithm = %v, want sha256", mismatch.Algorithm)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "original" {
+		t.Errorf("destination contains %q after a failed download, want it untouched", got)
+	}
+}
+
+func TestClient_downloadToFile_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/repos/o/r/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+
+	path := filepath.Join(t.TempDir(), "asset")
+	err := client.Repositories.DownloadReleaseAssetToFile(context.Background(), "o", "r", 1, path, &DownloadToFileOptions{MaxRetries: 3})
+	if err == nil {
+		t.Fatal("Repositories.DownloadReleaseAssetToFile returned nil error, want error")
+	}
+	if calls != 1 {
+		t.Errorf("Repositories.DownloadReleaseAssetToFile made %v requests, want 1", calls)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("destination exists after a failed download: %v", err)
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sha1Hex := strings.Repeat("a", 40)
+	sha256Hex := strings.Repeat("B", 64)
+	tests := []struct {
+		in         string
+		wantAlgo   string
+		wantDigest string
+		wantErr    bool
+	}{
+		{in: sha1Hex, wantAlgo: "sha1", wantDigest: sha1Hex},
+		{in: sha256Hex, wantAlgo: "sha256", wantDigest: strings.ToLower(sha256Hex)},
+		{in: "sha512:" + strings.Repeat("c", 128), wantAlgo: "sha512", wantDigest: strings.Repeat("c", 128)},
+		{in: "sha256:" + sha1Hex, wantErr: true},
+		{in: "md5:" + strings.Repeat("d", 32), wantErr: true},
+		{in: strings.Repeat("z", 64), wantErr: true},
+	}
+	for _, tt := range tests {
+		algo, digest, _, err := parseChecksum(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseChecksum(%q) returned error %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if algo != tt.wantAlgo || digest != tt.wantDigest {
+			t.Errorf("parseChecksum(%q) = %v, %v, want %v, %v", tt.in, algo, digest, tt.wantAlgo, tt.wantDigest)
+		}
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantStart int64
+		wantSize  int64
+		wantErr   bool
+	}{
+		{in: "bytes 8-19/20", wantStart: 8, wantSize: 20},
+		{in: "bytes 0-9/*", wantStart: 0, wantSize: -1},
+		{in: "bytes */20", wantErr: true},
+		{in: "items 0-1/2", wantErr: true},
+	}
+	for _, tt := range tests {
+		start, size, err := parseContentRange(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseContentRange(%q) returned error %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if start != tt.wantStart || size != tt.wantSize {
+			t.Errorf("parseContentRange(%q) = %v, %v, want %v, %v", tt.in, start, size, tt.wantStart, tt.wantSize)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
 // ListRunnerApplicationDownloads lists self-hosted runner application binaries that can be downloaded and run.
@@ -75,6 +76,28 @@ func (s *EnterpriseService) CreateRegistrationToken(ctx context.Context, enterpr
 	return registrationToken, resp, nil
 }
 
+// CreateRemoveToken creates a token that can be used to remove a self-hosted runner from an enterprise.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#create-a-remove-token-for-an-enterprise
+//
+//meta:operation POST /enterprises/{enterprise}/actions/runners/remove-token
+func (s *EnterpriseService) CreateRemoveToken(ctx context.Context, enterprise string) (*RemoveToken, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/remove-token", enterprise)
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	removeToken := new(RemoveToken)
+	resp, err := s.client.Do(ctx, req, removeToken)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return removeToken, resp, nil
+}
+
 // ListRunners lists all the self-hosted runners for a enterprise.
 //
 // GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#list-self-hosted-runners-for-an-enterprise
@@ -101,6 +124,27 @@ func (s *EnterpriseService) ListRunners(ctx context.Context, enterprise string,
 	return runners, resp, nil
 }
 
+// GetRunner gets a specific self-hosted runner for an enterprise using its runner ID.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#get-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation GET /enterprises/{enterprise}/actions/runners/{runner_id}
+func (s *EnterpriseService) GetRunner(ctx context.Context, enterprise string, runnerID int64) (*Runner, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v", enterprise, runnerID)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runner := new(Runner)
+	resp, err := s.client.Do(ctx, req, runner)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return runner, resp, nil
+}
+
 // RemoveRunner forces the removal of a self-hosted runner from an enterprise using the runner id.
 //
 // GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#delete-a-self-hosted-runner-from-an-enterprise
@@ -116,3 +160,58 @@ func (s *EnterpriseService) RemoveRunner(ctx context.Context, enterprise string,
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// ListRunnerLabels lists all labels for a self-hosted runner in an enterprise.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#list-labels-for-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation GET /enterprises/{enterprise}/actions/runners/{runner_id}/labels
+func (s *EnterpriseService) ListRunnerLabels(ctx context.Context, enterprise string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v/labels", enterprise, runnerID)
+	return s.client.runnerLabels(ctx, "GET", u, nil)
+}
+
+// AddRunnerLabels adds custom labels to a self-hosted runner in an enterprise.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#add-custom-labels-to-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation POST /enterprises/{enterprise}/actions/runners/{runner_id}/labels
+func (s *EnterpriseService) AddRunnerLabels(ctx context.Context, enterprise string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v/labels", enterprise, runnerID)
+	return s.client.runnerLabels(ctx, "POST", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// SetRunnerLabels replaces all custom labels of a self-hosted runner in an enterprise.
+// Default labels, such as "self-hosted", are kept.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#set-custom-labels-for-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation PUT /enterprises/{enterprise}/actions/runners/{runner_id}/labels
+func (s *EnterpriseService) SetRunnerLabels(ctx context.Context, enterprise string, runnerID int64, labels []string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v/labels", enterprise, runnerID)
+	return s.client.runnerLabels(ctx, "PUT", u, &runnerLabelsRequest{Labels: labels})
+}
+
+// RemoveRunnerLabels removes all custom labels from a self-hosted runner in an enterprise
+// and returns the labels it has left.
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#remove-all-custom-labels-from-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation DELETE /enterprises/{enterprise}/actions/runners/{runner_id}/labels
+func (s *EnterpriseService) RemoveRunnerLabels(ctx context.Context, enterprise string, runnerID int64) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v/labels", enterprise, runnerID)
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
+
+// RemoveRunnerLabel removes a custom label from a self-hosted runner in an enterprise
+// and returns the labels it has left.
+//
+// Note: the label name is URL path escaped for you. See: https://pkg.go.dev/net/url#PathEscape .
+//
+// GitHub API docs: https://docs.github.com/enterprise-cloud@latest/rest/actions/self-hosted-runners#remove-a-custom-label-from-a-self-hosted-runner-for-an-enterprise
+//
+//meta:operation DELETE /enterprises/{enterprise}/actions/runners/{runner_id}/labels/{name}
+func (s *EnterpriseService) RemoveRunnerLabel(ctx context.Context, enterprise string, runnerID int64, name string) (*RunnerLabelsList, *Response, error) {
+	u := fmt.Sprintf("enterprises/%v/actions/runners/%v/labels/%v", enterprise, runnerID, url.PathEscape(name))
+	return s.client.runnerLabels(ctx, "DELETE", u, nil)
+}
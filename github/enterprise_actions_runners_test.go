@@ -208,3 +208,285 @@ func TestEnterpriseService_ListRunnerApplicationDownloads(t *testing.T) {
 		return resp, err
 	})
 }
+
+func TestEnterpriseService_ListRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Enterprise.ListRunnerLabels(ctx, "e", 23)
+	if err != nil {
+		t.Errorf("Enterprise.ListRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Enterprise.ListRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "ListRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.ListRunnerLabels(ctx, "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.ListRunnerLabels(ctx, "e", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_AddRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Enterprise.AddRunnerLabels(ctx, "e", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Enterprise.AddRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Enterprise.AddRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "AddRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.AddRunnerLabels(ctx, "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.AddRunnerLabels(ctx, "e", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_SetRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"labels":["gpu"]}`+"\n")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Enterprise.SetRunnerLabels(ctx, "e", 23, []string{"gpu"})
+	if err != nil {
+		t.Errorf("Enterprise.SetRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Enterprise.SetRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "SetRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.SetRunnerLabels(ctx, "\n", 23, []string{"gpu"})
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.SetRunnerLabels(ctx, "e", 23, []string{"gpu"})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_RemoveRunnerLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Enterprise.RemoveRunnerLabels(ctx, "e", 23)
+	if err != nil {
+		t.Errorf("Enterprise.RemoveRunnerLabels returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Enterprise.RemoveRunnerLabels returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveRunnerLabels"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.RemoveRunnerLabels(ctx, "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.RemoveRunnerLabels(ctx, "e", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_RemoveRunnerLabel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23/labels/my label", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"total_count":2,"labels":[{"id":1,"name":"self-hosted","type":"read-only"},{"id":2,"name":"gpu","type":"custom"}]}`)
+	})
+
+	ctx := context.Background()
+	labels, _, err := client.Enterprise.RemoveRunnerLabel(ctx, "e", 23, "my label")
+	if err != nil {
+		t.Errorf("Enterprise.RemoveRunnerLabel returned error: %v", err)
+	}
+
+	want := &RunnerLabelsList{
+		TotalCount: 2,
+		Labels: []*RunnerLabels{
+			{ID: Int64(1), Name: String("self-hosted"), Type: String("read-only")},
+			{ID: Int64(2), Name: String("gpu"), Type: String("custom")},
+		},
+	}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("Enterprise.RemoveRunnerLabel returned %+v, want %+v", labels, want)
+	}
+
+	const methodName = "RemoveRunnerLabel"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.RemoveRunnerLabel(ctx, "\n", 23, "my label")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.RemoveRunnerLabel(ctx, "e", 23, "my label")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_CreateRemoveToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/remove-token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"token":"AABF3JGZDX3P5PMEXLND6TS6FCWO6","expires_at":"2020-01-29T12:13:35.123Z"}`)
+	})
+
+	ctx := context.Background()
+	token, _, err := client.Enterprise.CreateRemoveToken(ctx, "e")
+	if err != nil {
+		t.Errorf("Enterprise.CreateRemoveToken returned error: %v", err)
+	}
+
+	want := &RemoveToken{Token: String("AABF3JGZDX3P5PMEXLND6TS6FCWO6"), ExpiresAt: &Timestamp{time.Date(2020, time.January, 29, 12, 13, 35, 123000000, time.UTC)}}
+	if !cmp.Equal(token, want) {
+		t.Errorf("Enterprise.CreateRemoveToken returned %+v, want %+v", token, want)
+	}
+
+	const methodName = "CreateRemoveToken"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.CreateRemoveToken(ctx, "\n")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.CreateRemoveToken(ctx, "e")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestEnterpriseService_GetRunner(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/enterprises/e/actions/runners/23", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":23,"name":"MBP","os":"macos","status":"online"}`)
+	})
+
+	ctx := context.Background()
+	runner, _, err := client.Enterprise.GetRunner(ctx, "e", 23)
+	if err != nil {
+		t.Errorf("Enterprise.GetRunner returned error: %v", err)
+	}
+
+	want := &Runner{
+		ID:     Int64(23),
+		Name:   String("MBP"),
+		OS:     String("macos"),
+		Status: String("online"),
+	}
+	if !cmp.Equal(runner, want) {
+		t.Errorf("Enterprise.GetRunner returned %+v, want %+v", runner, want)
+	}
+
+	const methodName = "GetRunner"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Enterprise.GetRunner(ctx, "\n", 23)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Enterprise.GetRunner(ctx, "e", 23)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
@@ -30,6 +30,8 @@ type SignatureVerification struct {
 //	commit.Signer = github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
 //		return openpgp.ArmoredDetachSign(w, openpgpEntity, r, nil)
 //	})
+//
+// To sign a commit with an SSH key, use NewSSHSigner.
 type MessageSigner interface {
 	Sign(w io.Writer, r io.Reader) error
 }
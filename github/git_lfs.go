@@ -0,0 +1,382 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaTypeLFS = "application/vnd.git-lfs+json"
+
+	lfsPointerVersion = "https://git-lfs.github.com/spec/v1"
+	// lfsPointerMaxSize is the size above which a file is never treated as
+	// an LFS pointer, as in the reference implementation.
+	lfsPointerMaxSize = 1024
+)
+
+// LFSPointer identifies a Git LFS object. It is what a pointer file,
+// committed to the repository in place of the content, records.
+type LFSPointer struct {
+	// OID is the hex encoded SHA-256 of the content.
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// NewLFSPointer reads r to the end and returns the pointer to its content.
+func NewLFSPointer(r io.Reader) (*LFSPointer, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return nil, err
+	}
+	return &LFSPointer{OID: hex.EncodeToString(h.Sum(nil)), Size: n}, nil
+}
+
+// ParseLFSPointer parses the contents of a pointer file.
+func ParseLFSPointer(data []byte) (*LFSPointer, error) {
+	if len(data) > lfsPointerMaxSize {
+		return nil, errors.New("too large to be an LFS pointer")
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) == 0 || lines[0] != "version "+lfsPointerVersion {
+		return nil, errors.New("not an LFS pointer")
+	}
+
+	p := &LFSPointer{Size: -1}
+	for _, line := range lines[1:] {
+		kv := strings.SplitN(line, " ", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid LFS pointer line %q", line)
+		}
+		switch kv[0] {
+		case "oid":
+			oid := strings.TrimPrefix(kv[1], "sha256:")
+			if oid == kv[1] || len(oid) != 2*sha256.Size || strings.ToLower(oid) != oid {
+				return nil, fmt.Errorf("invalid LFS pointer oid %q", kv[1])
+			}
+			if _, err := hex.DecodeString(oid); err != nil {
+				return nil, fmt.Errorf("invalid LFS pointer oid %q", kv[1])
+			}
+			p.OID = oid
+		case "size":
+			size, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil || size < 0 {
+				return nil, fmt.Errorf("invalid LFS pointer size %q", kv[1])
+			}
+			p.Size = size
+		}
+	}
+	if p.OID == "" || p.Size < 0 {
+		return nil, errors.New("LFS pointer is missing its oid or size")
+	}
+	return p, nil
+}
+
+// Bytes returns the contents of the pointer file for p.
+func (p *LFSPointer) Bytes() []byte {
+	return []byte(fmt.Sprintf("version %v\noid sha256:%v\nsize %d\n", lfsPointerVersion, p.OID, p.Size))
+}
+
+// LFSBatchRequest represents a request to the Git LFS batch API.
+type LFSBatchRequest struct {
+	// Operation can be one of: "download", "upload".
+	Operation string        `json:"operation"`
+	Transfers []string      `json:"transfers,omitempty"`
+	Ref       *LFSRef       `json:"ref,omitempty"`
+	Objects   []*LFSPointer `json:"objects"`
+	HashAlgo  *string       `json:"hash_algo,omitempty"`
+}
+
+// LFSRef is the ref an LFS batch request is made on behalf of.
+type LFSRef struct {
+	Name string `json:"name"`
+}
+
+// LFSBatchResponse represents a response from the Git LFS batch API.
+type LFSBatchResponse struct {
+	Transfer *string      `json:"transfer,omitempty"`
+	Objects  []*LFSObject `json:"objects"`
+	HashAlgo *string      `json:"hash_algo,omitempty"`
+}
+
+// LFSObject describes how to transfer one object in an LFS batch response.
+type LFSObject struct {
+	OID           string                `json:"oid"`
+	Size          int64                 `json:"size"`
+	Authenticated *bool                 `json:"authenticated,omitempty"`
+	Actions       map[string]*LFSAction `json:"actions,omitempty"`
+	Error         *LFSObjectError       `json:"error,omitempty"`
+}
+
+// LFSAction is a request to make to transfer an LFS object.
+type LFSAction struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn *int64            `json:"expires_in,omitempty"`
+	ExpiresAt *Timestamp        `json:"expires_at,omitempty"`
+}
+
+// LFSObjectError reports why a single object of a batch cannot be
+// transferred.
+type LFSObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *LFSObjectError) Error() string {
+	return fmt.Sprintf("LFS object error %v: %v", e.Code, e.Message)
+}
+
+// lfsURL returns the LFS server URL for a repository. The server is part of
+// the web host, not the API host.
+func (c *Client) lfsURL(owner, repo string) string {
+	u := *c.BaseURL
+	if u.Host == "api.github.com" {
+		u.Host = "github.com"
+		u.Path = "/"
+	} else {
+		u.Path = strings.TrimSuffix(u.Path, "api/v3/")
+	}
+	return fmt.Sprintf("%v%v/%v.git/info/lfs/objects/batch", u.String(), owner, repo)
+}
+
+// LFSClient is a client for the Git LFS batch API of repositories. The
+// batch API is part of the Git LFS protocol and is served by the web host
+// rather than the REST API.
+//
+// Git LFS docs: https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md
+type LFSClient struct {
+	client *Client
+}
+
+// NewLFSClient returns an LFSClient that uses the base URL and
+// authentication of client.
+func NewLFSClient(client *Client) *LFSClient {
+	return &LFSClient{client: client}
+}
+
+// Batch asks the Git LFS server how to transfer a batch of objects.
+func (c *LFSClient) Batch(ctx context.Context, owner, repo string, batch *LFSBatchRequest) (*LFSBatchResponse, *Response, error) {
+	req, err := c.client.NewRequest("POST", c.client.lfsURL(owner, repo), batch)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", mediaTypeLFS)
+	req.Header.Set("Content-Type", mediaTypeLFS)
+
+	result := new(LFSBatchResponse)
+	resp, err := c.client.Do(ctx, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// object makes a single object batch request and returns the object,
+// or its error.
+func (c *LFSClient) object(ctx context.Context, owner, repo, operation string, p *LFSPointer) (*LFSObject, error) {
+	batch, _, err := c.Batch(ctx, owner, repo, &LFSBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   []*LFSPointer{p},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range batch.Objects {
+		if o.OID != p.OID {
+			continue
+		}
+		if o.Error != nil {
+			return nil, o.Error
+		}
+		return o, nil
+	}
+	return nil, fmt.Errorf("LFS server did not return object %v", p.OID)
+}
+
+// Download downloads the content of an LFS object. Reading the
+// content to the end returns an error if it does not match p. It is the
+// caller's responsibility to close the ReadCloser.
+//
+// The content is fetched with http.DefaultClient, as the storage location
+// returned by the LFS server carries its own authentication.
+func (c *LFSClient) Download(ctx context.Context, owner, repo string, p *LFSPointer) (io.ReadCloser, error) {
+	o, err := c.object(ctx, owner, repo, "download", p)
+	if err != nil {
+		return nil, err
+	}
+	action := o.Actions["download"]
+	if action == nil {
+		return nil, fmt.Errorf("LFS server returned no download action for %v", p.OID)
+	}
+
+	resp, err := doLFSAction(ctx, "GET", action, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &lfsVerifyingReader{rc: resp.Body, want: p, h: sha256.New()}, nil
+}
+
+// Upload uploads content, from its current offset to its end, to the LFS
+// server, unless the server already has it, and returns its pointer.
+//
+// The content is sent with http.DefaultClient, as the storage location
+// returned by the LFS server carries its own authentication.
+func (c *LFSClient) Upload(ctx context.Context, owner, repo string, content io.ReadSeeker) (*LFSPointer, error) {
+	start, err := content.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	p, err := NewLFSPointer(content)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := c.object(ctx, owner, repo, "upload", p)
+	if err != nil {
+		return nil, err
+	}
+	upload := o.Actions["upload"]
+	if upload == nil {
+		// The server already has the object.
+		return p, nil
+	}
+
+	if _, err := content.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	resp, err := doLFSAction(ctx, "PUT", upload, io.NopCloser(content), p.Size)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if verify := o.Actions["verify"]; verify != nil {
+		body, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := doLFSAction(ctx, "POST", verify, io.NopCloser(bytes.NewReader(body)), int64(len(body)))
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+	}
+
+	return p, nil
+}
+
+// CreateLFSBlob uploads content to the LFS server and creates a blob
+// holding its pointer file. The blob's SHA can be used in a TreeEntry to
+// commit the file through the Git Data API. The content is uploaded as
+// with LFSClient.Upload.
+//
+// GitHub API docs: https://docs.github.com/rest/git/blobs#create-a-blob
+//
+//meta:operation POST /repos/{owner}/{repo}/git/blobs
+func (s *GitService) CreateLFSBlob(ctx context.Context, owner, repo string, content io.ReadSeeker) (*Blob, *LFSPointer, error) {
+	p, err := NewLFSClient(s.client).Upload(ctx, owner, repo, content)
+	if err != nil {
+		return nil, nil, err
+	}
+	blob, _, err := s.CreateBlob(ctx, owner, repo, &Blob{
+		Content:  String(string(p.Bytes())),
+		Encoding: String("utf-8"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return blob, p, nil
+}
+
+// ResolveContent returns the content of a file read with
+// RepositoriesService.GetContents. If the file is an LFS pointer, the
+// object it points to is downloaded; otherwise the file itself is
+// returned. It is the caller's responsibility to close the ReadCloser.
+func (c *LFSClient) ResolveContent(ctx context.Context, owner, repo string, content *RepositoryContent) (io.ReadCloser, error) {
+	data, err := content.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	p, err := ParseLFSPointer([]byte(data))
+	if err != nil {
+		return io.NopCloser(strings.NewReader(data)), nil
+	}
+	return c.Download(ctx, owner, repo, p)
+}
+
+// doLFSAction makes the request described by an LFS action and checks
+// that it succeeded.
+func doLFSAction(ctx context.Context, method string, action *LFSAction, body io.ReadCloser, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, action.Href, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withContext(ctx, req)
+	if body != nil {
+		req.Body = body
+		req.ContentLength = size
+		req.Header.Set("Content-Type", defaultMediaType)
+		if method == "POST" {
+			req.Header.Set("Content-Type", mediaTypeLFS)
+		}
+	}
+	req.Header.Set("Accept", mediaTypeLFS)
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// lfsVerifyingReader checks the content it reads against an LFS pointer.
+type lfsVerifyingReader struct {
+	rc   io.ReadCloser
+	want *LFSPointer
+	h    hash.Hash
+	n    int64
+}
+
+func (r *lfsVerifyingReader) Read(b []byte) (int, error) {
+	n, err := r.rc.Read(b)
+	r.h.Write(b[:n])
+	r.n += int64(n)
+	if err == io.EOF {
+		if r.n != r.want.Size {
+			return n, fmt.Errorf("LFS object %v is %v bytes, want %v", r.want.OID, r.n, r.want.Size)
+		}
+		if got := hex.EncodeToString(r.h.Sum(nil)); got != r.want.OID {
+			return n, fmt.Errorf("LFS object %v has checksum %v", r.want.OID, got)
+		}
+	}
+	return n, err
+}
+
+func (r *lfsVerifyingReader) Close() error {
+	return r.rc.Close()
+}
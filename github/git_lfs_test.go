@@ -0,0 +1,225 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const lfsTestContent = "large file\n"
+
+func lfsTestPointer(t *testing.T) *LFSPointer {
+	t.Helper()
+	p, err := NewLFSPointer(strings.NewReader(lfsTestContent))
+	if err != nil {
+		t.Fatalf("NewLFSPointer returned error: %v", err)
+	}
+	return p
+}
+
+func TestLFSPointer_roundTrip(t *testing.T) {
+	p := lfsTestPointer(t)
+	if p.Size != int64(len(lfsTestContent)) {
+		t.Errorf("NewLFSPointer size = %v, want %v", p.Size, len(lfsTestContent))
+	}
+
+	want := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%v\nsize 11\n", p.OID)
+	if got := string(p.Bytes()); got != want {
+		t.Errorf("LFSPointer.Bytes() = %q, want %q", got, want)
+	}
+
+	got, err := ParseLFSPointer(p.Bytes())
+	if err != nil {
+		t.Fatalf("ParseLFSPointer returned error: %v", err)
+	}
+	if !cmp.Equal(got, p) {
+		t.Errorf("ParseLFSPointer returned %+v, want %+v", got, p)
+	}
+
+	for _, bad := range []string{
+		"plain text",
+		"version https://git-lfs.github.com/spec/v1\nsize 11\n",
+		"version https://git-lfs.github.com/spec/v1\noid md5:abc\nsize 11\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:" + p.OID + "\nsize -1\n",
+		strings.Repeat("x", 2000),
+	} {
+		if _, err := ParseLFSPointer([]byte(bad)); err == nil {
+			t.Errorf("ParseLFSPointer(%q) returned nil error, want error", bad)
+		}
+	}
+}
+
+func TestClient_lfsURL(t *testing.T) {
+	c := NewClient(nil)
+	if got, want := c.lfsURL("o", "r"), "https://github.com/o/r.git/info/lfs/objects/batch"; got != want {
+		t.Errorf("lfsURL = %v, want %v", got, want)
+	}
+
+	c, err := c.WithEnterpriseURLs("https://ghe.example.com/", "https://ghe.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.lfsURL("o", "r"), "https://ghe.example.com/o/r.git/info/lfs/objects/batch"; got != want {
+		t.Errorf("lfsURL = %v, want %v", got, want)
+	}
+}
+
+func TestLFSClient_Batch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/o/r.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Accept", mediaTypeLFS)
+		testHeader(t, r, "Content-Type", mediaTypeLFS)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		fmt.Fprint(w, `{"transfer":"basic","objects":[{"oid":"abc","size":3,"error":{"code":404,"message":"Object does not exist"}}]}`)
+	})
+
+	ctx := context.Background()
+	got, _, err := NewLFSClient(client).Batch(ctx, "o", "r", &LFSBatchRequest{
+		Operation: "download",
+		Ref:       &LFSRef{Name: "refs/heads/main"},
+		Objects:   []*LFSPointer{{OID: "abc", Size: 3}},
+	})
+	if err != nil {
+		t.Fatalf("LFSClient.Batch returned error: %v", err)
+	}
+	if want := `{"operation":"download","ref":{"name":"refs/heads/main"},"objects":[{"oid":"abc","size":3}]}` + "\n"; body != want {
+		t.Errorf("LFSClient.Batch request body is %v, want %v", body, want)
+	}
+	want := &LFSBatchResponse{
+		Transfer: String("basic"),
+		Objects:  []*LFSObject{{OID: "abc", Size: 3, Error: &LFSObjectError{Code: 404, Message: "Object does not exist"}}},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("LFSClient.Batch returned %+v, want %+v", got, want)
+	}
+
+	if _, err := NewLFSClient(client).Download(ctx, "o", "r", &LFSPointer{OID: "abc", Size: 3}); err == nil || !strings.Contains(err.Error(), "Object does not exist") {
+		t.Errorf("LFSClient.Download returned error %v, want the object error", err)
+	}
+
+	const methodName = "Batch"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := NewLFSClient(client).Batch(ctx, "o", "r", &LFSBatchRequest{})
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestGitService_CreateLFSBlob(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	p := lfsTestPointer(t)
+	var uploaded, verified bool
+	mux.HandleFunc("/o/r.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, fmt.Sprintf(`{"operation":"upload","transfers":["basic"],"objects":[{"oid":%q,"size":11}]}`+"\n", p.OID))
+		fmt.Fprintf(w, `{"objects":[{"oid":%q,"size":11,"actions":{
+			"upload":{"href":"%v/api-v3/storage/%v","header":{"Authorization":"RemoteAuth secret"}},
+			"verify":{"href":"%v/api-v3/verify"}}}]}`, p.OID, serverURL, p.OID, serverURL)
+	})
+	mux.HandleFunc("/storage/"+p.OID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testHeader(t, r, "Authorization", "RemoteAuth secret")
+		testHeader(t, r, "Content-Type", defaultMediaType)
+		testBody(t, r, lfsTestContent)
+		uploaded = true
+	})
+	mux.HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, fmt.Sprintf(`{"oid":%q,"size":11}`, p.OID))
+		verified = true
+	})
+	mux.HandleFunc("/repos/o/r/git/blobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		want := fmt.Sprintf(`{"content":%q,"encoding":"utf-8"}`+"\n", p.Bytes())
+		testBody(t, r, want)
+		fmt.Fprint(w, `{"sha":"s"}`)
+	})
+
+	ctx := context.Background()
+	// Only the content after the reader's offset is uploaded.
+	content := strings.NewReader("prefix" + lfsTestContent)
+	if _, err := content.Seek(int64(len("prefix")), io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	blob, got, err := client.Git.CreateLFSBlob(ctx, "o", "r", content)
+	if err != nil {
+		t.Fatalf("Git.CreateLFSBlob returned error: %v", err)
+	}
+	if blob.GetSHA() != "s" || !cmp.Equal(got, p) {
+		t.Errorf("Git.CreateLFSBlob returned %+v, %+v, want blob s and %+v", blob, got, p)
+	}
+	if !uploaded || !verified {
+		t.Errorf("Git.CreateLFSBlob uploaded: %v, verified: %v; want both", uploaded, verified)
+	}
+}
+
+func TestLFSClient_ResolveContent(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	p := lfsTestPointer(t)
+	mux.HandleFunc("/o/r.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"objects":[{"oid":%q,"size":11,"actions":{"download":{"href":"%v/api-v3/storage/obj"}}}]}`, p.OID, serverURL)
+	})
+	body := lfsTestContent
+	mux.HandleFunc("/storage/obj", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, body)
+	})
+
+	ctx := context.Background()
+	pointerFile := &RepositoryContent{
+		Encoding: String("base64"),
+		Content:  String(base64.StdEncoding.EncodeToString(p.Bytes())),
+	}
+	rc, err := NewLFSClient(client).ResolveContent(ctx, "o", "r", pointerFile)
+	if err != nil {
+		t.Fatalf("LFSClient.ResolveContent returned error: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("reading LFS content returned error: %v", err)
+	}
+	if string(got) != lfsTestContent {
+		t.Errorf("LFSClient.ResolveContent returned %q, want %q", got, lfsTestContent)
+	}
+
+	body = "tampered!!\n"
+	rc, err = NewLFSClient(client).ResolveContent(ctx, "o", "r", pointerFile)
+	if err != nil {
+		t.Fatalf("LFSClient.ResolveContent returned error: %v", err)
+	}
+	if _, err := io.ReadAll(rc); err == nil {
+		t.Error("reading tampered LFS content returned nil error, want checksum error")
+	}
+	rc.Close()
+
+	plain := &RepositoryContent{Content: String("not a pointer")}
+	rc, err = NewLFSClient(client).ResolveContent(ctx, "o", "r", plain)
+	if err != nil {
+		t.Fatalf("LFSClient.ResolveContent returned error: %v", err)
+	}
+	if got, _ := io.ReadAll(rc); string(got) != "not a pointer" {
+		t.Errorf("LFSClient.ResolveContent returned %q for a regular file, want its content", got)
+	}
+}
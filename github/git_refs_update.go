@@ -0,0 +1,182 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RefConflictError occurs when a reference does not point at the commit
+// it was expected to, because it was moved concurrently.
+type RefConflictError struct {
+	// Ref is the fully qualified name of the reference, such as
+	// "refs/heads/main".
+	Ref string
+	// Expected is the SHA the reference was expected to point at.
+	Expected string
+	// Actual is the SHA the reference points at, or empty if it no longer
+	// exists.
+	Actual string
+}
+
+func (e *RefConflictError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("%v was expected at %v but no longer exists", e.Ref, e.Expected)
+	}
+	return fmt.Sprintf("%v was expected at %v but points at %v", e.Ref, e.Expected, e.Actual)
+}
+
+// Is returns whether the provided error equals this error.
+func (e *RefConflictError) Is(target error) bool {
+	v, ok := target.(*RefConflictError)
+	if !ok {
+		return false
+	}
+	return e.Ref == v.Ref && e.Expected == v.Expected && e.Actual == v.Actual
+}
+
+// UpdateRefIfMatch moves ref to newSHA only if it currently points at
+// oldSHA, which must be an ancestor of newSHA. If the reference does not
+// point at oldSHA, a *RefConflictError is returned.
+//
+// This is not a true compare-and-swap, which the GitHub API does not
+// offer. The reference is read and then updated as a fast-forward in a
+// separate request. If it is moved in between to another ancestor of
+// newSHA, such as a commit between oldSHA and newSHA, the update still
+// succeeds. UpdateRefWithRetry avoids this by only moving references to
+// children of the commit they were read at.
+//
+// ref may be given with or without the "refs/" prefix, such as
+// "heads/main".
+//
+// GitHub API docs: https://docs.github.com/rest/git/refs#get-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#update-a-reference
+//
+//meta:operation GET /repos/{owner}/{repo}/git/ref/{ref}
+//meta:operation PATCH /repos/{owner}/{repo}/git/refs/{ref}
+func (s *GitService) UpdateRefIfMatch(ctx context.Context, owner, repo, ref, oldSHA, newSHA string) (*Reference, *Response, error) {
+	name := "refs/" + strings.TrimPrefix(ref, "refs/")
+
+	current, resp, err := s.currentRefSHA(ctx, owner, repo, name)
+	if err != nil {
+		return nil, resp, err
+	}
+	if current != oldSHA {
+		return nil, resp, &RefConflictError{Ref: name, Expected: oldSHA, Actual: current}
+	}
+
+	updated, resp, err := s.UpdateRef(ctx, owner, repo, &Reference{Ref: String(name), Object: &GitObject{SHA: String(newSHA)}}, false)
+	if err == nil {
+		return updated, resp, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnprocessableEntity {
+		return nil, resp, err
+	}
+
+	// The update was not a fast-forward. Find out whether that is because
+	// the reference has moved.
+	current, _, rerr := s.currentRefSHA(ctx, owner, repo, name)
+	switch {
+	case rerr != nil:
+		return nil, resp, err
+	case current == newSHA:
+		// Someone else made the same update.
+		return &Reference{Ref: String(name), Object: &GitObject{SHA: String(newSHA)}}, resp, nil
+	case current != oldSHA:
+		return nil, resp, &RefConflictError{Ref: name, Expected: oldSHA, Actual: current}
+	}
+	return nil, resp, err
+}
+
+// UpdateRefWithRetry applies a change to ref with optimistic concurrency.
+// It reads the current head of ref and calls apply with it. apply creates
+// a commit on top of head, for example with GitService.CreateCommit, and
+// returns its SHA, or head itself if there is nothing to change. The
+// reference is then moved with UpdateRefIfMatch. If it was moved
+// concurrently, apply is called again with the new head, up to
+// maxAttempts times in total.
+//
+// Before the reference is moved, the commit returned by apply is checked
+// to have head as a parent, and an error is returned if it does not. Since
+// the reference is only moved as a fast-forward, this ensures the update
+// fails if the reference was moved to any other commit after it was read.
+//
+// If every attempt conflicts, the last *RefConflictError is returned.
+//
+// GitHub API docs: https://docs.github.com/rest/git/commits#get-a-commit-object
+// GitHub API docs: https://docs.github.com/rest/git/refs#get-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#update-a-reference
+//
+//meta:operation GET /repos/{owner}/{repo}/git/commits/{commit_sha}
+//meta:operation GET /repos/{owner}/{repo}/git/ref/{ref}
+//meta:operation PATCH /repos/{owner}/{repo}/git/refs/{ref}
+func (s *GitService) UpdateRefWithRetry(ctx context.Context, owner, repo, ref string, maxAttempts int, apply func(ctx context.Context, head string) (string, error)) (*Reference, error) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	name := "refs/" + strings.TrimPrefix(ref, "refs/")
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		current, _, gerr := s.GetRef(ctx, owner, repo, name)
+		if gerr != nil {
+			return nil, gerr
+		}
+		head := current.GetObject().GetSHA()
+
+		newSHA, aerr := apply(ctx, head)
+		if aerr != nil {
+			return nil, aerr
+		}
+		if newSHA == "" || newSHA == head {
+			return current, nil
+		}
+		commit, _, cerr := s.GetCommit(ctx, owner, repo, newSHA)
+		if cerr != nil {
+			return nil, cerr
+		}
+		if !hasParent(commit, head) {
+			return nil, fmt.Errorf("commit %v is not a child of %v, the head of %v", newSHA, head, name)
+		}
+
+		var updated *Reference
+		updated, _, err = s.UpdateRefIfMatch(ctx, owner, repo, name, head, newSHA)
+		var conflict *RefConflictError
+		if !errors.As(err, &conflict) {
+			return updated, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
+
+// hasParent reports whether sha is a parent of commit.
+func hasParent(commit *Commit, sha string) bool {
+	for _, p := range commit.Parents {
+		if p.GetSHA() == sha {
+			return true
+		}
+	}
+	return false
+}
+
+// currentRefSHA returns the SHA ref points at, or "" if it does not exist.
+func (s *GitService) currentRefSHA(ctx context.Context, owner, repo, ref string) (string, *Response, error) {
+	r, resp, err := s.GetRef(ctx, owner, repo, ref)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", resp, nil
+		}
+		return "", resp, err
+	}
+	return r.GetObject().GetSHA(), resp, nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testRefServer serves refs/heads/main, starting at head. Updates are
+// accepted only as fast-forwards from the current head, where each commit
+// named "<x>+" is a child of "<x>", and a commit named "<x>" has no
+// parents. moves are applied to the ref, one per read, to simulate
+// concurrent writers.
+func testRefServer(t *testing.T, mux *http.ServeMux, head *string, moves *[]string) {
+	t.Helper()
+	mux.HandleFunc("/repos/o/r/git/ref/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if *head == "" {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"ref":"refs/heads/main","object":{"type":"commit","sha":%q}}`, *head)
+		if len(*moves) > 0 {
+			*head, *moves = (*moves)[0], (*moves)[1:]
+		}
+	})
+	mux.HandleFunc("/repos/o/r/git/commits/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		sha := strings.TrimPrefix(r.URL.Path, "/repos/o/r/git/commits/")
+		parents := "[]"
+		if strings.HasSuffix(sha, "+") {
+			parents = fmt.Sprintf(`[{"sha":%q}]`, strings.TrimSuffix(sha, "+"))
+		}
+		fmt.Fprintf(w, `{"sha":%q,"parents":%v}`, sha, parents)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		var body struct {
+			SHA   string `json:"sha"`
+			Force bool   `json:"force"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Force {
+			t.Error("ref was updated with force")
+		}
+		if body.SHA != *head+"+" {
+			http.Error(w, `{"message":"Update is not a fast forward"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		*head = body.SHA
+		fmt.Fprintf(w, `{"ref":"refs/heads/main","object":{"type":"commit","sha":%q}}`, *head)
+	})
+}
+
+func TestGitService_UpdateRefIfMatch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	head, moves := "a", []string(nil)
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	ref, _, err := client.Git.UpdateRefIfMatch(ctx, "o", "r", "heads/main", "a", "a+")
+	if err != nil {
+		t.Fatalf("Git.UpdateRefIfMatch returned error: %v", err)
+	}
+	want := &Reference{Ref: String("refs/heads/main"), Object: &GitObject{Type: String("commit"), SHA: String("a+")}}
+	if !cmp.Equal(ref, want) {
+		t.Errorf("Git.UpdateRefIfMatch returned %+v, want %+v", ref, want)
+	}
+
+	_, _, err = client.Git.UpdateRefIfMatch(ctx, "o", "r", "refs/heads/main", "a", "a+")
+	wantErr := &RefConflictError{Ref: "refs/heads/main", Expected: "a", Actual: "a+"}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Git.UpdateRefIfMatch returned error %v, want %v", err, wantErr)
+	}
+
+	const methodName = "UpdateRefIfMatch"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Git.UpdateRefIfMatch(ctx, "\n", "\n", "heads/main", "a", "b")
+		return err
+	})
+}
+
+func TestGitService_UpdateRefIfMatch_movedDuringUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// The ref moves from a to b right after it is read.
+	head, moves := "a", []string{"b"}
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	_, _, err := client.Git.UpdateRefIfMatch(ctx, "o", "r", "heads/main", "a", "a+")
+	wantErr := &RefConflictError{Ref: "refs/heads/main", Expected: "a", Actual: "b"}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Git.UpdateRefIfMatch returned error %v, want %v", err, wantErr)
+	}
+	if head != "b" {
+		t.Errorf("ref was moved to %v, want b", head)
+	}
+}
+
+func TestGitService_UpdateRefIfMatch_notFastForward(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	head, moves := "a", []string(nil)
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	_, _, err := client.Git.UpdateRefIfMatch(ctx, "o", "r", "heads/main", "a", "unrelated")
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("Git.UpdateRefIfMatch returned error %v, want *ErrorResponse", err)
+	}
+}
+
+func TestGitService_UpdateRefIfMatch_deleted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	head, moves := "", []string(nil)
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	_, _, err := client.Git.UpdateRefIfMatch(ctx, "o", "r", "heads/main", "a", "a+")
+	wantErr := &RefConflictError{Ref: "refs/heads/main", Expected: "a"}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Git.UpdateRefIfMatch returned error %v, want %v", err, wantErr)
+	}
+	if got, want := err.Error(), "refs/heads/main was expected at a but no longer exists"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestGitService_UpdateRefWithRetry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// Another writer moves the ref to b after the first read.
+	head, moves := "a", []string{"b"}
+	testRefServer(t, mux, &head, &moves)
+
+	var bases []string
+	apply := func(ctx context.Context, head string) (string, error) {
+		bases = append(bases, head)
+		return head + "+", nil
+	}
+
+	ctx := context.Background()
+	ref, err := client.Git.UpdateRefWithRetry(ctx, "o", "r", "heads/main", 3, apply)
+	if err != nil {
+		t.Fatalf("Git.UpdateRefWithRetry returned error: %v", err)
+	}
+	if ref.GetObject().GetSHA() != "b+" {
+		t.Errorf("Git.UpdateRefWithRetry moved the ref to %v, want b+", ref.GetObject().GetSHA())
+	}
+	if want := []string{"a", "b"}; !cmp.Equal(bases, want) {
+		t.Errorf("apply was called with %v, want %v", bases, want)
+	}
+}
+
+func TestGitService_UpdateRefWithRetry_exhausted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	head, moves := "a", []string{"b", "b", "c", "c"}
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	_, err := client.Git.UpdateRefWithRetry(ctx, "o", "r", "heads/main", 2, func(ctx context.Context, head string) (string, error) {
+		return head + "+", nil
+	})
+	var conflict *RefConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Git.UpdateRefWithRetry returned error %v, want *RefConflictError", err)
+	}
+}
+
+func TestGitService_UpdateRefWithRetry_noChange(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	head, moves := "a", []string(nil)
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	ref, err := client.Git.UpdateRefWithRetry(ctx, "o", "r", "heads/main", 3, func(ctx context.Context, head string) (string, error) {
+		return head, nil
+	})
+	if err != nil {
+		t.Fatalf("Git.UpdateRefWithRetry returned error: %v", err)
+	}
+	if ref.GetObject().GetSHA() != "a" {
+		t.Errorf("Git.UpdateRefWithRetry returned %v, want a", ref.GetObject().GetSHA())
+	}
+
+	wantErr := errors.New("apply failed")
+	_, err = client.Git.UpdateRefWithRetry(ctx, "o", "r", "heads/main", 3, func(ctx context.Context, head string) (string, error) {
+		return "", wantErr
+	})
+	if err != wantErr {
+		t.Errorf("Git.UpdateRefWithRetry returned error %v, want %v", err, wantErr)
+	}
+}
+
+func TestGitService_UpdateRefWithRetry_notChild(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// b+ is a fast-forward from b, but apply was called with a.
+	head, moves := "a", []string{"b"}
+	testRefServer(t, mux, &head, &moves)
+
+	ctx := context.Background()
+	_, err := client.Git.UpdateRefWithRetry(ctx, "o", "r", "heads/main", 3, func(ctx context.Context, head string) (string, error) {
+		return "b+", nil
+	})
+	if err == nil {
+		t.Error("Git.UpdateRefWithRetry returned nil error for a commit that is not a child of the head")
+	}
+	if head != "b" {
+		t.Errorf("Git.UpdateRefWithRetry moved the ref to %v, want it left at b", head)
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// The SSH signature format is described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+const (
+	sshSigMagic     = "SSHSIG"
+	sshSigVersion   = 1
+	sshSigNamespace = "git"
+	sshSigHash      = "sha512"
+	sshSigBegin     = "-----BEGIN SSH SIGNATURE-----"
+	sshSigEnd       = "-----END SSH SIGNATURE-----"
+)
+
+// SSHSigner is a MessageSigner that creates SSH signatures, which GitHub
+// verifies against the SSH signing keys of the commit author.
+type SSHSigner struct {
+	signer    crypto.Signer
+	publicKey []byte
+}
+
+// NewSSHSigner returns an SSHSigner that signs with signer, which must hold
+// an ed25519, ECDSA (P-256, P-384 or P-521) or RSA key.
+//
+// To sign commits with it, register its public key with
+// UsersService.CreateSSHSigningKey and pass it to GitService.CreateCommit:
+//
+//	signer, err := github.NewSSHSigner(privateKey)
+//	commit, _, err = client.Git.CreateCommit(ctx, owner, repo, commit, &github.CreateCommitOptions{Signer: signer})
+func NewSSHSigner(signer crypto.Signer) (*SSHSigner, error) {
+	pub, err := marshalSSHPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &SSHSigner{signer: signer, publicKey: pub}, nil
+}
+
+// PublicKey returns the public key of the signer in the authorized_keys
+// format used by SSHSigningKey.Key, such as "ssh-ed25519 AAAA...".
+func (s *SSHSigner) PublicKey() string {
+	return formatSSHPublicKey(s.publicKey)
+}
+
+// Sign writes an armored SSH signature of the message read from r to w.
+func (s *SSHSigner) Sign(w io.Writer, r io.Reader) error {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	signed := sshSignedData(sshSigNamespace, sshSigHash, h.Sum(nil))
+
+	sig, err := s.signSSH(signed)
+	if err != nil {
+		return err
+	}
+
+	var blob bytes.Buffer
+	blob.WriteString(sshSigMagic)
+	binary.Write(&blob, binary.BigEndian, uint32(sshSigVersion))
+	writeSSHString(&blob, s.publicKey)
+	writeSSHString(&blob, []byte(sshSigNamespace))
+	writeSSHString(&blob, nil)
+	writeSSHString(&blob, []byte(sshSigHash))
+	writeSSHString(&blob, sig)
+
+	_, err = io.WriteString(w, armorSSHSignature(blob.Bytes()))
+	return err
+}
+
+// signSSH signs data and returns the signature in SSH wire format.
+func (s *SSHSigner) signSSH(data []byte) ([]byte, error) {
+	var (
+		format string
+		sig    []byte
+		err    error
+	)
+	switch pub := s.signer.Public().(type) {
+	case ed25519.PublicKey:
+		format = "ssh-ed25519"
+		sig, err = s.signer.Sign(rand.Reader, data, crypto.Hash(0))
+	case *rsa.PublicKey:
+		// SSH signatures must not use SHA-1, so "ssh-rsa" is not an option.
+		format = "rsa-sha2-512"
+		digest := sha512.Sum512(data)
+		sig, err = s.signer.Sign(rand.Reader, digest[:], crypto.SHA512)
+	case *ecdsa.PublicKey:
+		var hash crypto.Hash
+		format, _, hash, err = sshECDSAParams(pub.Curve)
+		if err != nil {
+			return nil, err
+		}
+		h := hash.New()
+		h.Write(data)
+		var der []byte
+		if der, err = s.signer.Sign(rand.Reader, h.Sum(nil), hash); err != nil {
+			return nil, err
+		}
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(der, &rs); err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		writeSSHMPInt(&b, rs.R)
+		writeSSHMPInt(&b, rs.S)
+		sig = b.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported SSH key type %T", pub)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	writeSSHString(&b, []byte(format))
+	writeSSHString(&b, sig)
+	return b.Bytes(), nil
+}
+
+// VerifySSHSignature verifies that signature, an armored SSH signature for
+// git, was made over message by one of keys, and returns that key.
+func VerifySSHSignature(message io.Reader, signature string, keys []*SSHSigningKey) (*SSHSigningKey, error) {
+	blob, err := unarmorSSHSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(blob, []byte(sshSigMagic)) {
+		return nil, errors.New("invalid SSH signature: bad magic")
+	}
+	r := &sshReader{b: blob[len(sshSigMagic):]}
+	version := r.uint32()
+	pub := r.string()
+	namespace := r.string()
+	r.string() // reserved
+	hashAlgo := r.string()
+	sig := r.string()
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid SSH signature: %w", r.err)
+	}
+	if version != sshSigVersion {
+		return nil, fmt.Errorf("unsupported SSH signature version %v", version)
+	}
+	if string(namespace) != sshSigNamespace {
+		return nil, fmt.Errorf("SSH signature has namespace %q, want %q", namespace, sshSigNamespace)
+	}
+
+	var digest []byte
+	switch string(hashAlgo) {
+	case "sha256":
+		h := sha256.New()
+		if _, err := io.Copy(h, message); err != nil {
+			return nil, err
+		}
+		digest = h.Sum(nil)
+	case "sha512":
+		h := sha512.New()
+		if _, err := io.Copy(h, message); err != nil {
+			return nil, err
+		}
+		digest = h.Sum(nil)
+	default:
+		return nil, fmt.Errorf("unsupported SSH signature hash %q", hashAlgo)
+	}
+
+	var key *SSHSigningKey
+	for _, k := range keys {
+		if b, err := parseSSHAuthorizedKey(k.GetKey()); err == nil && bytes.Equal(b, pub) {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("SSH signature was made by unknown key %v", formatSSHPublicKey(pub))
+	}
+
+	if err := verifySSH(pub, sshSignedData(string(namespace), string(hashAlgo), digest), sig); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// VerifySSHCommitSignature verifies the SSH signature in
+// commit.Verification against the SSH signing keys registered by user,
+// without relying on GitHub's verdict, and returns the key that made it.
+// The commit must have been fetched with its verification details, for
+// example with GitService.GetCommit.
+//
+// GitHub API docs: https://docs.github.com/rest/users/ssh-signing-keys#list-ssh-signing-keys-for-a-user
+//
+//meta:operation GET /users/{username}/ssh_signing_keys
+func (s *GitService) VerifySSHCommitSignature(ctx context.Context, user string, commit *Commit) (*SSHSigningKey, error) {
+	v := commit.GetVerification()
+	if v.GetSignature() == "" || v.Payload == nil {
+		return nil, errors.New("commit has no signature")
+	}
+	if !strings.HasPrefix(v.GetSignature(), sshSigBegin) {
+		return nil, errors.New("commit signature is not an SSH signature")
+	}
+
+	keys, err := s.listAllSSHSigningKeys(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return VerifySSHSignature(strings.NewReader(v.GetPayload()), v.GetSignature(), keys)
+}
+
+// listAllSSHSigningKeys returns all SSH signing keys registered by user.
+func (s *GitService) listAllSSHSigningKeys(ctx context.Context, user string) ([]*SSHSigningKey, error) {
+	var keys []*SSHSigningKey
+	opts := &ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.client.Users.ListSSHSigningKeys(ctx, user, opts)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+		if resp.NextPage == 0 {
+			return keys, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// verifySSH verifies the SSH wire format signature sig of data by the SSH
+// wire format public key pub.
+func verifySSH(pub, data, sig []byte) error {
+	sr := &sshReader{b: sig}
+	format := string(sr.string())
+	blob := sr.string()
+	if sr.err != nil {
+		return fmt.Errorf("invalid SSH signature: %w", sr.err)
+	}
+
+	pr := &sshReader{b: pub}
+	keyType := string(pr.string())
+	bad := errors.New("SSH signature verification failed")
+
+	switch keyType {
+	case "ssh-ed25519":
+		key := pr.string()
+		if pr.err != nil || len(key) != ed25519.PublicKeySize {
+			return errors.New("invalid ed25519 public key")
+		}
+		if format != keyType || !ed25519.Verify(ed25519.PublicKey(key), data, blob) {
+			return bad
+		}
+	case "ssh-rsa":
+		e := pr.mpint()
+		n := pr.mpint()
+		if pr.err != nil || !e.IsInt64() {
+			return errors.New("invalid RSA public key")
+		}
+		key := &rsa.PublicKey{N: n, E: int(e.Int64())}
+		var hash crypto.Hash
+		switch format {
+		case "rsa-sha2-256":
+			hash = crypto.SHA256
+		case "rsa-sha2-512":
+			hash = crypto.SHA512
+		default:
+			return fmt.Errorf("unsupported RSA signature format %q", format)
+		}
+		h := hash.New()
+		h.Write(data)
+		if rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), blob) != nil {
+			return bad
+		}
+	case "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521":
+		curve, hash := sshECDSACurve(keyType)
+		pr.string() // curve name
+		point := pr.string()
+		if pr.err != nil {
+			return errors.New("invalid ECDSA public key")
+		}
+		x, y := elliptic.Unmarshal(curve, point)
+		if x == nil {
+			return errors.New("invalid ECDSA public key")
+		}
+		br := &sshReader{b: blob}
+		r, s := br.mpint(), br.mpint()
+		if br.err != nil {
+			return fmt.Errorf("invalid SSH signature: %w", br.err)
+		}
+		h := hash.New()
+		h.Write(data)
+		if format != keyType || !ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, h.Sum(nil), r, s) {
+			return bad
+		}
+	default:
+		return fmt.Errorf("unsupported SSH key type %q", keyType)
+	}
+	return nil
+}
+
+// sshSignedData returns the data that is signed in an SSH signature of a
+// message with the given digest.
+func sshSignedData(namespace, hashAlgo string, digest []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(sshSigMagic)
+	writeSSHString(&b, []byte(namespace))
+	writeSSHString(&b, nil)
+	writeSSHString(&b, []byte(hashAlgo))
+	writeSSHString(&b, digest)
+	return b.Bytes()
+}
+
+// marshalSSHPublicKey returns pub in SSH wire format.
+func marshalSSHPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	var b bytes.Buffer
+	switch pub := pub.(type) {
+	case ed25519.PublicKey:
+		writeSSHString(&b, []byte("ssh-ed25519"))
+		writeSSHString(&b, pub)
+	case *rsa.PublicKey:
+		writeSSHString(&b, []byte("ssh-rsa"))
+		writeSSHMPInt(&b, big.NewInt(int64(pub.E)))
+		writeSSHMPInt(&b, pub.N)
+	case *ecdsa.PublicKey:
+		keyType, curveName, _, err := sshECDSAParams(pub.Curve)
+		if err != nil {
+			return nil, err
+		}
+		writeSSHString(&b, []byte(keyType))
+		writeSSHString(&b, []byte(curveName))
+		writeSSHString(&b, elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	default:
+		return nil, fmt.Errorf("unsupported SSH key type %T", pub)
+	}
+	return b.Bytes(), nil
+}
+
+// sshECDSAParams returns the SSH key type, curve name and hash of an ECDSA
+// curve.
+func sshECDSAParams(curve elliptic.Curve) (keyType, curveName string, hash crypto.Hash, err error) {
+	switch curve {
+	case elliptic.P256():
+		return "ecdsa-sha2-nistp256", "nistp256", crypto.SHA256, nil
+	case elliptic.P384():
+		return "ecdsa-sha2-nistp384", "nistp384", crypto.SHA384, nil
+	case elliptic.P521():
+		return "ecdsa-sha2-nistp521", "nistp521", crypto.SHA512, nil
+	}
+	return "", "", 0, fmt.Errorf("unsupported ECDSA curve %v", curve.Params().Name)
+}
+
+// sshECDSACurve returns the curve and hash of an ECDSA SSH key type.
+func sshECDSACurve(keyType string) (elliptic.Curve, crypto.Hash) {
+	switch keyType {
+	case "ecdsa-sha2-nistp384":
+		return elliptic.P384(), crypto.SHA384
+	case "ecdsa-sha2-nistp521":
+		return elliptic.P521(), crypto.SHA512
+	}
+	return elliptic.P256(), crypto.SHA256
+}
+
+// formatSSHPublicKey returns the SSH wire format public key pub in
+// authorized_keys format.
+func formatSSHPublicKey(pub []byte) string {
+	keyType := (&sshReader{b: pub}).string()
+	return string(keyType) + " " + base64.StdEncoding.EncodeToString(pub)
+}
+
+// parseSSHAuthorizedKey returns the SSH wire format public key of an
+// authorized_keys line, ignoring options and comments.
+func parseSSHAuthorizedKey(line string) ([]byte, error) {
+	fields := strings.Fields(line)
+	for i := 0; i+1 < len(fields); i++ {
+		pub, err := base64.StdEncoding.DecodeString(fields[i+1])
+		if err != nil {
+			continue
+		}
+		if keyType := (&sshReader{b: pub}).string(); string(keyType) == fields[i] {
+			return pub, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid SSH public key %q", line)
+}
+
+// armorSSHSignature returns the armored form of an SSH signature blob.
+func armorSSHSignature(blob []byte) string {
+	enc := base64.StdEncoding.EncodeToString(blob)
+	var b strings.Builder
+	b.WriteString(sshSigBegin + "\n")
+	for len(enc) > 70 {
+		b.WriteString(enc[:70] + "\n")
+		enc = enc[70:]
+	}
+	b.WriteString(enc + "\n")
+	b.WriteString(sshSigEnd + "\n")
+	return b.String()
+}
+
+// unarmorSSHSignature returns the blob of an armored SSH signature.
+func unarmorSSHSignature(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, sshSigBegin) || !strings.HasSuffix(s, sshSigEnd) {
+		return nil, errors.New("invalid SSH signature: missing armor")
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, sshSigBegin), sshSigEnd)
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SSH signature: %w", err)
+	}
+	return blob, nil
+}
+
+func writeSSHString(b *bytes.Buffer, s []byte) {
+	binary.Write(b, binary.BigEndian, uint32(len(s)))
+	b.Write(s)
+}
+
+func writeSSHMPInt(b *bytes.Buffer, n *big.Int) {
+	bs := n.Bytes()
+	if len(bs) > 0 && bs[0]&0x80 != 0 {
+		bs = append([]byte{0}, bs...)
+	}
+	writeSSHString(b, bs)
+}
+
+// sshReader reads values in SSH wire format. After the first error, reads
+// return zero values and err is set.
+type sshReader struct {
+	b   []byte
+	err error
+}
+
+func (r *sshReader) uint32() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.b) < 4 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.b)
+	r.b = r.b[4:]
+	return v
+}
+
+func (r *sshReader) string() []byte {
+	n := r.uint32()
+	if r.err != nil {
+		return nil
+	}
+	if uint32(len(r.b)) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	s := r.b[:n]
+	r.b = r.b[n:]
+	return s
+}
+
+func (r *sshReader) mpint() *big.Int {
+	b := r.string()
+	if r.err == nil && len(b) > 0 && b[0]&0x80 != 0 {
+		r.err = errors.New("negative mpint")
+	}
+	return new(big.Int).SetBytes(b)
+}
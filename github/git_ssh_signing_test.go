@@ -0,0 +1,214 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testSSHKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]crypto.Signer{
+		"ssh-ed25519":         edKey,
+		"ecdsa-sha2-nistp256": ecKey,
+		"ecdsa-sha2-nistp384": ec384Key,
+		"ssh-rsa":             rsaKey,
+	}
+}
+
+func TestSSHSigner_SignAndVerify(t *testing.T) {
+	keys := testSSHKeys(t)
+	for keyType, key := range keys {
+		t.Run(keyType, func(t *testing.T) {
+			signer, err := NewSSHSigner(key)
+			if err != nil {
+				t.Fatalf("NewSSHSigner returned error: %v", err)
+			}
+			if !strings.HasPrefix(signer.PublicKey(), keyType+" ") {
+				t.Errorf("PublicKey = %v, want prefix %q", signer.PublicKey(), keyType)
+			}
+
+			var sig bytes.Buffer
+			if err := signer.Sign(&sig, strings.NewReader("tree abc\n\nmessage")); err != nil {
+				t.Fatalf("Sign returned error: %v", err)
+			}
+			if !strings.HasPrefix(sig.String(), "-----BEGIN SSH SIGNATURE-----\n") {
+				t.Errorf("Sign wrote %q, want an armored SSH signature", sig.String())
+			}
+
+			want := &SSHSigningKey{ID: Int64(1), Key: String(signer.PublicKey() + " me@example.com")}
+			other, err := NewSSHSigner(keys["ssh-ed25519"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered := []*SSHSigningKey{want}
+			if keyType != "ssh-ed25519" {
+				registered = append([]*SSHSigningKey{{ID: Int64(2), Key: String(other.PublicKey())}}, registered...)
+			}
+
+			got, err := VerifySSHSignature(strings.NewReader("tree abc\n\nmessage"), sig.String(), registered)
+			if err != nil {
+				t.Fatalf("VerifySSHSignature returned error: %v", err)
+			}
+			if got != want {
+				t.Errorf("VerifySSHSignature returned %v, want %v", got, want)
+			}
+
+			if _, err := VerifySSHSignature(strings.NewReader("tree abc\n\nmessage!"), sig.String(), registered); err == nil {
+				t.Error("VerifySSHSignature of a modified message returned nil error")
+			}
+			if _, err := VerifySSHSignature(strings.NewReader("tree abc\n\nmessage"), sig.String(), registered[:len(registered)-1]); err == nil {
+				t.Error("VerifySSHSignature with an unregistered key returned nil error")
+			}
+		})
+	}
+}
+
+func TestNewSSHSigner_unsupportedKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSSHSigner(key); err == nil {
+		t.Error("NewSSHSigner with a P-224 key returned nil error")
+	}
+}
+
+func TestVerifySSHSignature_invalid(t *testing.T) {
+	for name, sig := range map[string]string{
+		"no armor":  "AAAA",
+		"bad magic": "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----",
+		"truncated": "-----BEGIN SSH SIGNATURE-----\nU1NIU0lHAAAAAQ==\n-----END SSH SIGNATURE-----",
+		"not ssh":   "-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----",
+	} {
+		if _, err := VerifySSHSignature(strings.NewReader("m"), sig, nil); err == nil {
+			t.Errorf("VerifySSHSignature(%v) returned nil error", name)
+		}
+	}
+}
+
+func TestGitService_CreateCommit_sshSigner(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSSHSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := &Timestamp{referenceTime}
+	author := &CommitAuthor{Name: String("go-github"), Email: String("go-github@github.com"), Date: date}
+	input := &Commit{Message: String("Commit Message."), Tree: &Tree{SHA: String("t")}, Parents: []*Commit{{SHA: String("p")}}, Author: author}
+
+	var signature string
+	mux.HandleFunc("/repos/o/r/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		v := new(createCommit)
+		assertNilError(t, json.NewDecoder(r.Body).Decode(v))
+		if v.Signature != nil {
+			signature = *v.Signature
+		}
+		fmt.Fprint(w, `{"sha":"s"}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Git.CreateCommit(ctx, "o", "r", input, &CreateCommitOptions{Signer: signer}); err != nil {
+		t.Fatalf("Git.CreateCommit returned error: %v", err)
+	}
+
+	payload, err := createSignatureMessage(&createCommit{Author: author, Message: input.Message, Tree: String("t"), Parents: []string{"p"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []*SSHSigningKey{{Key: String(signer.PublicKey())}}
+	if _, err := VerifySSHSignature(strings.NewReader(payload), signature, keys); err != nil {
+		t.Errorf("VerifySSHSignature of the commit signature returned error: %v", err)
+	}
+}
+
+func TestGitService_VerifySSHCommitSignature(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSSHSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := "tree t\nauthor a <a@example.com> 0 +0000\ncommitter a <a@example.com> 0 +0000\n\nm"
+	var sig bytes.Buffer
+	if err := signer.Sign(&sig, strings.NewReader(payload)); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/users/u/ssh_signing_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("page") == "" {
+			w.Header().Set("Link", `<https://api.github.com/users/u/ssh_signing_keys?page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"id":1,"key":"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"}]`)
+			return
+		}
+		fmt.Fprintf(w, `[{"id":2,"key":%q}]`, signer.PublicKey())
+	})
+
+	ctx := context.Background()
+	commit := &Commit{Verification: &SignatureVerification{Payload: String(payload), Signature: String(sig.String())}}
+	got, err := client.Git.VerifySSHCommitSignature(ctx, "u", commit)
+	if err != nil {
+		t.Fatalf("Git.VerifySSHCommitSignature returned error: %v", err)
+	}
+	if got.GetID() != 2 {
+		t.Errorf("Git.VerifySSHCommitSignature returned key %v, want 2", got.GetID())
+	}
+
+	if _, err := client.Git.VerifySSHCommitSignature(ctx, "u", &Commit{}); err == nil {
+		t.Error("Git.VerifySSHCommitSignature of an unsigned commit returned nil error")
+	}
+	pgp := &Commit{Verification: &SignatureVerification{Payload: String(payload), Signature: String("-----BEGIN PGP SIGNATURE-----")}}
+	if _, err := client.Git.VerifySSHCommitSignature(ctx, "u", pgp); err == nil {
+		t.Error("Git.VerifySSHCommitSignature of a PGP signature returned nil error")
+	}
+
+	const methodName = "VerifySSHCommitSignature"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Git.VerifySSHCommitSignature(ctx, "\n", commit)
+		return err
+	})
+}
@@ -0,0 +1,371 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Git file modes of tree entries.
+const (
+	gitModeFile       = "100644"
+	gitModeExecutable = "100755"
+	gitModeSymlink    = "120000"
+)
+
+// SyncDirectoryOptions specifies parameters to GitService.SyncDirectory.
+type SyncDirectoryOptions struct {
+	// Branch is the branch to sync onto. It is required.
+	Branch string
+
+	// Path is the directory within the branch that mirrors the local
+	// directory, such as "docs/api". The repository root is used if empty.
+	Path string
+
+	// Message is the commit message. It defaults to
+	// "Sync <Path> from local directory".
+	Message string
+
+	// Author and Committer of the commit. See GitService.CreateCommit.
+	Author    *CommitAuthor
+	Committer *CommitAuthor
+
+	// CommitOptions are passed to GitService.CreateCommit, for example to
+	// sign the commit.
+	CommitOptions *CreateCommitOptions
+
+	// Exclude, if set, is called with the slash-separated path of each
+	// local file and directory relative to the synced directory. Excluded
+	// paths are neither uploaded nor deleted from the branch. ".git"
+	// directories are always excluded.
+	Exclude func(name string) bool
+
+	// PullRequestBranch is the branch created for the commit when Branch
+	// is protected. It defaults to "sync/<Branch>-<short commit SHA>".
+	PullRequestBranch string
+
+	// PullRequestTitle and PullRequestBody describe the pull request opened
+	// when Branch is protected. The title defaults to the commit message.
+	PullRequestTitle string
+	PullRequestBody  string
+}
+
+// SyncDirectoryResult describes the outcome of GitService.SyncDirectory.
+type SyncDirectoryResult struct {
+	// Commit is the commit that was created, or nil if the branch was
+	// already in sync.
+	Commit *Commit
+
+	// PullRequest is the pull request opened for Commit when the branch
+	// is protected.
+	PullRequest *PullRequest
+
+	// Added, Modified and Deleted list the changed files by their path in
+	// the repository.
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+// syncFile is a file in the local directory to sync.
+type syncFile struct {
+	path string
+	mode string
+	sha  string
+}
+
+// SyncDirectory mirrors the local directory dir onto opts.Path in
+// opts.Branch with a single commit. Git blob SHAs are computed locally and
+// compared with the branch's tree, so that only new or changed files are
+// uploaded. Files missing from dir are deleted. Symbolic links and
+// executable modes are preserved.
+//
+// If the branch is already in sync, no commit is created. If the branch is
+// protected, the commit is pushed to a new branch and a pull request is
+// opened against opts.Branch instead.
+//
+// GitHub API docs: https://docs.github.com/rest/branches/branches#get-a-branch
+// GitHub API docs: https://docs.github.com/rest/git/blobs#create-a-blob
+// GitHub API docs: https://docs.github.com/rest/git/commits#create-a-commit
+// GitHub API docs: https://docs.github.com/rest/git/refs#create-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#update-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/trees#create-a-tree
+// GitHub API docs: https://docs.github.com/rest/git/trees#get-a-tree
+// GitHub API docs: https://docs.github.com/rest/pulls/pulls#create-a-pull-request
+//
+//meta:operation GET /repos/{owner}/{repo}/branches/{branch}
+//meta:operation POST /repos/{owner}/{repo}/git/blobs
+//meta:operation POST /repos/{owner}/{repo}/git/commits
+//meta:operation POST /repos/{owner}/{repo}/git/refs
+//meta:operation PATCH /repos/{owner}/{repo}/git/refs/{ref}
+//meta:operation POST /repos/{owner}/{repo}/git/trees
+//meta:operation GET /repos/{owner}/{repo}/git/trees/{tree_sha}
+//meta:operation POST /repos/{owner}/{repo}/pulls
+func (s *GitService) SyncDirectory(ctx context.Context, owner, repo, dir string, opts *SyncDirectoryOptions) (*SyncDirectoryResult, error) {
+	if opts == nil || opts.Branch == "" {
+		return nil, errors.New("a branch is required to sync a directory")
+	}
+	prefix := strings.Trim(opts.Path, "/")
+	if prefix != "" && !fs.ValidPath(prefix) {
+		return nil, fmt.Errorf("invalid path %q", opts.Path)
+	}
+
+	local, err := scanSyncDirectory(dir, prefix, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	branch, _, err := s.client.Repositories.GetBranch(ctx, owner, repo, opts.Branch, 1)
+	if err != nil {
+		return nil, err
+	}
+	head := branch.GetCommit().GetSHA()
+	baseTree := branch.GetCommit().GetCommit().GetTree().GetSHA()
+	if head == "" || baseTree == "" {
+		return nil, fmt.Errorf("branch %v has no commit", opts.Branch)
+	}
+
+	tree, _, err := s.GetTree(ctx, owner, repo, baseTree, true)
+	if err != nil {
+		return nil, err
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("the tree of branch %v is too large to be listed", opts.Branch)
+	}
+
+	result := &SyncDirectoryResult{}
+	var entries []*TreeEntry
+	remote := make(map[string]*TreeEntry)
+	for _, e := range tree.Entries {
+		p := e.GetPath()
+		if e.GetType() != "blob" || !underSyncPath(p, prefix) {
+			continue
+		}
+		remote[p] = e
+		if _, ok := local[p]; ok {
+			continue
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, prefix), "/")
+		if opts.Exclude != nil && excludedSyncPath(rel, opts.Exclude) {
+			continue
+		}
+		result.Deleted = append(result.Deleted, p)
+		entries = append(entries, &TreeEntry{Path: String(p), Mode: e.Mode, Type: String("blob")})
+	}
+
+	paths := make([]string, 0, len(local))
+	for p := range local {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		f := local[p]
+		old, ok := remote[p]
+		switch {
+		case !ok:
+			result.Added = append(result.Added, p)
+		case old.GetSHA() != f.sha || old.GetMode() != f.mode:
+			result.Modified = append(result.Modified, p)
+		default:
+			continue
+		}
+
+		sha := f.sha
+		if !ok || old.GetSHA() != f.sha {
+			if sha, err = s.uploadSyncFile(ctx, owner, repo, dir, prefix, f); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, &TreeEntry{Path: String(p), Mode: String(f.mode), Type: String("blob"), SHA: String(sha)})
+	}
+	sort.Strings(result.Deleted)
+
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	newTree, _, err := s.CreateTree(ctx, owner, repo, baseTree, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	message := opts.Message
+	if message == "" {
+		target := prefix
+		if target == "" {
+			target = "repository root"
+		}
+		message = fmt.Sprintf("Sync %v from local directory", target)
+	}
+	commit, _, err := s.CreateCommit(ctx, owner, repo, &Commit{
+		Message:   String(message),
+		Tree:      &Tree{SHA: newTree.SHA},
+		Parents:   []*Commit{{SHA: String(head)}},
+		Author:    opts.Author,
+		Committer: opts.Committer,
+	}, opts.CommitOptions)
+	if err != nil {
+		return nil, err
+	}
+	result.Commit = commit
+
+	if !branch.GetProtected() {
+		ref := &Reference{Ref: String("refs/heads/" + opts.Branch), Object: &GitObject{SHA: commit.SHA}}
+		if _, _, err := s.UpdateRef(ctx, owner, repo, ref, false); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	prBranch := opts.PullRequestBranch
+	if prBranch == "" {
+		sha := commit.GetSHA()
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		prBranch = fmt.Sprintf("sync/%v-%v", opts.Branch, sha)
+	}
+	ref := &Reference{Ref: String("refs/heads/" + prBranch), Object: &GitObject{SHA: commit.SHA}}
+	if _, _, err := s.CreateRef(ctx, owner, repo, ref); err != nil {
+		return nil, err
+	}
+
+	title := opts.PullRequestTitle
+	if title == "" {
+		title = strings.SplitN(message, "\n", 2)[0]
+	}
+	pull := &NewPullRequest{
+		Title: String(title),
+		Head:  String(prBranch),
+		Base:  String(opts.Branch),
+	}
+	if opts.PullRequestBody != "" {
+		pull.Body = String(opts.PullRequestBody)
+	}
+	if result.PullRequest, _, err = s.client.PullRequests.Create(ctx, owner, repo, pull); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// uploadSyncFile creates the blob for f and returns its SHA.
+func (s *GitService) uploadSyncFile(ctx context.Context, owner, repo, dir, prefix string, f *syncFile) (string, error) {
+	content, err := readSyncFile(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(f.path, prefix), "/"))), f.mode)
+	if err != nil {
+		return "", err
+	}
+	blob, _, err := s.CreateBlob(ctx, owner, repo, &Blob{
+		Content:  String(base64.StdEncoding.EncodeToString(content)),
+		Encoding: String("base64"),
+	})
+	if err != nil {
+		return "", err
+	}
+	if blob.GetSHA() != f.sha {
+		return "", fmt.Errorf("blob for %v was stored as %v, want %v", f.path, blob.GetSHA(), f.sha)
+	}
+	return f.sha, nil
+}
+
+// scanSyncDirectory returns the files in dir keyed by their path in the
+// repository under prefix.
+func scanSyncDirectory(dir, prefix string, exclude func(string) bool) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if (d.IsDir() && d.Name() == ".git") || (exclude != nil && exclude(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var mode string
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			mode = gitModeSymlink
+		case info.Mode().IsRegular() && info.Mode()&0o111 != 0:
+			mode = gitModeExecutable
+		case info.Mode().IsRegular():
+			mode = gitModeFile
+		default:
+			// Sockets, devices and the like cannot be stored in git.
+			return nil
+		}
+
+		content, err := readSyncFile(p, mode)
+		if err != nil {
+			return err
+		}
+		name := path.Join(prefix, rel)
+		files[name] = &syncFile{path: name, mode: mode, sha: gitBlobSHA(content)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readSyncFile returns the content git stores for the file at p, which is
+// the target of a symbolic link.
+func readSyncFile(p, mode string) ([]byte, error) {
+	if mode == gitModeSymlink {
+		target, err := os.Readlink(p)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(filepath.ToSlash(target)), nil
+	}
+	return os.ReadFile(p)
+}
+
+// gitBlobSHA returns the SHA git assigns to a blob with the given content.
+func gitBlobSHA(content []byte) string {
+	return gitObjectSHA("blob", string(content))
+}
+
+// underSyncPath reports whether the repository path p is inside prefix.
+func underSyncPath(p, prefix string) bool {
+	return prefix == "" || strings.HasPrefix(p, prefix+"/")
+}
+
+// excludedSyncPath reports whether rel or any of its parent directories is
+// excluded.
+func excludedSyncPath(rel string, exclude func(string) bool) bool {
+	for p := rel; p != "."; p = path.Dir(p) {
+		if exclude(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGitBlobSHA(t *testing.T) {
+	// As computed by: printf 'hello\n' | git hash-object --stdin
+	if got, want := gitBlobSHA([]byte("hello\n")), "ce013625030ba8dba906f756967f9e9ca394464a"; got != want {
+		t.Errorf("gitBlobSHA = %v, want %v", got, want)
+	}
+	if got, want := gitBlobSHA(nil), "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"; got != want {
+		t.Errorf("gitBlobSHA(empty) = %v, want %v", got, want)
+	}
+}
+
+func writeSyncTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"index.html":      "index",
+		"same.txt":        "same",
+		"changed.txt":     "new",
+		"run.sh":          "#!/bin/sh\n",
+		".git/HEAD":       "ignored",
+		"drafts/wip.html": "ignored",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(dir, "run.sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func syncTestTree(entries ...string) string {
+	tree := `{"sha":"t0","truncated":false,"tree":[`
+	for i, e := range entries {
+		if i > 0 {
+			tree += ","
+		}
+		tree += e
+	}
+	return tree + "]}"
+}
+
+func TestGitService_SyncDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable modes are not supported")
+	}
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := writeSyncTestDir(t)
+	mux.HandleFunc("/repos/o/r/branches/gh-pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"name":"gh-pages","protected":false,"commit":{"sha":"c0","commit":{"tree":{"sha":"t0"}}}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/trees/t0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"recursive": "1"})
+		fmt.Fprint(w, syncTestTree(
+			`{"path":"README.md","mode":"100644","type":"blob","sha":"r1"}`,
+			`{"path":"site","mode":"040000","type":"tree","sha":"s1"}`,
+			fmt.Sprintf(`{"path":"site/same.txt","mode":"100644","type":"blob","sha":"%v"}`, gitBlobSHA([]byte("same"))),
+			`{"path":"site/changed.txt","mode":"100644","type":"blob","sha":"old"}`,
+			fmt.Sprintf(`{"path":"site/run.sh","mode":"100644","type":"blob","sha":"%v"}`, gitBlobSHA([]byte("#!/bin/sh\n"))),
+			`{"path":"site/removed.txt","mode":"100644","type":"blob","sha":"x1"}`,
+			`{"path":"site/drafts/old.html","mode":"100644","type":"blob","sha":"x2"}`,
+		))
+	})
+
+	var uploaded []string
+	mux.HandleFunc("/repos/o/r/git/blobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var blob Blob
+		if err := json.NewDecoder(r.Body).Decode(&blob); err != nil {
+			t.Fatal(err)
+		}
+		content, err := base64.StdEncoding.DecodeString(blob.GetContent())
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploaded = append(uploaded, string(content))
+		fmt.Fprintf(w, `{"sha":"%v"}`, gitBlobSHA(content))
+	})
+
+	var treeBody string
+	mux.HandleFunc("/repos/o/r/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		b, _ := io.ReadAll(r.Body)
+		treeBody = string(b)
+		fmt.Fprint(w, `{"sha":"t1"}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"message":"Update site","tree":"t1","parents":["c0"]}`+"\n")
+		fmt.Fprint(w, `{"sha":"c1"}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs/heads/gh-pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"sha":"c1","force":false}`+"\n")
+		fmt.Fprint(w, `{"ref":"refs/heads/gh-pages"}`)
+	})
+
+	ctx := context.Background()
+	opts := &SyncDirectoryOptions{
+		Branch:  "gh-pages",
+		Path:    "/site/",
+		Message: "Update site",
+		Exclude: func(name string) bool { return name == "drafts" },
+	}
+	result, err := client.Git.SyncDirectory(ctx, "o", "r", dir, opts)
+	if err != nil {
+		t.Fatalf("Git.SyncDirectory returned error: %v", err)
+	}
+
+	want := &SyncDirectoryResult{
+		Commit:   &Commit{SHA: String("c1")},
+		Added:    []string{"site/index.html"},
+		Modified: []string{"site/changed.txt", "site/run.sh"},
+		Deleted:  []string{"site/removed.txt"},
+	}
+	if !cmp.Equal(result, want) {
+		t.Errorf("Git.SyncDirectory returned %+v, want %+v", result, want)
+	}
+	if want := []string{"new", "index"}; !cmp.Equal(uploaded, want) {
+		t.Errorf("uploaded blobs %q, want %q", uploaded, want)
+	}
+
+	wantTree := fmt.Sprintf(`{"base_tree":"t0","tree":[`+
+		`{"sha":null,"path":"site/removed.txt","mode":"100644","type":"blob"},`+
+		`{"sha":"%v","path":"site/changed.txt","mode":"100644","type":"blob"},`+
+		`{"sha":"%v","path":"site/index.html","mode":"100644","type":"blob"},`+
+		`{"sha":"%v","path":"site/run.sh","mode":"100755","type":"blob"}]}`+"\n",
+		gitBlobSHA([]byte("new")), gitBlobSHA([]byte("index")), gitBlobSHA([]byte("#!/bin/sh\n")))
+	if treeBody != wantTree {
+		t.Errorf("tree request body = %v, want %v", treeBody, wantTree)
+	}
+}
+
+func TestGitService_SyncDirectory_noChanges(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink("a.txt", filepath.Join(dir, "b.txt")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mux.HandleFunc("/repos/o/r/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"main","protected":true,"commit":{"sha":"c0","commit":{"tree":{"sha":"t0"}}}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/trees/t0", func(w http.ResponseWriter, r *http.Request) {
+		entries := []string{fmt.Sprintf(`{"path":"a.txt","mode":"100644","type":"blob","sha":"%v"}`, gitBlobSHA([]byte("a")))}
+		if runtime.GOOS != "windows" {
+			entries = append(entries, fmt.Sprintf(`{"path":"b.txt","mode":"120000","type":"blob","sha":"%v"}`, gitBlobSHA([]byte("a.txt"))))
+		}
+		fmt.Fprint(w, syncTestTree(entries...))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+	})
+
+	ctx := context.Background()
+	result, err := client.Git.SyncDirectory(ctx, "o", "r", dir, &SyncDirectoryOptions{Branch: "main"})
+	if err != nil {
+		t.Fatalf("Git.SyncDirectory returned error: %v", err)
+	}
+	if want := (&SyncDirectoryResult{}); !cmp.Equal(result, want) {
+		t.Errorf("Git.SyncDirectory returned %+v, want %+v", result, want)
+	}
+}
+
+func TestGitService_SyncDirectory_protectedBranch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "client.go"), []byte("package client\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/repos/o/r/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"main","protected":true,"commit":{"sha":"c0","commit":{"tree":{"sha":"t0"}}}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/trees/t0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, syncTestTree())
+	})
+	mux.HandleFunc("/repos/o/r/git/blobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"sha":"%v"}`, gitBlobSHA([]byte("package client\n")))
+	})
+	mux.HandleFunc("/repos/o/r/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha":"t1"}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, `{"message":"Sync api from local directory","tree":"t1","parents":["c0"]}`+"\n")
+		fmt.Fprint(w, `{"sha":"0123456789abcdef"}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"ref":"refs/heads/sync/main-0123456","sha":"0123456789abcdef"}`+"\n")
+		fmt.Fprint(w, `{"ref":"refs/heads/sync/main-0123456"}`)
+	})
+	mux.HandleFunc("/repos/o/r/pulls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"title":"Sync api from local directory","head":"sync/main-0123456","base":"main","body":"Generated."}`+"\n")
+		fmt.Fprint(w, `{"number":7}`)
+	})
+
+	ctx := context.Background()
+	result, err := client.Git.SyncDirectory(ctx, "o", "r", dir, &SyncDirectoryOptions{
+		Branch:          "main",
+		Path:            "api",
+		PullRequestBody: "Generated.",
+	})
+	if err != nil {
+		t.Fatalf("Git.SyncDirectory returned error: %v", err)
+	}
+
+	want := &SyncDirectoryResult{
+		Commit:      &Commit{SHA: String("0123456789abcdef")},
+		PullRequest: &PullRequest{Number: Int(7)},
+		Added:       []string{"api/client.go"},
+	}
+	if !cmp.Equal(result, want) {
+		t.Errorf("Git.SyncDirectory returned %+v, want %+v", result, want)
+	}
+}
+
+func TestGitService_SyncDirectory_invalidOptions(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	if _, err := client.Git.SyncDirectory(ctx, "o", "r", t.TempDir(), nil); err == nil {
+		t.Error("Git.SyncDirectory with no branch returned nil error")
+	}
+	if _, err := client.Git.SyncDirectory(ctx, "o", "r", t.TempDir(), &SyncDirectoryOptions{Branch: "main", Path: "../x"}); err == nil {
+		t.Error("Git.SyncDirectory with invalid path returned nil error")
+	}
+}
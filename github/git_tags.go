@@ -6,8 +6,13 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 )
 
 // Tag represents a tag object.
@@ -86,3 +91,183 @@ func (s *GitService) CreateTag(ctx context.Context, owner string, repo string, t
 
 	return t, resp, nil
 }
+
+// CreateTagWithRefOptions specifies optional parameters to the
+// GitService.CreateTagWithRef method.
+type CreateTagWithRefOptions struct {
+	// Message is the message of an annotated tag. If it is empty, a
+	// lightweight tag is created, pointing directly at the object.
+	Message string
+
+	// ObjectType is the type of the tagged object. It defaults to "commit".
+	ObjectType string
+
+	// Tagger of an annotated tag. It is required to sign the tag. The date
+	// defaults to the current time.
+	Tagger *CommitAuthor
+
+	// Signer, if set, signs the annotated tag. See MessageSigner.
+	Signer MessageSigner
+
+	// Force moves the tag if it already exists and points at a different
+	// object.
+	Force bool
+}
+
+// CreateTagWithRef creates the tag name for the object sha: it creates the
+// tag object, for an annotated tag, and the refs/tags/<name> reference. It
+// returns the reference and, for an annotated tag, the tag object.
+//
+// If the tag already exists and points at sha, either directly or through
+// an annotated tag object, it is returned unchanged. If it points
+// elsewhere, an error is returned unless opts.Force is set, in which case
+// the tag is moved.
+//
+// GitHub API docs: https://docs.github.com/rest/git/refs#create-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#get-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#update-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/tags#create-a-tag-object
+// GitHub API docs: https://docs.github.com/rest/git/tags#get-a-tag
+//
+//meta:operation GET /repos/{owner}/{repo}/git/ref/{ref}
+//meta:operation POST /repos/{owner}/{repo}/git/refs
+//meta:operation PATCH /repos/{owner}/{repo}/git/refs/{ref}
+//meta:operation POST /repos/{owner}/{repo}/git/tags
+//meta:operation GET /repos/{owner}/{repo}/git/tags/{tag_sha}
+func (s *GitService) CreateTagWithRef(ctx context.Context, owner, repo, name, sha string, opts *CreateTagWithRefOptions) (*Reference, *Tag, error) {
+	if opts == nil {
+		opts = &CreateTagWithRefOptions{}
+	}
+	if name == "" || sha == "" {
+		return nil, nil, errors.New("a tag name and object SHA are required")
+	}
+	if opts.Signer != nil && opts.Message == "" {
+		return nil, nil, errors.New("only annotated tags can be signed")
+	}
+	annotated := opts.Message != ""
+
+	refName := "tags/" + name
+	existing, resp, err := s.GetRef(ctx, owner, repo, refName)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return nil, nil, err
+	}
+	if existing != nil {
+		tag, same, err := s.tagRefPointsAt(ctx, owner, repo, existing, sha, annotated)
+		if err != nil {
+			return nil, nil, err
+		}
+		if same {
+			return existing, tag, nil
+		}
+		if !opts.Force {
+			return nil, nil, fmt.Errorf("tag %v already exists and points at %v", name, existing.GetObject().GetSHA())
+		}
+	}
+
+	var tag *Tag
+	target := sha
+	if annotated {
+		if tag, err = s.createTagObject(ctx, owner, repo, name, sha, opts); err != nil {
+			return nil, nil, err
+		}
+		target = tag.GetSHA()
+	}
+
+	ref := &Reference{Ref: String("refs/" + refName), Object: &GitObject{SHA: String(target)}}
+	if existing != nil {
+		updated, _, err := s.UpdateRef(ctx, owner, repo, ref, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return updated, tag, nil
+	}
+
+	created, resp, err := s.CreateRef(ctx, owner, repo, ref)
+	if err == nil {
+		return created, tag, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnprocessableEntity {
+		return nil, nil, err
+	}
+
+	// The tag may have been created concurrently.
+	existing, _, gerr := s.GetRef(ctx, owner, repo, refName)
+	if gerr != nil {
+		return nil, nil, err
+	}
+	existingTag, same, gerr := s.tagRefPointsAt(ctx, owner, repo, existing, sha, annotated)
+	if gerr != nil || !same {
+		return nil, nil, err
+	}
+	return existing, existingTag, nil
+}
+
+// tagRefPointsAt reports whether the tag reference ref points at the
+// object sha, through an annotated tag object if annotated is true. The
+// annotated tag object is returned if there is one.
+func (s *GitService) tagRefPointsAt(ctx context.Context, owner, repo string, ref *Reference, sha string, annotated bool) (*Tag, bool, error) {
+	obj := ref.GetObject()
+	if obj.GetType() != "tag" {
+		return nil, !annotated && obj.GetSHA() == sha, nil
+	}
+	if !annotated {
+		return nil, false, nil
+	}
+	tag, _, err := s.GetTag(ctx, owner, repo, obj.GetSHA())
+	if err != nil {
+		return nil, false, err
+	}
+	return tag, tag.GetObject().GetSHA() == sha, nil
+}
+
+// createTagObject creates the annotated tag object, signing it with
+// opts.Signer if set.
+func (s *GitService) createTagObject(ctx context.Context, owner, repo, name, sha string, opts *CreateTagWithRefOptions) (*Tag, error) {
+	objectType := opts.ObjectType
+	if objectType == "" {
+		objectType = "commit"
+	}
+	tag := &Tag{
+		Tag:     String(name),
+		Message: String(opts.Message),
+		Tagger:  opts.Tagger,
+		Object:  &GitObject{SHA: String(sha), Type: String(objectType)},
+	}
+	if opts.Signer == nil {
+		t, _, err := s.CreateTag(ctx, owner, repo, tag)
+		return t, err
+	}
+
+	if opts.Tagger == nil {
+		return nil, errors.New("a tagger is required to sign a tag")
+	}
+	// GitHub stores the time in UTC, so the signed payload must use it too.
+	tagger := *opts.Tagger
+	date := time.Now()
+	if tagger.Date != nil {
+		date = tagger.Date.Time
+	}
+	tagger.Date = &Timestamp{date.UTC().Truncate(time.Second)}
+	tag.Tagger = &tagger
+
+	message := opts.Message
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	payload := fmt.Sprintf("object %v\ntype %v\ntag %v\ntagger %v\n\n%v", sha, objectType, name, gitIdentity(&tagger), message)
+	var signature bytes.Buffer
+	if err := opts.Signer.Sign(&signature, strings.NewReader(payload)); err != nil {
+		return nil, err
+	}
+	// Git stores the signature of a tag at the end of its message.
+	tag.Message = String(message + signature.String())
+
+	t, _, err := s.CreateTag(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+	if want := gitObjectSHA("tag", payload+signature.String()); t.GetSHA() != want {
+		return nil, fmt.Errorf("signed tag was stored as %v, want %v", t.GetSHA(), want)
+	}
+	return t, nil
+}
@@ -7,10 +7,14 @@ package github
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -185,3 +189,185 @@ func TestCreateTagRequest_Marshal(t *testing.T) {
 
 	testJSONMarshal(t, u, want)
 }
+
+func TestGitService_CreateTagWithRef_lightweight(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"ref":"refs/tags/v1","sha":"c"}`+"\n")
+		fmt.Fprint(w, `{"ref":"refs/tags/v1","object":{"type":"commit","sha":"c"}}`)
+	})
+
+	ctx := context.Background()
+	ref, tag, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", nil)
+	if err != nil {
+		t.Fatalf("Git.CreateTagWithRef returned error: %v", err)
+	}
+	if tag != nil {
+		t.Errorf("Git.CreateTagWithRef returned tag %v, want nil", tag)
+	}
+	want := &Reference{Ref: String("refs/tags/v1"), Object: &GitObject{Type: String("commit"), SHA: String("c")}}
+	if !cmp.Equal(ref, want) {
+		t.Errorf("Git.CreateTagWithRef returned %+v, want %+v", ref, want)
+	}
+
+	const methodName = "CreateTagWithRef"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Git.CreateTagWithRef(ctx, "\n", "\n", "v1", "c", nil)
+		return err
+	})
+}
+
+func TestGitService_CreateTagWithRef_signed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSSHSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	var tagSHA, message string
+	mux.HandleFunc("/repos/o/r/git/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := new(createTagRequest)
+		assertNilError(t, json.NewDecoder(r.Body).Decode(v))
+		message = *v.Message
+		// Store the tag object the way GitHub does.
+		object := fmt.Sprintf("object %v\ntype %v\ntag %v\ntagger %v <%v> %v +0000\n\n%v",
+			*v.Object, *v.Type, *v.Tag, v.Tagger.GetName(), v.Tagger.GetEmail(), v.Tagger.GetDate().Unix(), message)
+		tagSHA = gitObjectSHA("tag", object)
+		fmt.Fprintf(w, `{"sha":%q,"tag":"v1","object":{"type":"commit","sha":"c"}}`, tagSHA)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, fmt.Sprintf(`{"ref":"refs/tags/v1","sha":%q}`+"\n", tagSHA))
+		fmt.Fprintf(w, `{"ref":"refs/tags/v1","object":{"type":"tag","sha":%q}}`, tagSHA)
+	})
+
+	ctx := context.Background()
+	tagger := &CommitAuthor{Name: String("n"), Email: String("e@example.com"), Date: &Timestamp{referenceTime.In(time.FixedZone("", 7200))}}
+	_, tag, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", &CreateTagWithRefOptions{
+		Message: "Release v1",
+		Tagger:  tagger,
+		Signer:  signer,
+	})
+	if err != nil {
+		t.Fatalf("Git.CreateTagWithRef returned error: %v", err)
+	}
+	if tag.GetSHA() != tagSHA {
+		t.Errorf("Git.CreateTagWithRef returned tag %v, want %v", tag.GetSHA(), tagSHA)
+	}
+
+	parts := strings.SplitN(message, "-----BEGIN SSH SIGNATURE-----", 2)
+	if len(parts) != 2 || parts[0] != "Release v1\n" {
+		t.Fatalf("tag message = %q, want the message followed by a signature", message)
+	}
+	payload := fmt.Sprintf("object c\ntype commit\ntag v1\ntagger n <e@example.com> %v +0000\n\nRelease v1\n", referenceTime.Unix())
+	keys := []*SSHSigningKey{{Key: String(signer.PublicKey())}}
+	if _, err := VerifySSHSignature(strings.NewReader(payload), "-----BEGIN SSH SIGNATURE-----"+parts[1], keys); err != nil {
+		t.Errorf("VerifySSHSignature of the tag returned error: %v", err)
+	}
+
+	if _, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", &CreateTagWithRefOptions{Signer: signer}); err == nil {
+		t.Error("Git.CreateTagWithRef of a signed lightweight tag returned nil error")
+	}
+	if _, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", &CreateTagWithRefOptions{Message: "m", Signer: signer}); err == nil {
+		t.Error("Git.CreateTagWithRef of a signed tag without a tagger returned nil error")
+	}
+}
+
+func TestGitService_CreateTagWithRef_exists(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v1","object":{"type":"tag","sha":"t"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/tags/t", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"sha":"t","tag":"v1","object":{"type":"commit","sha":"c"}}`)
+	})
+
+	ctx := context.Background()
+	ref, tag, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", &CreateTagWithRefOptions{Message: "m"})
+	if err != nil {
+		t.Fatalf("Git.CreateTagWithRef returned error: %v", err)
+	}
+	if ref.GetObject().GetSHA() != "t" || tag.GetSHA() != "t" {
+		t.Errorf("Git.CreateTagWithRef returned %v, %v, want the existing tag", ref, tag)
+	}
+
+	if _, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "d", &CreateTagWithRefOptions{Message: "m"}); err == nil {
+		t.Error("Git.CreateTagWithRef for another object returned nil error")
+	}
+	if _, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", nil); err == nil {
+		t.Error("Git.CreateTagWithRef of a lightweight tag over an annotated one returned nil error")
+	}
+}
+
+func TestGitService_CreateTagWithRef_force(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/git/ref/tags/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/latest","object":{"type":"commit","sha":"old"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs/tags/latest", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"sha":"new","force":true}`+"\n")
+		fmt.Fprint(w, `{"ref":"refs/tags/latest","object":{"type":"commit","sha":"new"}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "latest", "new", nil); err == nil {
+		t.Error("Git.CreateTagWithRef without Force returned nil error")
+	}
+	ref, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "latest", "new", &CreateTagWithRefOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Git.CreateTagWithRef returned error: %v", err)
+	}
+	if ref.GetObject().GetSHA() != "new" {
+		t.Errorf("Git.CreateTagWithRef moved the tag to %v, want new", ref.GetObject().GetSHA())
+	}
+}
+
+func TestGitService_CreateTagWithRef_createdConcurrently(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	exists := false
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v1", func(w http.ResponseWriter, r *http.Request) {
+		if !exists {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"ref":"refs/tags/v1","object":{"type":"commit","sha":"c"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		exists = true
+		http.Error(w, `{"message":"Reference already exists"}`, http.StatusUnprocessableEntity)
+	})
+
+	ctx := context.Background()
+	ref, _, err := client.Git.CreateTagWithRef(ctx, "o", "r", "v1", "c", nil)
+	if err != nil {
+		t.Fatalf("Git.CreateTagWithRef returned error: %v", err)
+	}
+	if ref.GetObject().GetSHA() != "c" {
+		t.Errorf("Git.CreateTagWithRef returned %v, want the existing tag", ref)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Signature formats reported in GitSignature.Format.
+const (
+	GitSignatureFormatGPG = "gpg"
+	GitSignatureFormatSSH = "ssh"
+)
+
+// GitSignature describes a signature verified locally by
+// GitService.VerifyCommitSignature or GitService.VerifyTagSignature.
+type GitSignature struct {
+	// Format is the format of the signature, "gpg" or "ssh".
+	Format string `json:"format"`
+
+	// GPGKey is the registered key that made a GPG signature. If the
+	// signature was made by a subkey, this is the primary key.
+	GPGKey *GPGKey `json:"gpg_key,omitempty"`
+
+	// KeyID is the ID of the GPG key or subkey that made the signature.
+	KeyID *string `json:"key_id,omitempty"`
+
+	// SSHSigningKey is the registered key that made an SSH signature.
+	SSHSigningKey *SSHSigningKey `json:"ssh_signing_key,omitempty"`
+
+	// Emails are the verified email addresses of the GPG key.
+	Emails []string `json:"emails,omitempty"`
+
+	// SignedAt is the creation time recorded in a GPG signature.
+	SignedAt *Timestamp `json:"signed_at,omitempty"`
+
+	// KeyExpiresAt is the time the GPG key that made the signature
+	// expires, if it does.
+	KeyExpiresAt *Timestamp `json:"key_expires_at,omitempty"`
+
+	// KeyExpired reports whether the GPG key had expired when the
+	// signature was made.
+	KeyExpired bool `json:"key_expired"`
+}
+
+// GPGVerifier is used by GitService.VerifyCommitSignature and
+// GitService.VerifyTagSignature to verify GPG signatures. This package does
+// not implement OpenPGP; use a vetted library instead.
+//
+// To create a GPGVerifier with [github.com/ProtonMail/go-crypto/openpgp], use:
+//
+//	opts.GPGVerifier = github.GPGVerifierFunc(func(message io.Reader, signature string, keys []*github.GPGKey) (string, time.Time, error) {
+//		var keyring openpgp.EntityList
+//		for _, k := range keys {
+//			entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.GetRawKey()))
+//			if err == nil {
+//				keyring = append(keyring, entities...)
+//			}
+//		}
+//		sig, _, err := openpgp.VerifyArmoredDetachedSignature(keyring, message, strings.NewReader(signature), nil)
+//		if err != nil {
+//			return "", time.Time{}, err
+//		}
+//		return fmt.Sprintf("%016X", *sig.IssuerKeyId), sig.CreationTime, nil
+//	})
+type GPGVerifier interface {
+	// Verify verifies the armored detached signature of message against
+	// keys, the GPG keys registered by the signer. It returns the ID, in
+	// hex, of the key or subkey that made the signature, and the time the
+	// signature was made.
+	//
+	// Verify must reject expired signatures and signatures made with weak
+	// hash algorithms, such as SHA-1.
+	Verify(message io.Reader, signature string, keys []*GPGKey) (keyID string, signedAt time.Time, err error)
+}
+
+// GPGVerifierFunc is a single function implementation of GPGVerifier.
+type GPGVerifierFunc func(message io.Reader, signature string, keys []*GPGKey) (keyID string, signedAt time.Time, err error)
+
+func (f GPGVerifierFunc) Verify(message io.Reader, signature string, keys []*GPGKey) (string, time.Time, error) {
+	return f(message, signature, keys)
+}
+
+// VerifySignatureOptions specifies the optional parameters to
+// GitService.VerifyCommitSignature and GitService.VerifyTagSignature.
+type VerifySignatureOptions struct {
+	// GPGVerifier verifies GPG signatures. It is required to verify them;
+	// SSH signatures are verified by this package. See GPGVerifier.
+	GPGVerifier GPGVerifier
+}
+
+// VerifyCommitSignature verifies the signature of commit against the GPG
+// keys and SSH signing keys registered by user, independently of the
+// verdict GitHub reports in commit.Verification. GPG signatures are
+// verified by opts.GPGVerifier.
+//
+// The signed payload is reconstructed from the commit's fields, falling
+// back to commit.Verification.Payload, and is only trusted if the signed
+// commit object hashes to commit.SHA. The commit must therefore have been
+// fetched with GitService.GetCommit or another endpoint that returns the
+// tree, parents and signature.
+//
+// GitHub API docs: https://docs.github.com/rest/users/gpg-keys#list-gpg-keys-for-a-user
+// GitHub API docs: https://docs.github.com/rest/users/ssh-signing-keys#list-ssh-signing-keys-for-a-user
+//
+//meta:operation GET /users/{username}/gpg_keys
+//meta:operation GET /users/{username}/ssh_signing_keys
+func (s *GitService) VerifyCommitSignature(ctx context.Context, user string, commit *Commit, opts *VerifySignatureOptions) (*GitSignature, error) {
+	if commit == nil {
+		return nil, errors.New("commit must be provided")
+	}
+	signature := commit.GetVerification().GetSignature()
+	if signature == "" {
+		return nil, errors.New("commit has no signature")
+	}
+
+	var candidates []string
+	for _, message := range gitMessageCandidates(commit.GetMessage()) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "tree %v\n", commit.GetTree().GetSHA())
+		for _, p := range commit.Parents {
+			fmt.Fprintf(&b, "parent %v\n", p.GetSHA())
+		}
+		fmt.Fprintf(&b, "author %v\n", gitIdentity(commit.GetAuthor()))
+		fmt.Fprintf(&b, "committer %v\n", gitIdentity(commit.GetCommitter()))
+		b.WriteString("\n" + message)
+		candidates = append(candidates, b.String())
+	}
+	if p := commit.GetVerification().Payload; p != nil {
+		candidates = append(candidates, *p)
+	}
+
+	payload := ""
+	for _, c := range candidates {
+		if gitObjectSHA("commit", signedCommitObject(c, signature)) == commit.GetSHA() {
+			payload = c
+			break
+		}
+	}
+	if payload == "" {
+		return nil, fmt.Errorf("signed payload of commit %v could not be reconstructed", commit.GetSHA())
+	}
+	return s.verifyGitSignature(ctx, user, payload, signature, opts)
+}
+
+// VerifyTagSignature verifies the signature of the annotated tag against
+// the GPG keys and SSH signing keys registered by user, independently of
+// the verdict GitHub reports in tag.Verification. GPG signatures are
+// verified by opts.GPGVerifier.
+//
+// The signed payload is reconstructed from the tag's fields, falling back
+// to tag.Verification.Payload, and is only trusted if the signed tag
+// object hashes to tag.SHA. The tag must therefore have been fetched with
+// GitService.GetTag.
+//
+// GitHub API docs: https://docs.github.com/rest/users/gpg-keys#list-gpg-keys-for-a-user
+// GitHub API docs: https://docs.github.com/rest/users/ssh-signing-keys#list-ssh-signing-keys-for-a-user
+//
+//meta:operation GET /users/{username}/gpg_keys
+//meta:operation GET /users/{username}/ssh_signing_keys
+func (s *GitService) VerifyTagSignature(ctx context.Context, user string, tag *Tag, opts *VerifySignatureOptions) (*GitSignature, error) {
+	if tag == nil {
+		return nil, errors.New("tag must be provided")
+	}
+	signature := tag.GetVerification().GetSignature()
+	if signature == "" {
+		return nil, errors.New("tag has no signature")
+	}
+
+	var candidates []string
+	for _, message := range gitMessageCandidates(tag.GetMessage()) {
+		candidates = append(candidates, fmt.Sprintf("object %v\ntype %v\ntag %v\ntagger %v\n\n%v",
+			tag.GetObject().GetSHA(), tag.GetObject().GetType(), tag.GetTag(), gitIdentity(tag.GetTagger()), message))
+	}
+	if p := tag.GetVerification().Payload; p != nil {
+		candidates = append(candidates, *p)
+	}
+
+	payload := ""
+	for _, c := range candidates {
+		if gitObjectSHA("tag", c+signature) == tag.GetSHA() {
+			payload = c
+			break
+		}
+	}
+	if payload == "" {
+		return nil, fmt.Errorf("signed payload of tag %v could not be reconstructed", tag.GetSHA())
+	}
+	return s.verifyGitSignature(ctx, user, payload, signature, opts)
+}
+
+// verifyGitSignature verifies signature of payload against the keys
+// registered by user.
+func (s *GitService) verifyGitSignature(ctx context.Context, user, payload, signature string, opts *VerifySignatureOptions) (*GitSignature, error) {
+	if strings.HasPrefix(strings.TrimSpace(signature), sshSigBegin) {
+		keys, err := s.listAllSSHSigningKeys(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		key, err := VerifySSHSignature(strings.NewReader(payload), signature, keys)
+		if err != nil {
+			return nil, err
+		}
+		return &GitSignature{Format: GitSignatureFormatSSH, SSHSigningKey: key}, nil
+	}
+
+	if opts == nil || opts.GPGVerifier == nil {
+		return nil, errors.New("a GPGVerifier must be provided to verify GPG signatures")
+	}
+	var keys []*GPGKey
+	lo := &ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.client.Users.ListGPGKeys(ctx, user, lo)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		lo.Page = resp.NextPage
+	}
+
+	keyID, signedAt, err := opts.GPGVerifier.Verify(strings.NewReader(payload), signature, keys)
+	if err != nil {
+		return nil, err
+	}
+	primary, signing := findGPGKey(keys, keyID)
+	if signing == nil {
+		return nil, fmt.Errorf("GPG signature was made by unknown key %v", keyID)
+	}
+	if signing.CanSign != nil && !signing.GetCanSign() {
+		return nil, fmt.Errorf("GPG key %v is not a signing key", keyID)
+	}
+
+	result := &GitSignature{
+		Format: GitSignatureFormatGPG,
+		GPGKey: primary,
+		KeyID:  String(signing.GetKeyID()),
+	}
+	for _, e := range primary.Emails {
+		if e.GetVerified() {
+			result.Emails = append(result.Emails, e.GetEmail())
+		}
+	}
+	if signedAt.IsZero() {
+		signedAt = time.Now()
+	} else {
+		result.SignedAt = &Timestamp{signedAt}
+	}
+	for _, k := range []*GPGKey{signing, primary} {
+		if k.ExpiresAt != nil && (result.KeyExpiresAt == nil || k.ExpiresAt.Before(result.KeyExpiresAt.Time)) {
+			result.KeyExpiresAt = k.ExpiresAt
+		}
+	}
+	result.KeyExpired = result.KeyExpiresAt != nil && signedAt.After(result.KeyExpiresAt.Time)
+	return result, nil
+}
+
+// findGPGKey returns the key among keys, and its primary key, whose key ID
+// matches id.
+func findGPGKey(keys []*GPGKey, id string) (primary, key *GPGKey) {
+	for _, k := range keys {
+		if strings.EqualFold(k.GetKeyID(), id) {
+			return k, k
+		}
+		for _, sub := range k.Subkeys {
+			if strings.EqualFold(sub.GetKeyID(), id) {
+				return k, sub
+			}
+		}
+	}
+	return nil, nil
+}
+
+// gitIdentity formats an author, committer or tagger as git stores it.
+// The API reports times in UTC, so the original time zone may be lost;
+// the resulting payload then fails to match the object SHA.
+func gitIdentity(a *CommitAuthor) string {
+	t := a.GetDate().Time
+	return fmt.Sprintf("%v <%v> %v %v", a.GetName(), a.GetEmail(), t.Unix(), t.Format("-0700"))
+}
+
+// gitMessageCandidates returns the possible messages stored in a git
+// object whose message is reported as m. The API may drop the trailing
+// newline git adds to messages.
+func gitMessageCandidates(m string) []string {
+	if strings.HasSuffix(m, "\n") {
+		return []string{m}
+	}
+	return []string{m + "\n", m}
+}
+
+// signedCommitObject returns the commit object created by adding signature
+// to payload as a gpgsig header.
+func signedCommitObject(payload, signature string) string {
+	i := strings.Index(payload, "\n\n")
+	if i < 0 {
+		return payload
+	}
+	header := "gpgsig " + strings.ReplaceAll(strings.TrimSuffix(signature, "\n"), "\n", "\n ")
+	return payload[:i+1] + header + payload[i:]
+}
+
+// gitObjectSHA returns the SHA of the git object of the given type and
+// content.
+func gitObjectSHA(objectType, content string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v %d\x00", objectType, len(content))
+	b.WriteString(content)
+	sum := sha1.Sum(b.Bytes())
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The signatures below were created with gpg and git. They are not
+// verified here, but are part of the signed objects whose SHAs are checked.
+
+const testGPGCommitSignature = `-----BEGIN PGP SIGNATURE-----
+
+iHUEABYIAB0WIQT7J6f1Xx9m5H/rd3U9gBOnOnjlNgUCatTPBAAKCRA9gBOnOnjl
+NuhEAQCz7yfgUsT4ZG1Af9bDGdfCxUT5rmjqQU+BA1b50omgtwD+PQZDuUdFPRWY
+WBgGcpqkOycG5vTudhRvugIUALpIOwM=
+=/Dco
+-----END PGP SIGNATURE-----
+`
+
+const testGPGTagSignature = `-----BEGIN PGP SIGNATURE-----
+
+iQEzBAABCgAdFiEEv2Sq3WPeawa0gbP/bhEsV35UpLgFAmrUzwQACgkQbhEsV35U
+pLhNvQf/ZC8jF4lEmTHHzc8itYWR6DAdRFHUGBsF6FbyR0E07JDARKTmABLyR1UM
+sQ5pvsibV6Zw4Zq2kNDLZ+AYd/eYFumH8PQZHVYG9CNcSXEcBiO7BuGlSh+OnbfY
+dP7vUPh9mUNR1qN2sZjq0P3ypokyXEGM/LCuiKesKz1h6S0YL6QzjaFVjhueIs8d
+9Yq5T+rptMHlgZCMGQyBtgHLHkNdIgo+xiMcrnK01pFaN6TXefWqp9Qrshev92T4
+I0VSaB1mKR0doFPz/lIhXpRTkypNpnP3fblep8S0wtu0GxF54Zrdi4m9uKqYbdu4
+0NC1551XkbeCjunUznYa1+K7n5L73w==
+=eChr
+-----END PGP SIGNATURE-----
+`
+
+func testGPGCommit() *Commit {
+	date := &Timestamp{time.Unix(1700000000, 0).UTC()}
+	signer := &CommitAuthor{Name: String("Test Signer"), Email: String("signer@example.com"), Date: date}
+	return &Commit{
+		SHA:          String("620a4930c4ceb8de9bd90e442a3924124667badd"),
+		Tree:         &Tree{SHA: String("c49897f29f9819a0ab6850d7e22443508a1a29d5")},
+		Author:       signer,
+		Committer:    signer,
+		Message:      String("Signed commit"),
+		Verification: &SignatureVerification{Signature: String(testGPGCommitSignature)},
+	}
+}
+
+func testGPGTag() *Tag {
+	return &Tag{
+		SHA:     String("eea9da17f77a22034127266a5925e2afc4fb8a0c"),
+		Tag:     String("v1"),
+		Message: String("Signed tag\n"),
+		Tagger: &CommitAuthor{
+			Name:  String("RSA Signer"),
+			Email: String("rsa@example.com"),
+			// The API reports the time in UTC, losing the +0200 offset.
+			Date: &Timestamp{time.Unix(1700000100, 0).UTC()},
+		},
+		Object: &GitObject{Type: String("commit"), SHA: String("620a4930c4ceb8de9bd90e442a3924124667badd")},
+		Verification: &SignatureVerification{
+			Signature: String(testGPGTagSignature),
+			Payload:   String("object 620a4930c4ceb8de9bd90e442a3924124667badd\ntype commit\ntag v1\ntagger RSA Signer <rsa@example.com> 1700000100 +0200\n\nSigned tag\n"),
+		},
+	}
+}
+
+// testGPGVerifier returns a GPGVerifier that accepts signature of payload,
+// made by keyID at signedAt.
+func testGPGVerifier(t *testing.T, payload, signature, keyID string, signedAt time.Time) GPGVerifier {
+	t.Helper()
+	return GPGVerifierFunc(func(message io.Reader, sig string, keys []*GPGKey) (string, time.Time, error) {
+		b, err := io.ReadAll(message)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		if string(b) != payload || sig != signature {
+			return "", time.Time{}, errors.New("invalid signature")
+		}
+		if len(keys) == 0 {
+			t.Error("GPGVerifier called without keys")
+		}
+		return keyID, signedAt, nil
+	})
+}
+
+const testGPGCommitPayload = "tree c49897f29f9819a0ab6850d7e22443508a1a29d5\n" +
+	"author Test Signer <signer@example.com> 1700000000 +0000\n" +
+	"committer Test Signer <signer@example.com> 1700000000 +0000\n\nSigned commit\n"
+
+func TestGitService_VerifyCommitSignature_gpg(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"id":1,"key_id":"65F226282DF6C9DC"},
+			{"id":2,"key_id":"3D8013A73A78E536","can_sign":true,
+			 "emails":[{"email":"signer@example.com","verified":true},{"email":"other@example.com","verified":false}]}
+		]`)
+	})
+
+	ctx := context.Background()
+	signedAt := time.Unix(1700000004, 0)
+	opts := &VerifySignatureOptions{
+		GPGVerifier: testGPGVerifier(t, testGPGCommitPayload, testGPGCommitSignature, "3d8013a73a78e536", signedAt),
+	}
+	got, err := client.Git.VerifyCommitSignature(ctx, "u", testGPGCommit(), opts)
+	if err != nil {
+		t.Fatalf("Git.VerifyCommitSignature returned error: %v", err)
+	}
+	if got.Format != GitSignatureFormatGPG || got.GPGKey.GetID() != 2 || got.GetKeyID() != "3D8013A73A78E536" {
+		t.Errorf("Git.VerifyCommitSignature returned %+v, want key 2", got)
+	}
+	if len(got.Emails) != 1 || got.Emails[0] != "signer@example.com" {
+		t.Errorf("Git.VerifyCommitSignature returned emails %v, want [signer@example.com]", got.Emails)
+	}
+	if !got.GetSignedAt().Time.Equal(signedAt) || got.KeyExpired {
+		t.Errorf("Git.VerifyCommitSignature returned %+v, want an unexpired key signed at %v", got, signedAt)
+	}
+
+	tampered := testGPGCommit()
+	tampered.Message = String("Tampered commit")
+	if _, err := client.Git.VerifyCommitSignature(ctx, "u", tampered, opts); err == nil {
+		t.Error("Git.VerifyCommitSignature of a tampered commit returned nil error")
+	}
+
+	// A payload that does not hash to the commit SHA is not trusted.
+	forged := testGPGCommit()
+	forged.Message = String("Forged")
+	forged.Verification.Payload = String("tree c49897f29f9819a0ab6850d7e22443508a1a29d5\n\nForged")
+	if _, err := client.Git.VerifyCommitSignature(ctx, "u", forged, opts); err == nil {
+		t.Error("Git.VerifyCommitSignature with a forged payload returned nil error")
+	}
+
+	const methodName = "VerifyCommitSignature"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Git.VerifyCommitSignature(ctx, "\n", testGPGCommit(), opts)
+		return err
+	})
+}
+
+func TestGitService_VerifyCommitSignature_invalid(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":1,"key_id":"65F226282DF6C9DC","can_sign":false,"subkeys":[{"id":3,"key_id":"6E112C577E54A4B8"}]}]`)
+	})
+
+	ctx := context.Background()
+	tests := map[string]*VerifySignatureOptions{
+		"no verifier": nil,
+		"rejected": {GPGVerifier: GPGVerifierFunc(func(io.Reader, string, []*GPGKey) (string, time.Time, error) {
+			return "", time.Time{}, errors.New("weak hash algorithm")
+		})},
+		"unknown key":     {GPGVerifier: testGPGVerifier(t, testGPGCommitPayload, testGPGCommitSignature, "3D8013A73A78E536", time.Time{})},
+		"not signing key": {GPGVerifier: testGPGVerifier(t, testGPGCommitPayload, testGPGCommitSignature, "65F226282DF6C9DC", time.Time{})},
+	}
+	for name, opts := range tests {
+		if _, err := client.Git.VerifyCommitSignature(ctx, "u", testGPGCommit(), opts); err == nil {
+			t.Errorf("%v: Git.VerifyCommitSignature returned nil error", name)
+		}
+	}
+	if _, err := client.Git.VerifyCommitSignature(ctx, "u", &Commit{}, nil); err == nil {
+		t.Error("Git.VerifyCommitSignature of an unsigned commit returned nil error")
+	}
+}
+
+func TestGitService_VerifyTagSignature_gpgSubkey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":1,"key_id":"65F226282DF6C9DC","expires_at":"2020-01-01T00:00:00Z",
+			"subkeys":[{"id":3,"key_id":"6E112C577E54A4B8","can_sign":true}]}]`)
+	})
+
+	ctx := context.Background()
+	tag := testGPGTag()
+	opts := &VerifySignatureOptions{
+		GPGVerifier: testGPGVerifier(t, tag.Verification.GetPayload(), testGPGTagSignature, "6E112C577E54A4B8", time.Unix(1700000100, 0)),
+	}
+	got, err := client.Git.VerifyTagSignature(ctx, "u", tag, opts)
+	if err != nil {
+		t.Fatalf("Git.VerifyTagSignature returned error: %v", err)
+	}
+	if got.GPGKey.GetID() != 1 || got.GetKeyID() != "6E112C577E54A4B8" {
+		t.Errorf("Git.VerifyTagSignature returned %+v, want subkey 6E112C577E54A4B8 of key 1", got)
+	}
+	if !got.KeyExpired || got.GetKeyExpiresAt().Year() != 2020 {
+		t.Errorf("Git.VerifyTagSignature returned %+v, want an expired key", got)
+	}
+
+	tag = testGPGTag()
+	tag.Verification.Payload = nil
+	if _, err := client.Git.VerifyTagSignature(ctx, "u", tag, opts); err == nil {
+		t.Error("Git.VerifyTagSignature without the original time zone returned nil error")
+	}
+	if _, err := client.Git.VerifyTagSignature(ctx, "u", nil, opts); err == nil {
+		t.Error("Git.VerifyTagSignature of a nil tag returned nil error")
+	}
+}
+
+func TestGitService_VerifyCommitSignature_ssh(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSSHSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := testGPGCommit()
+	commit.Message = String("SSH signed\n")
+	payload := "tree c49897f29f9819a0ab6850d7e22443508a1a29d5\n" +
+		"author Test Signer <signer@example.com> 1700000000 +0000\n" +
+		"committer Test Signer <signer@example.com> 1700000000 +0000\n\nSSH signed\n"
+	var sig bytes.Buffer
+	if err := signer.Sign(&sig, strings.NewReader(payload)); err != nil {
+		t.Fatal(err)
+	}
+	object := strings.Replace(payload, "\n\n", "\ngpgsig "+strings.ReplaceAll(strings.TrimSuffix(sig.String(), "\n"), "\n", "\n ")+"\n\n", 1)
+	commit.SHA = String(gitObjectSHA("commit", object))
+	commit.Verification = &SignatureVerification{Signature: String(sig.String())}
+
+	mux.HandleFunc("/users/u/ssh_signing_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `[{"id":5,"key":%q}]`, signer.PublicKey())
+	})
+
+	ctx := context.Background()
+	got, err := client.Git.VerifyCommitSignature(ctx, "u", commit, nil)
+	if err != nil {
+		t.Fatalf("Git.VerifyCommitSignature returned error: %v", err)
+	}
+	if got.Format != GitSignatureFormatSSH || got.SSHSigningKey.GetID() != 5 {
+		t.Errorf("Git.VerifyCommitSignature returned %+v, want SSH key 5", got)
+	}
+}
+
+func TestGitObjectSHA(t *testing.T) {
+	// As computed by: git hash-object -t commit.
+	object := "tree c49897f29f9819a0ab6850d7e22443508a1a29d5\n" +
+		"author Test Signer <signer@example.com> 1700000000 +0000\n" +
+		"committer Test Signer <signer@example.com> 1700000000 +0000\n" +
+		"gpgsig " + strings.ReplaceAll(strings.TrimSuffix(testGPGCommitSignature, "\n"), "\n", "\n ") + "\n" +
+		"\nSigned commit\n"
+	if got, want := gitObjectSHA("commit", object), "620a4930c4ceb8de9bd90e442a3924124667badd"; got != want {
+		t.Errorf("gitObjectSHA = %v, want %v", got, want)
+	}
+}
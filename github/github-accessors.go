@@ -126,6 +126,14 @@ func (a *ActionsCacheListOptions) GetSort() string {
 	return *a.Sort
 }
 
+// GetRepoCacheSizeLimitInGB returns the RepoCacheSizeLimitInGB field if it's non-nil, zero value otherwise.
+func (a *ActionsCacheUsagePolicy) GetRepoCacheSizeLimitInGB() int {
+	if a == nil || a.RepoCacheSizeLimitInGB == nil {
+		return 0
+	}
+	return *a.RepoCacheSizeLimitInGB
+}
+
 // GetAllowedActions returns the AllowedActions field if it's non-nil, zero value otherwise.
 func (a *ActionsPermissions) GetAllowedActions() string {
 	if a == nil || a.AllowedActions == nil {
@@ -4822,6 +4830,14 @@ func (c *CreateRunnerGroupRequest) GetVisibility() string {
 	return *c.Visibility
 }
 
+// GetTagger returns the Tagger field.
+func (c *CreateTagWithRefOptions) GetTagger() *CommitAuthor {
+	if c == nil {
+		return nil
+	}
+	return c.Tagger
+}
+
 // GetCanAdminsBypass returns the CanAdminsBypass field if it's non-nil, zero value otherwise.
 func (c *CreateUpdateEnvironment) GetCanAdminsBypass() bool {
 	if c == nil || c.CanAdminsBypass == nil {
@@ -4990,6 +5006,38 @@ func (c *CredentialAuthorization) GetTokenLastEight() string {
 	return *c.TokenLastEight
 }
 
+// GetDefaultValue returns the DefaultValue field if it's non-nil, zero value otherwise.
+func (c *CustomProperty) GetDefaultValue() string {
+	if c == nil || c.DefaultValue == nil {
+		return ""
+	}
+	return *c.DefaultValue
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (c *CustomProperty) GetDescription() string {
+	if c == nil || c.Description == nil {
+		return ""
+	}
+	return *c.Description
+}
+
+// GetPropertyName returns the PropertyName field if it's non-nil, zero value otherwise.
+func (c *CustomProperty) GetPropertyName() string {
+	if c == nil || c.PropertyName == nil {
+		return ""
+	}
+	return *c.PropertyName
+}
+
+// GetRequired returns the Required field if it's non-nil, zero value otherwise.
+func (c *CustomProperty) GetRequired() bool {
+	if c == nil || c.Required == nil {
+		return false
+	}
+	return *c.Required
+}
+
 // GetBaseRole returns the BaseRole field if it's non-nil, zero value otherwise.
 func (c *CustomRepoRoles) GetBaseRole() string {
 	if c == nil || c.BaseRole == nil {
@@ -6766,6 +6814,22 @@ func (e *Enterprise) GetWebsiteURL() string {
 	return *e.WebsiteURL
 }
 
+// GetMaxRepoCacheSizeLimitInGB returns the MaxRepoCacheSizeLimitInGB field if it's non-nil, zero value otherwise.
+func (e *EnterpriseActionsCacheUsagePolicy) GetMaxRepoCacheSizeLimitInGB() int {
+	if e == nil || e.MaxRepoCacheSizeLimitInGB == nil {
+		return 0
+	}
+	return *e.MaxRepoCacheSizeLimitInGB
+}
+
+// GetRepoCacheSizeLimitInGB returns the RepoCacheSizeLimitInGB field if it's non-nil, zero value otherwise.
+func (e *EnterpriseActionsCacheUsagePolicy) GetRepoCacheSizeLimitInGB() int {
+	if e == nil || e.RepoCacheSizeLimitInGB == nil {
+		return 0
+	}
+	return *e.RepoCacheSizeLimitInGB
+}
+
 // GetAllowsPublicRepositories returns the AllowsPublicRepositories field if it's non-nil, zero value otherwise.
 func (e *EnterpriseRunnerGroup) GetAllowsPublicRepositories() bool {
 	if e == nil || e.AllowsPublicRepositories == nil {
@@ -6990,6 +7054,30 @@ func (e *Environment) GetWaitTimer() int {
 	return *e.WaitTimer
 }
 
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (e *EnvironmentApproval) GetComment() string {
+	if e == nil || e.Comment == nil {
+		return ""
+	}
+	return *e.Comment
+}
+
+// GetState returns the State field if it's non-nil, zero value otherwise.
+func (e *EnvironmentApproval) GetState() string {
+	if e == nil || e.State == nil {
+		return ""
+	}
+	return *e.State
+}
+
+// GetUser returns the User field.
+func (e *EnvironmentApproval) GetUser() *User {
+	if e == nil {
+		return nil
+	}
+	return e.User
+}
+
 // GetTotalCount returns the TotalCount field if it's non-nil, zero value otherwise.
 func (e *EnvResponse) GetTotalCount() int {
 	if e == nil || e.TotalCount == nil {
@@ -7742,6 +7830,46 @@ func (g *GitObject) GetURL() string {
 	return *g.URL
 }
 
+// GetGPGKey returns the GPGKey field.
+func (g *GitSignature) GetGPGKey() *GPGKey {
+	if g == nil {
+		return nil
+	}
+	return g.GPGKey
+}
+
+// GetKeyExpiresAt returns the KeyExpiresAt field if it's non-nil, zero value otherwise.
+func (g *GitSignature) GetKeyExpiresAt() Timestamp {
+	if g == nil || g.KeyExpiresAt == nil {
+		return Timestamp{}
+	}
+	return *g.KeyExpiresAt
+}
+
+// GetKeyID returns the KeyID field if it's non-nil, zero value otherwise.
+func (g *GitSignature) GetKeyID() string {
+	if g == nil || g.KeyID == nil {
+		return ""
+	}
+	return *g.KeyID
+}
+
+// GetSignedAt returns the SignedAt field if it's non-nil, zero value otherwise.
+func (g *GitSignature) GetSignedAt() Timestamp {
+	if g == nil || g.SignedAt == nil {
+		return Timestamp{}
+	}
+	return *g.SignedAt
+}
+
+// GetSSHSigningKey returns the SSHSigningKey field.
+func (g *GitSignature) GetSSHSigningKey() *SSHSigningKey {
+	if g == nil {
+		return nil
+	}
+	return g.SSHSigningKey
+}
+
 // GetInstallation returns the Installation field.
 func (g *GollumEvent) GetInstallation() *Installation {
 	if g == nil {
@@ -10414,6 +10542,14 @@ func (l *LabelResult) GetURL() string {
 	return *l.URL
 }
 
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (l *LabelSpec) GetDescription() string {
+	if l == nil || l.Description == nil {
+		return ""
+	}
+	return *l.Description
+}
+
 // GetIncompleteResults returns the IncompleteResults field if it's non-nil, zero value otherwise.
 func (l *LabelsSearchResult) GetIncompleteResults() bool {
 	if l == nil || l.IncompleteResults == nil {
@@ -10430,6 +10566,30 @@ func (l *LabelsSearchResult) GetTotal() int {
 	return *l.Total
 }
 
+// GetCurrent returns the Current field.
+func (l *LabelSyncChange) GetCurrent() *Label {
+	if l == nil {
+		return nil
+	}
+	return l.Current
+}
+
+// GetDesired returns the Desired field.
+func (l *LabelSyncChange) GetDesired() *Label {
+	if l == nil {
+		return nil
+	}
+	return l.Desired
+}
+
+// GetPlan returns the Plan field.
+func (l *LabelSyncResult) GetPlan() *LabelSyncPlan {
+	if l == nil {
+		return nil
+	}
+	return l.Plan
+}
+
 // GetOID returns the OID field if it's non-nil, zero value otherwise.
 func (l *LargeFile) GetOID() string {
 	if l == nil || l.OID == nil {
@@ -10462,6 +10622,78 @@ func (l *LargeFile) GetSize() int {
 	return *l.Size
 }
 
+// GetExpiresAt returns the ExpiresAt field if it's non-nil, zero value otherwise.
+func (l *LFSAction) GetExpiresAt() Timestamp {
+	if l == nil || l.ExpiresAt == nil {
+		return Timestamp{}
+	}
+	return *l.ExpiresAt
+}
+
+// GetExpiresIn returns the ExpiresIn field if it's non-nil, zero value otherwise.
+func (l *LFSAction) GetExpiresIn() int64 {
+	if l == nil || l.ExpiresIn == nil {
+		return 0
+	}
+	return *l.ExpiresIn
+}
+
+// GetHeader returns the Header map if it's non-nil, an empty map otherwise.
+func (l *LFSAction) GetHeader() map[string]string {
+	if l == nil || l.Header == nil {
+		return map[string]string{}
+	}
+	return l.Header
+}
+
+// GetHashAlgo returns the HashAlgo field if it's non-nil, zero value otherwise.
+func (l *LFSBatchRequest) GetHashAlgo() string {
+	if l == nil || l.HashAlgo == nil {
+		return ""
+	}
+	return *l.HashAlgo
+}
+
+// GetRef returns the Ref field.
+func (l *LFSBatchRequest) GetRef() *LFSRef {
+	if l == nil {
+		return nil
+	}
+	return l.Ref
+}
+
+// GetHashAlgo returns the HashAlgo field if it's non-nil, zero value otherwise.
+func (l *LFSBatchResponse) GetHashAlgo() string {
+	if l == nil || l.HashAlgo == nil {
+		return ""
+	}
+	return *l.HashAlgo
+}
+
+// GetTransfer returns the Transfer field if it's non-nil, zero value otherwise.
+func (l *LFSBatchResponse) GetTransfer() string {
+	if l == nil || l.Transfer == nil {
+		return ""
+	}
+	return *l.Transfer
+}
+
+// GetAuthenticated returns the Authenticated field if it's non-nil, zero value otherwise.
+func (l *LFSObject) GetAuthenticated() bool {
+	if l == nil || l.Authenticated == nil {
+		return false
+	}
+	return *l.Authenticated
+}
+
+// GetError returns the Error field.
+func (l *LFSObject) GetError() *LFSObjectError {
+	if l == nil {
+		return nil
+	}
+	return l.Error
+}
+
 // GetBody returns the Body field if it's non-nil, zero value otherwise.
 func (l *License) GetBody() string {
 	if l == nil || l.Body == nil {
@@ -11774,6 +12006,30 @@ func (m *MilestoneEvent) GetSender() *User {
 	return m.Sender
 }
 
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (m *MilestoneSpec) GetDescription() string {
+	if m == nil || m.Description == nil {
+		return ""
+	}
+	return *m.Description
+}
+
+// GetDueOn returns the DueOn field if it's non-nil, zero value otherwise.
+func (m *MilestoneSpec) GetDueOn() Timestamp {
+	if m == nil || m.DueOn == nil {
+		return Timestamp{}
+	}
+	return *m.DueOn
+}
+
+// GetState returns the State field if it's non-nil, zero value otherwise.
+func (m *MilestoneSpec) GetState() string {
+	if m == nil || m.State == nil {
+		return ""
+	}
+	return *m.State
+}
+
 // GetClosedMilestones returns the ClosedMilestones field if it's non-nil, zero value otherwise.
 func (m *MilestoneStats) GetClosedMilestones() int {
 	if m == nil || m.ClosedMilestones == nil {
@@ -11798,6 +12054,22 @@ func (m *MilestoneStats) GetTotalMilestones() int {
 	return *m.TotalMilestones
 }
 
+// GetCurrent returns the Current field.
+func (m *MilestoneSyncChange) GetCurrent() *Milestone {
+	if m == nil {
+		return nil
+	}
+	return m.Current
+}
+
+// GetDesired returns the Desired field.
+func (m *MilestoneSyncChange) GetDesired() *Milestone {
+	if m == nil {
+		return nil
+	}
+	return m.Desired
+}
+
 // GetAnalysisKey returns the AnalysisKey field if it's non-nil, zero value otherwise.
 func (m *MostRecentInstance) GetAnalysisKey() string {
 	if m == nil || m.AnalysisKey == nil {
@@ -13942,6 +14214,78 @@ func (p *PagesUpdate) GetSource() *PagesSource {
 	return p.Source
 }
 
+// GetCurrentUserCanApprove returns the CurrentUserCanApprove field if it's non-nil, zero value otherwise.
+func (p *PendingDeployment) GetCurrentUserCanApprove() bool {
+	if p == nil || p.CurrentUserCanApprove == nil {
+		return false
+	}
+	return *p.CurrentUserCanApprove
+}
+
+// GetEnvironment returns the Environment field.
+func (p *PendingDeployment) GetEnvironment() *PendingDeploymentEnvironment {
+	if p == nil {
+		return nil
+	}
+	return p.Environment
+}
+
+// GetWaitTimer returns the WaitTimer field if it's non-nil, zero value otherwise.
+func (p *PendingDeployment) GetWaitTimer() int64 {
+	if p == nil || p.WaitTimer == nil {
+		return 0
+	}
+	return *p.WaitTimer
+}
+
+// GetWaitTimerStartedAt returns the WaitTimerStartedAt field if it's non-nil, zero value otherwise.
+func (p *PendingDeployment) GetWaitTimerStartedAt() Timestamp {
+	if p == nil || p.WaitTimerStartedAt == nil {
+		return Timestamp{}
+	}
+	return *p.WaitTimerStartedAt
+}
+
+// GetHTMLURL returns the HTMLURL field if it's non-nil, zero value otherwise.
+func (p *PendingDeploymentEnvironment) GetHTMLURL() string {
+	if p == nil || p.HTMLURL == nil {
+		return ""
+	}
+	return *p.HTMLURL
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (p *PendingDeploymentEnvironment) GetID() int64 {
+	if p == nil || p.ID == nil {
+		return 0
+	}
+	return *p.ID
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (p *PendingDeploymentEnvironment) GetName() string {
+	if p == nil || p.Name == nil {
+		return ""
+	}
+	return *p.Name
+}
+
+// GetNodeID returns the NodeID field if it's non-nil, zero value otherwise.
+func (p *PendingDeploymentEnvironment) GetNodeID() string {
+	if p == nil || p.NodeID == nil {
+		return ""
+	}
+	return *p.NodeID
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (p *PendingDeploymentEnvironment) GetURL() string {
+	if p == nil || p.URL == nil {
+		return ""
+	}
+	return *p.URL
+}
+
 // GetOrg returns the Org map if it's non-nil, an empty map otherwise.
 func (p *PersonalAccessTokenPermissions) GetOrg() map[string]string {
 	if p == nil || p.Org == nil {
@@ -15582,6 +15926,14 @@ func (p *ProtectionRule) GetWaitTimer() int {
 	return *p.WaitTimer
 }
 
+// GetErrors returns the Errors map if it's non-nil, an empty map otherwise.
+func (p *PruneCachesResult) GetErrors() map[int64]error {
+	if p == nil || p.Errors == nil {
+		return map[int64]error{}
+	}
+	return p.Errors
+}
+
 // GetInstallation returns the Installation field.
 func (p *PublicEvent) GetInstallation() *Installation {
 	if p == nil {
@@ -15630,6 +15982,14 @@ func (p *PublicKey) GetKeyID() string {
 	return *p.KeyID
 }
 
+// GetUploadOptions returns the UploadOptions field.
+func (p *PublishReleaseOptions) GetUploadOptions() *ReleaseAssetUploadOptions {
+	if p == nil {
+		return nil
+	}
+	return p.UploadOptions
+}
+
 // GetActiveLockReason returns the ActiveLockReason field if it's non-nil, zero value otherwise.
 func (p *PullRequest) GetActiveLockReason() string {
 	if p == nil || p.ActiveLockReason == nil {
@@ -17750,6 +18110,14 @@ func (r *RegistrationToken) GetToken() string {
 	return *r.Token
 }
 
+// GetErrors returns the Errors map if it's non-nil, an empty map otherwise.
+func (r *RelabelRunnersResult) GetErrors() map[int64]error {
+	if r == nil || r.Errors == nil {
+		return map[int64]error{}
+	}
+	return r.Errors
+}
+
 // GetBrowserDownloadURL returns the BrowserDownloadURL field if it's non-nil, zero value otherwise.
 func (r *ReleaseAsset) GetBrowserDownloadURL() string {
 	if r == nil || r.BrowserDownloadURL == nil {
@@ -17774,6 +18142,14 @@ func (r *ReleaseAsset) GetCreatedAt() Timestamp {
 	return *r.CreatedAt
 }
 
+// GetDigest returns the Digest field if it's non-nil, zero value otherwise.
+func (r *ReleaseAsset) GetDigest() string {
+	if r == nil || r.Digest == nil {
+		return ""
+	}
+	return *r.Digest
+}
+
 // GetDownloadCount returns the DownloadCount field if it's non-nil, zero value otherwise.
 func (r *ReleaseAsset) GetDownloadCount() int {
 	if r == nil || r.DownloadCount == nil {
@@ -19854,6 +20230,38 @@ func (r *RepositoryRule) GetParameters() json.RawMessage {
 	return *r.Parameters
 }
 
+// GetActionsPermissions returns the ActionsPermissions field.
+func (r *RepositorySettings) GetActionsPermissions() *ActionsPermissionsRepository {
+	if r == nil {
+		return nil
+	}
+	return r.ActionsPermissions
+}
+
+// GetCollaborators returns the Collaborators map if it's non-nil, an empty map otherwise.
+func (r *RepositorySettings) GetCollaborators() map[string]string {
+	if r == nil || r.Collaborators == nil {
+		return map[string]string{}
+	}
+	return r.Collaborators
+}
+
+// GetRepository returns the Repository field.
+func (r *RepositorySettings) GetRepository() *Repository {
+	if r == nil {
+		return nil
+	}
+	return r.Repository
+}
+
+// GetTeams returns the Teams map if it's non-nil, an empty map otherwise.
+func (r *RepositorySettings) GetTeams() map[string]string {
+	if r == nil || r.Teams == nil {
+		return map[string]string{}
+	}
+	return r.Teams
+}
+
 // GetCommit returns the Commit field.
 func (r *RepositoryTag) GetCommit() *Commit {
 	if r == nil {
@@ -20318,6 +20726,46 @@ func (r *Rule) GetSeverity() string {
 	return *r.Severity
 }
 
+// GetDetails returns the Details field if it's non-nil, zero value otherwise.
+func (r *RuleEvaluation) GetDetails() string {
+	if r == nil || r.Details == nil {
+		return ""
+	}
+	return *r.Details
+}
+
+// GetEnforcement returns the Enforcement field if it's non-nil, zero value otherwise.
+func (r *RuleEvaluation) GetEnforcement() string {
+	if r == nil || r.Enforcement == nil {
+		return ""
+	}
+	return *r.Enforcement
+}
+
+// GetResult returns the Result field if it's non-nil, zero value otherwise.
+func (r *RuleEvaluation) GetResult() string {
+	if r == nil || r.Result == nil {
+		return ""
+	}
+	return *r.Result
+}
+
+// GetRuleSource returns the RuleSource field.
+func (r *RuleEvaluation) GetRuleSource() *RuleSource {
+	if r == nil {
+		return nil
+	}
+	return r.RuleSource
+}
+
+// GetRuleType returns the RuleType field if it's non-nil, zero value otherwise.
+func (r *RuleEvaluation) GetRuleType() string {
+	if r == nil || r.RuleType == nil {
+		return ""
+	}
+	return *r.RuleType
+}
+
 // GetName returns the Name field if it's non-nil, zero value otherwise.
 func (r *RulePatternParameters) GetName() string {
 	if r == nil || r.Name == nil {
@@ -20414,6 +20862,14 @@ func (r *RulesetConditions) GetRepositoryName() *RulesetRepositoryNamesCondition
 	return r.RepositoryName
 }
 
+// GetRepositoryProperty returns the RepositoryProperty field.
+func (r *RulesetConditions) GetRepositoryProperty() *RulesetRepositoryPropertyConditionParameters {
+	if r == nil {
+		return nil
+	}
+	return r.RepositoryProperty
+}
+
 // GetHRef returns the HRef field if it's non-nil, zero value otherwise.
 func (r *RulesetLink) GetHRef() string {
 	if r == nil || r.HRef == nil {
@@ -20430,6 +20886,22 @@ func (r *RulesetLinks) GetSelf() *RulesetLink {
 	return r.Self
 }
 
+// GetProtection returns the Protection field.
+func (r *RulesetMigration) GetProtection() *Protection {
+	if r == nil {
+		return nil
+	}
+	return r.Protection
+}
+
+// GetRuleset returns the Ruleset field.
+func (r *RulesetMigration) GetRuleset() *Ruleset {
+	if r == nil {
+		return nil
+	}
+	return r.Ruleset
+}
+
 // GetProtected returns the Protected field if it's non-nil, zero value otherwise.
 func (r *RulesetRepositoryNamesConditionParameters) GetProtected() bool {
 	if r == nil || r.Protected == nil {
@@ -20438,6 +20910,142 @@ func (r *RulesetRepositoryNamesConditionParameters) GetProtected() bool {
 	return *r.Protected
 }
 
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (r *RuleSource) GetID() int64 {
+	if r == nil || r.ID == nil {
+		return 0
+	}
+	return *r.ID
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (r *RuleSource) GetName() string {
+	if r == nil || r.Name == nil {
+		return ""
+	}
+	return *r.Name
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (r *RuleSource) GetType() string {
+	if r == nil || r.Type == nil {
+		return ""
+	}
+	return *r.Type
+}
+
+// GetActorID returns the ActorID field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetActorID() int64 {
+	if r == nil || r.ActorID == nil {
+		return 0
+	}
+	return *r.ActorID
+}
+
+// GetActorName returns the ActorName field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetActorName() string {
+	if r == nil || r.ActorName == nil {
+		return ""
+	}
+	return *r.ActorName
+}
+
+// GetAfterSHA returns the AfterSHA field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetAfterSHA() string {
+	if r == nil || r.AfterSHA == nil {
+		return ""
+	}
+	return *r.AfterSHA
+}
+
+// GetBeforeSHA returns the BeforeSHA field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetBeforeSHA() string {
+	if r == nil || r.BeforeSHA == nil {
+		return ""
+	}
+	return *r.BeforeSHA
+}
+
+// GetEvaluationResult returns the EvaluationResult field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetEvaluationResult() string {
+	if r == nil || r.EvaluationResult == nil {
+		return ""
+	}
+	return *r.EvaluationResult
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetID() int64 {
+	if r == nil || r.ID == nil {
+		return 0
+	}
+	return *r.ID
+}
+
+// GetPushedAt returns the PushedAt field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetPushedAt() Timestamp {
+	if r == nil || r.PushedAt == nil {
+		return Timestamp{}
+	}
+	return *r.PushedAt
+}
+
+// GetRef returns the Ref field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetRef() string {
+	if r == nil || r.Ref == nil {
+		return ""
+	}
+	return *r.Ref
+}
+
+// GetRepositoryID returns the RepositoryID field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetRepositoryID() int64 {
+	if r == nil || r.RepositoryID == nil {
+		return 0
+	}
+	return *r.RepositoryID
+}
+
+// GetRepositoryName returns the RepositoryName field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetRepositoryName() string {
+	if r == nil || r.RepositoryName == nil {
+		return ""
+	}
+	return *r.RepositoryName
+}
+
+// GetResult returns the Result field if it's non-nil, zero value otherwise.
+func (r *RuleSuite) GetResult() string {
+	if r == nil || r.Result == nil {
+		return ""
+	}
+	return *r.Result
+}
+
+// GetCommit returns the Commit field.
+func (r *RuleViolation) GetCommit() *Commit {
+	if r == nil {
+		return nil
+	}
+	return r.Commit
+}
+
+// GetRule returns the Rule field.
+func (r *RuleViolation) GetRule() *RepositoryRule {
+	if r == nil {
+		return nil
+	}
+	return r.Rule
+}
+
+// GetRuleset returns the Ruleset field.
+func (r *RuleViolation) GetRuleset() *Ruleset {
+	if r == nil {
+		return nil
+	}
+	return r.Ruleset
+}
+
 // GetBusy returns the Busy field if it's non-nil, zero value otherwise.
 func (r *Runner) GetBusy() bool {
 	if r == nil || r.Busy == nil {
@@ -20630,6 +21238,22 @@ func (r *RunnerLabels) GetType() string {
 	return *r.Type
 }
 
+// GetRunner returns the Runner field.
+func (r *RunnerRelabel) GetRunner() *Runner {
+	if r == nil {
+		return nil
+	}
+	return r.Runner
+}
+
+// GetBusy returns the Busy field if it's non-nil, zero value otherwise.
+func (r *RunnerSelector) GetBusy() bool {
+	if r == nil || r.Busy == nil {
+		return false
+	}
+	return *r.Busy
+}
+
 // GetCheckoutURI returns the CheckoutURI field if it's non-nil, zero value otherwise.
 func (s *SarifAnalysis) GetCheckoutURI() string {
 	if s == nil || s.CheckoutURI == nil {
@@ -22062,6 +22686,46 @@ func (s *Subscription) GetURL() string {
 	return *s.URL
 }
 
+// GetAuthor returns the Author field.
+func (s *SyncDirectoryOptions) GetAuthor() *CommitAuthor {
+	if s == nil {
+		return nil
+	}
+	return s.Author
+}
+
+// GetCommitOptions returns the CommitOptions field.
+func (s *SyncDirectoryOptions) GetCommitOptions() *CreateCommitOptions {
+	if s == nil {
+		return nil
+	}
+	return s.CommitOptions
+}
+
+// GetCommitter returns the Committer field.
+func (s *SyncDirectoryOptions) GetCommitter() *CommitAuthor {
+	if s == nil {
+		return nil
+	}
+	return s.Committer
+}
+
+// GetCommit returns the Commit field.
+func (s *SyncDirectoryResult) GetCommit() *Commit {
+	if s == nil {
+		return nil
+	}
+	return s.Commit
+}
+
+// GetPullRequest returns the PullRequest field.
+func (s *SyncDirectoryResult) GetPullRequest() *PullRequest {
+	if s == nil {
+		return nil
+	}
+	return s.PullRequest
+}
+
 // GetMessage returns the Message field if it's non-nil, zero value otherwise.
 func (t *Tag) GetMessage() string {
 	if t == nil || t.Message == nil {
@@ -24710,6 +25374,22 @@ func (w *WorkflowJobEvent) GetWorkflowJob() *WorkflowJob {
 	return w.WorkflowJob
 }
 
+// GetJob returns the Job field.
+func (w *WorkflowJobLog) GetJob() *WorkflowJob {
+	if w == nil {
+		return nil
+	}
+	return w.Job
+}
+
+// GetProperties returns the Properties map if it's non-nil, an empty map otherwise.
+func (w *WorkflowLogLine) GetProperties() map[string]string {
+	if w == nil || w.Properties == nil {
+		return map[string]string{}
+	}
+	return w.Properties
+}
+
 // GetActor returns the Actor field.
 func (w *WorkflowRun) GetActor() *User {
 	if w == nil {
@@ -25062,6 +25742,14 @@ func (w *WorkflowRunJobRun) GetJobID() int {
 	return *w.JobID
 }
 
+// GetRun returns the Run field.
+func (w *WorkflowRunResult) GetRun() *WorkflowRun {
+	if w == nil {
+		return nil
+	}
+	return w.Run
+}
+
 // GetTotalCount returns the TotalCount field if it's non-nil, zero value otherwise.
 func (w *WorkflowRuns) GetTotalCount() int {
 	if w == nil || w.TotalCount == nil {
@@ -25070,6 +25758,22 @@ func (w *WorkflowRuns) GetTotalCount() int {
 	return *w.TotalCount
 }
 
+// GetJob returns the Job field.
+func (w *WorkflowRunStatusChange) GetJob() *WorkflowJob {
+	if w == nil {
+		return nil
+	}
+	return w.Job
+}
+
+// GetRun returns the Run field.
+func (w *WorkflowRunStatusChange) GetRun() *WorkflowRun {
+	if w == nil {
+		return nil
+	}
+	return w.Run
+}
+
 // GetBillable returns the Billable field.
 func (w *WorkflowRunUsage) GetBillable() *WorkflowRunBillMap {
 	if w == nil {
@@ -25094,6 +25798,14 @@ func (w *Workflows) GetTotalCount() int {
 	return *w.TotalCount
 }
 
+// GetStep returns the Step field.
+func (w *WorkflowStepLog) GetStep() *TaskStep {
+	if w == nil {
+		return nil
+	}
+	return w.Step
+}
+
 // GetBillable returns the Billable field.
 func (w *WorkflowUsage) GetBillable() *WorkflowBillMap {
 	if w == nil {
@@ -155,6 +155,16 @@ func TestActionsCacheListOptions_GetSort(tt *testing.T) {
 	a.GetSort()
 }
 
+func TestActionsCacheUsagePolicy_GetRepoCacheSizeLimitInGB(tt *testing.T) {
+	var zeroValue int
+	a := &ActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: &zeroValue}
+	a.GetRepoCacheSizeLimitInGB()
+	a = &ActionsCacheUsagePolicy{}
+	a.GetRepoCacheSizeLimitInGB()
+	a = nil
+	a.GetRepoCacheSizeLimitInGB()
+}
+
 func TestActionsPermissions_GetAllowedActions(tt *testing.T) {
 	var zeroValue string
 	a := &ActionsPermissions{AllowedActions: &zeroValue}
@@ -5695,6 +5705,13 @@ func TestCreateRunnerGroupRequest_GetVisibility(tt *testing.T) {
 	c.GetVisibility()
 }
 
+func TestCreateTagWithRefOptions_GetTagger(tt *testing.T) {
+	c := &CreateTagWithRefOptions{}
+	c.GetTagger()
+	c = nil
+	c.GetTagger()
+}
+
 func TestCreateUpdateEnvironment_GetCanAdminsBypass(tt *testing.T) {
 	var zeroValue bool
 	c := &CreateUpdateEnvironment{CanAdminsBypass: &zeroValue}
@@ -5899,6 +5916,46 @@ func TestCredentialAuthorization_GetTokenLastEight(tt *testing.T) {
 	c.GetTokenLastEight()
 }
 
+func TestCustomProperty_GetDefaultValue(tt *testing.T) {
+	var zeroValue string
+	c := &CustomProperty{DefaultValue: &zeroValue}
+	c.GetDefaultValue()
+	c = &CustomProperty{}
+	c.GetDefaultValue()
+	c = nil
+	c.GetDefaultValue()
+}
+
+func TestCustomProperty_GetDescription(tt *testing.T) {
+	var zeroValue string
+	c := &CustomProperty{Description: &zeroValue}
+	c.GetDescription()
+	c = &CustomProperty{}
+	c.GetDescription()
+	c = nil
+	c.GetDescription()
+}
+
+func TestCustomProperty_GetPropertyName(tt *testing.T) {
+	var zeroValue string
+	c := &CustomProperty{PropertyName: &zeroValue}
+	c.GetPropertyName()
+	c = &CustomProperty{}
+	c.GetPropertyName()
+	c = nil
+	c.GetPropertyName()
+}
+
+func TestCustomProperty_GetRequired(tt *testing.T) {
+	var zeroValue bool
+	c := &CustomProperty{Required: &zeroValue}
+	c.GetRequired()
+	c = &CustomProperty{}
+	c.GetRequired()
+	c = nil
+	c.GetRequired()
+}
+
 func TestCustomRepoRoles_GetBaseRole(tt *testing.T) {
 	var zeroValue string
 	c := &CustomRepoRoles{BaseRole: &zeroValue}
@@ -7921,6 +7978,26 @@ func TestEnterprise_GetWebsiteURL(tt *testing.T) {
 	e.GetWebsiteURL()
 }
 
+func TestEnterpriseActionsCacheUsagePolicy_GetMaxRepoCacheSizeLimitInGB(tt *testing.T) {
+	var zeroValue int
+	e := &EnterpriseActionsCacheUsagePolicy{MaxRepoCacheSizeLimitInGB: &zeroValue}
+	e.GetMaxRepoCacheSizeLimitInGB()
+	e = &EnterpriseActionsCacheUsagePolicy{}
+	e.GetMaxRepoCacheSizeLimitInGB()
+	e = nil
+	e.GetMaxRepoCacheSizeLimitInGB()
+}
+
+func TestEnterpriseActionsCacheUsagePolicy_GetRepoCacheSizeLimitInGB(tt *testing.T) {
+	var zeroValue int
+	e := &EnterpriseActionsCacheUsagePolicy{RepoCacheSizeLimitInGB: &zeroValue}
+	e.GetRepoCacheSizeLimitInGB()
+	e = &EnterpriseActionsCacheUsagePolicy{}
+	e.GetRepoCacheSizeLimitInGB()
+	e = nil
+	e.GetRepoCacheSizeLimitInGB()
+}
+
 func TestEnterpriseRunnerGroup_GetAllowsPublicRepositories(tt *testing.T) {
 	var zeroValue bool
 	e := &EnterpriseRunnerGroup{AllowsPublicRepositories: &zeroValue}
@@ -8198,6 +8275,33 @@ func TestEnvironment_GetWaitTimer(tt *testing.T) {
 	e.GetWaitTimer()
 }
 
+func TestEnvironmentApproval_GetComment(tt *testing.T) {
+	var zeroValue string
+	e := &EnvironmentApproval{Comment: &zeroValue}
+	e.GetComment()
+	e = &EnvironmentApproval{}
+	e.GetComment()
+	e = nil
+	e.GetComment()
+}
+
+func TestEnvironmentApproval_GetState(tt *testing.T) {
+	var zeroValue string
+	e := &EnvironmentApproval{State: &zeroValue}
+	e.GetState()
+	e = &EnvironmentApproval{}
+	e.GetState()
+	e = nil
+	e.GetState()
+}
+
+func TestEnvironmentApproval_GetUser(tt *testing.T) {
+	e := &EnvironmentApproval{}
+	e.GetUser()
+	e = nil
+	e.GetUser()
+}
+
 func TestEnvResponse_GetTotalCount(tt *testing.T) {
 	var zeroValue int
 	e := &EnvResponse{TotalCount: &zeroValue}
@@ -9072,6 +9176,50 @@ func TestGitObject_GetURL(tt *testing.T) {
 	g.GetURL()
 }
 
+func TestGitSignature_GetGPGKey(tt *testing.T) {
+	g := &GitSignature{}
+	g.GetGPGKey()
+	g = nil
+	g.GetGPGKey()
+}
+
+func TestGitSignature_GetKeyExpiresAt(tt *testing.T) {
+	var zeroValue Timestamp
+	g := &GitSignature{KeyExpiresAt: &zeroValue}
+	g.GetKeyExpiresAt()
+	g = &GitSignature{}
+	g.GetKeyExpiresAt()
+	g = nil
+	g.GetKeyExpiresAt()
+}
+
+func TestGitSignature_GetKeyID(tt *testing.T) {
+	var zeroValue string
+	g := &GitSignature{KeyID: &zeroValue}
+	g.GetKeyID()
+	g = &GitSignature{}
+	g.GetKeyID()
+	g = nil
+	g.GetKeyID()
+}
+
+func TestGitSignature_GetSignedAt(tt *testing.T) {
+	var zeroValue Timestamp
+	g := &GitSignature{SignedAt: &zeroValue}
+	g.GetSignedAt()
+	g = &GitSignature{}
+	g.GetSignedAt()
+	g = nil
+	g.GetSignedAt()
+}
+
+func TestGitSignature_GetSSHSigningKey(tt *testing.T) {
+	g := &GitSignature{}
+	g.GetSSHSigningKey()
+	g = nil
+	g.GetSSHSigningKey()
+}
+
 func TestGollumEvent_GetInstallation(tt *testing.T) {
 	g := &GollumEvent{}
 	g.GetInstallation()
@@ -12172,6 +12320,16 @@ func TestLabelResult_GetURL(tt *testing.T) {
 	l.GetURL()
 }
 
+func TestLabelSpec_GetDescription(tt *testing.T) {
+	var zeroValue string
+	l := &LabelSpec{Description: &zeroValue}
+	l.GetDescription()
+	l = &LabelSpec{}
+	l.GetDescription()
+	l = nil
+	l.GetDescription()
+}
+
 func TestLabelsSearchResult_GetIncompleteResults(tt *testing.T) {
 	var zeroValue bool
 	l := &LabelsSearchResult{IncompleteResults: &zeroValue}
@@ -12192,6 +12350,27 @@ func TestLabelsSearchResult_GetTotal(tt *testing.T) {
 	l.GetTotal()
 }
 
+func TestLabelSyncChange_GetCurrent(tt *testing.T) {
+	l := &LabelSyncChange{}
+	l.GetCurrent()
+	l = nil
+	l.GetCurrent()
+}
+
+func TestLabelSyncChange_GetDesired(tt *testing.T) {
+	l := &LabelSyncChange{}
+	l.GetDesired()
+	l = nil
+	l.GetDesired()
+}
+
+func TestLabelSyncResult_GetPlan(tt *testing.T) {
+	l := &LabelSyncResult{}
+	l.GetPlan()
+	l = nil
+	l.GetPlan()
+}
+
 func TestLargeFile_GetOID(tt *testing.T) {
 	var zeroValue string
 	l := &LargeFile{OID: &zeroValue}
@@ -12232,6 +12411,90 @@ func TestLargeFile_GetSize(tt *testing.T) {
 	l.GetSize()
 }
 
+func TestLFSAction_GetExpiresAt(tt *testing.T) {
+	var zeroValue Timestamp
+	l := &LFSAction{ExpiresAt: &zeroValue}
+	l.GetExpiresAt()
+	l = &LFSAction{}
+	l.GetExpiresAt()
+	l = nil
+	l.GetExpiresAt()
+}
+
+func TestLFSAction_GetExpiresIn(tt *testing.T) {
+	var zeroValue int64
+	l := &LFSAction{ExpiresIn: &zeroValue}
+	l.GetExpiresIn()
+	l = &LFSAction{}
+	l.GetExpiresIn()
+	l = nil
+	l.GetExpiresIn()
+}
+
+func TestLFSAction_GetHeader(tt *testing.T) {
+	zeroValue := map[string]string{}
+	l := &LFSAction{Header: zeroValue}
+	l.GetHeader()
+	l = &LFSAction{}
+	l.GetHeader()
+	l = nil
+	l.GetHeader()
+}
+
+func TestLFSBatchRequest_GetHashAlgo(tt *testing.T) {
+	var zeroValue string
+	l := &LFSBatchRequest{HashAlgo: &zeroValue}
+	l.GetHashAlgo()
+	l = &LFSBatchRequest{}
+	l.GetHashAlgo()
+	l = nil
+	l.GetHashAlgo()
+}
+
+func TestLFSBatchRequest_GetRef(tt *testing.T) {
+	l := &LFSBatchRequest{}
+	l.GetRef()
+	l = nil
+	l.GetRef()
+}
+
+func TestLFSBatchResponse_GetHashAlgo(tt *testing.T) {
+	var zeroValue string
+	l := &LFSBatchResponse{HashAlgo: &zeroValue}
+	l.GetHashAlgo()
+	l = &LFSBatchResponse{}
+	l.GetHashAlgo()
+	l = nil
+	l.GetHashAlgo()
+}
+
+func TestLFSBatchResponse_GetTransfer(tt *testing.T) {
+	var zeroValue string
+	l := &LFSBatchResponse{Transfer: &zeroValue}
+	l.GetTransfer()
+	l = &LFSBatchResponse{}
+	l.GetTransfer()
+	l = nil
+	l.GetTransfer()
+}
+
+func TestLFSObject_GetAuthenticated(tt *testing.T) {
+	var zeroValue bool
+	l := &LFSObject{Authenticated: &zeroValue}
+	l.GetAuthenticated()
+	l = &LFSObject{}
+	l.GetAuthenticated()
+	l = nil
+	l.GetAuthenticated()
+}
+
+func TestLFSObject_GetError(tt *testing.T) {
+	l := &LFSObject{}
+	l.GetError()
+	l = nil
+	l.GetError()
+}
+
 func TestLicense_GetBody(tt *testing.T) {
 	var zeroValue string
 	l := &License{Body: &zeroValue}
@@ -13752,6 +14015,36 @@ func TestMilestoneEvent_GetSender(tt *testing.T) {
 	m.GetSender()
 }
 
+func TestMilestoneSpec_GetDescription(tt *testing.T) {
+	var zeroValue string
+	m := &MilestoneSpec{Description: &zeroValue}
+	m.GetDescription()
+	m = &MilestoneSpec{}
+	m.GetDescription()
+	m = nil
+	m.GetDescription()
+}
+
+func TestMilestoneSpec_GetDueOn(tt *testing.T) {
+	var zeroValue Timestamp
+	m := &MilestoneSpec{DueOn: &zeroValue}
+	m.GetDueOn()
+	m = &MilestoneSpec{}
+	m.GetDueOn()
+	m = nil
+	m.GetDueOn()
+}
+
+func TestMilestoneSpec_GetState(tt *testing.T) {
+	var zeroValue string
+	m := &MilestoneSpec{State: &zeroValue}
+	m.GetState()
+	m = &MilestoneSpec{}
+	m.GetState()
+	m = nil
+	m.GetState()
+}
+
 func TestMilestoneStats_GetClosedMilestones(tt *testing.T) {
 	var zeroValue int
 	m := &MilestoneStats{ClosedMilestones: &zeroValue}
@@ -13782,6 +14075,20 @@ func TestMilestoneStats_GetTotalMilestones(tt *testing.T) {
 	m.GetTotalMilestones()
 }
 
+func TestMilestoneSyncChange_GetCurrent(tt *testing.T) {
+	m := &MilestoneSyncChange{}
+	m.GetCurrent()
+	m = nil
+	m.GetCurrent()
+}
+
+func TestMilestoneSyncChange_GetDesired(tt *testing.T) {
+	m := &MilestoneSyncChange{}
+	m.GetDesired()
+	m = nil
+	m.GetDesired()
+}
+
 func TestMostRecentInstance_GetAnalysisKey(tt *testing.T) {
 	var zeroValue string
 	m := &MostRecentInstance{AnalysisKey: &zeroValue}
@@ -16330,6 +16637,93 @@ func TestPagesUpdate_GetSource(tt *testing.T) {
 	p.GetSource()
 }
 
+func TestPendingDeployment_GetCurrentUserCanApprove(tt *testing.T) {
+	var zeroValue bool
+	p := &PendingDeployment{CurrentUserCanApprove: &zeroValue}
+	p.GetCurrentUserCanApprove()
+	p = &PendingDeployment{}
+	p.GetCurrentUserCanApprove()
+	p = nil
+	p.GetCurrentUserCanApprove()
+}
+
+func TestPendingDeployment_GetEnvironment(tt *testing.T) {
+	p := &PendingDeployment{}
+	p.GetEnvironment()
+	p = nil
+	p.GetEnvironment()
+}
+
+func TestPendingDeployment_GetWaitTimer(tt *testing.T) {
+	var zeroValue int64
+	p := &PendingDeployment{WaitTimer: &zeroValue}
+	p.GetWaitTimer()
+	p = &PendingDeployment{}
+	p.GetWaitTimer()
+	p = nil
+	p.GetWaitTimer()
+}
+
+func TestPendingDeployment_GetWaitTimerStartedAt(tt *testing.T) {
+	var zeroValue Timestamp
+	p := &PendingDeployment{WaitTimerStartedAt: &zeroValue}
+	p.GetWaitTimerStartedAt()
+	p = &PendingDeployment{}
+	p.GetWaitTimerStartedAt()
+	p = nil
+	p.GetWaitTimerStartedAt()
+}
+
+func TestPendingDeploymentEnvironment_GetHTMLURL(tt *testing.T) {
+	var zeroValue string
+	p := &PendingDeploymentEnvironment{HTMLURL: &zeroValue}
+	p.GetHTMLURL()
+	p = &PendingDeploymentEnvironment{}
+	p.GetHTMLURL()
+	p = nil
+	p.GetHTMLURL()
+}
+
+func TestPendingDeploymentEnvironment_GetID(tt *testing.T) {
+	var zeroValue int64
+	p := &PendingDeploymentEnvironment{ID: &zeroValue}
+	p.GetID()
+	p = &PendingDeploymentEnvironment{}
+	p.GetID()
+	p = nil
+	p.GetID()
+}
+
+func TestPendingDeploymentEnvironment_GetName(tt *testing.T) {
+	var zeroValue string
+	p := &PendingDeploymentEnvironment{Name: &zeroValue}
+	p.GetName()
+	p = &PendingDeploymentEnvironment{}
+	p.GetName()
+	p = nil
+	p.GetName()
+}
+
+func TestPendingDeploymentEnvironment_GetNodeID(tt *testing.T) {
+	var zeroValue string
+	p := &PendingDeploymentEnvironment{NodeID: &zeroValue}
+	p.GetNodeID()
+	p = &PendingDeploymentEnvironment{}
+	p.GetNodeID()
+	p = nil
+	p.GetNodeID()
+}
+
+func TestPendingDeploymentEnvironment_GetURL(tt *testing.T) {
+	var zeroValue string
+	p := &PendingDeploymentEnvironment{URL: &zeroValue}
+	p.GetURL()
+	p = &PendingDeploymentEnvironment{}
+	p.GetURL()
+	p = nil
+	p.GetURL()
+}
+
 func TestPersonalAccessTokenPermissions_GetOrg(tt *testing.T) {
 	zeroValue := map[string]string{}
 	p := &PersonalAccessTokenPermissions{Org: zeroValue}
@@ -18107,6 +18501,16 @@ func TestProtectionRule_GetWaitTimer(tt *testing.T) {
 	p.GetWaitTimer()
 }
 
+func TestPruneCachesResult_GetErrors(tt *testing.T) {
+	zeroValue := map[int64]error{}
+	p := &PruneCachesResult{Errors: zeroValue}
+	p.GetErrors()
+	p = &PruneCachesResult{}
+	p.GetErrors()
+	p = nil
+	p.GetErrors()
+}
+
 func TestPublicEvent_GetInstallation(tt *testing.T) {
 	p := &PublicEvent{}
 	p.GetInstallation()
@@ -18155,6 +18559,13 @@ func TestPublicKey_GetKeyID(tt *testing.T) {
 	p.GetKeyID()
 }
 
+func TestPublishReleaseOptions_GetUploadOptions(tt *testing.T) {
+	p := &PublishReleaseOptions{}
+	p.GetUploadOptions()
+	p = nil
+	p.GetUploadOptions()
+}
+
 func TestPullRequest_GetActiveLockReason(tt *testing.T) {
 	var zeroValue string
 	p := &PullRequest{ActiveLockReason: &zeroValue}
@@ -20571,6 +20982,16 @@ func TestRegistrationToken_GetToken(tt *testing.T) {
 	r.GetToken()
 }
 
+func TestRelabelRunnersResult_GetErrors(tt *testing.T) {
+	zeroValue := map[int64]error{}
+	r := &RelabelRunnersResult{Errors: zeroValue}
+	r.GetErrors()
+	r = &RelabelRunnersResult{}
+	r.GetErrors()
+	r = nil
+	r.GetErrors()
+}
+
 func TestReleaseAsset_GetBrowserDownloadURL(tt *testing.T) {
 	var zeroValue string
 	r := &ReleaseAsset{BrowserDownloadURL: &zeroValue}
@@ -20601,6 +21022,16 @@ func TestReleaseAsset_GetCreatedAt(tt *testing.T) {
 	r.GetCreatedAt()
 }
 
+func TestReleaseAsset_GetDigest(tt *testing.T) {
+	var zeroValue string
+	r := &ReleaseAsset{Digest: &zeroValue}
+	r.GetDigest()
+	r = &ReleaseAsset{}
+	r.GetDigest()
+	r = nil
+	r.GetDigest()
+}
+
 func TestReleaseAsset_GetDownloadCount(tt *testing.T) {
 	var zeroValue int
 	r := &ReleaseAsset{DownloadCount: &zeroValue}
@@ -23072,6 +23503,40 @@ func TestRepositoryRule_GetParameters(tt *testing.T) {
 	r.GetParameters()
 }
 
+func TestRepositorySettings_GetActionsPermissions(tt *testing.T) {
+	r := &RepositorySettings{}
+	r.GetActionsPermissions()
+	r = nil
+	r.GetActionsPermissions()
+}
+
+func TestRepositorySettings_GetCollaborators(tt *testing.T) {
+	zeroValue := map[string]string{}
+	r := &RepositorySettings{Collaborators: zeroValue}
+	r.GetCollaborators()
+	r = &RepositorySettings{}
+	r.GetCollaborators()
+	r = nil
+	r.GetCollaborators()
+}
+
+func TestRepositorySettings_GetRepository(tt *testing.T) {
+	r := &RepositorySettings{}
+	r.GetRepository()
+	r = nil
+	r.GetRepository()
+}
+
+func TestRepositorySettings_GetTeams(tt *testing.T) {
+	zeroValue := map[string]string{}
+	r := &RepositorySettings{Teams: zeroValue}
+	r.GetTeams()
+	r = &RepositorySettings{}
+	r.GetTeams()
+	r = nil
+	r.GetTeams()
+}
+
 func TestRepositoryTag_GetCommit(tt *testing.T) {
 	r := &RepositoryTag{}
 	r.GetCommit()
@@ -23628,6 +24093,53 @@ func TestRule_GetSeverity(tt *testing.T) {
 	r.GetSeverity()
 }
 
+func TestRuleEvaluation_GetDetails(tt *testing.T) {
+	var zeroValue string
+	r := &RuleEvaluation{Details: &zeroValue}
+	r.GetDetails()
+	r = &RuleEvaluation{}
+	r.GetDetails()
+	r = nil
+	r.GetDetails()
+}
+
+func TestRuleEvaluation_GetEnforcement(tt *testing.T) {
+	var zeroValue string
+	r := &RuleEvaluation{Enforcement: &zeroValue}
+	r.GetEnforcement()
+	r = &RuleEvaluation{}
+	r.GetEnforcement()
+	r = nil
+	r.GetEnforcement()
+}
+
+func TestRuleEvaluation_GetResult(tt *testing.T) {
+	var zeroValue string
+	r := &RuleEvaluation{Result: &zeroValue}
+	r.GetResult()
+	r = &RuleEvaluation{}
+	r.GetResult()
+	r = nil
+	r.GetResult()
+}
+
+func TestRuleEvaluation_GetRuleSource(tt *testing.T) {
+	r := &RuleEvaluation{}
+	r.GetRuleSource()
+	r = nil
+	r.GetRuleSource()
+}
+
+func TestRuleEvaluation_GetRuleType(tt *testing.T) {
+	var zeroValue string
+	r := &RuleEvaluation{RuleType: &zeroValue}
+	r.GetRuleType()
+	r = &RuleEvaluation{}
+	r.GetRuleType()
+	r = nil
+	r.GetRuleType()
+}
+
 func TestRulePatternParameters_GetName(tt *testing.T) {
 	var zeroValue string
 	r := &RulePatternParameters{Name: &zeroValue}
@@ -23733,6 +24245,13 @@ func TestRulesetConditions_GetRepositoryName(tt *testing.T) {
 	r.GetRepositoryName()
 }
 
+func TestRulesetConditions_GetRepositoryProperty(tt *testing.T) {
+	r := &RulesetConditions{}
+	r.GetRepositoryProperty()
+	r = nil
+	r.GetRepositoryProperty()
+}
+
 func TestRulesetLink_GetHRef(tt *testing.T) {
 	var zeroValue string
 	r := &RulesetLink{HRef: &zeroValue}
@@ -23750,6 +24269,20 @@ func TestRulesetLinks_GetSelf(tt *testing.T) {
 	r.GetSelf()
 }
 
+func TestRulesetMigration_GetProtection(tt *testing.T) {
+	r := &RulesetMigration{}
+	r.GetProtection()
+	r = nil
+	r.GetProtection()
+}
+
+func TestRulesetMigration_GetRuleset(tt *testing.T) {
+	r := &RulesetMigration{}
+	r.GetRuleset()
+	r = nil
+	r.GetRuleset()
+}
+
 func TestRulesetRepositoryNamesConditionParameters_GetProtected(tt *testing.T) {
 	var zeroValue bool
 	r := &RulesetRepositoryNamesConditionParameters{Protected: &zeroValue}
@@ -23760,6 +24293,167 @@ func TestRulesetRepositoryNamesConditionParameters_GetProtected(tt *testing.T) {
 	r.GetProtected()
 }
 
+func TestRuleSource_GetID(tt *testing.T) {
+	var zeroValue int64
+	r := &RuleSource{ID: &zeroValue}
+	r.GetID()
+	r = &RuleSource{}
+	r.GetID()
+	r = nil
+	r.GetID()
+}
+
+func TestRuleSource_GetName(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSource{Name: &zeroValue}
+	r.GetName()
+	r = &RuleSource{}
+	r.GetName()
+	r = nil
+	r.GetName()
+}
+
+func TestRuleSource_GetType(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSource{Type: &zeroValue}
+	r.GetType()
+	r = &RuleSource{}
+	r.GetType()
+	r = nil
+	r.GetType()
+}
+
+func TestRuleSuite_GetActorID(tt *testing.T) {
+	var zeroValue int64
+	r := &RuleSuite{ActorID: &zeroValue}
+	r.GetActorID()
+	r = &RuleSuite{}
+	r.GetActorID()
+	r = nil
+	r.GetActorID()
+}
+
+func TestRuleSuite_GetActorName(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{ActorName: &zeroValue}
+	r.GetActorName()
+	r = &RuleSuite{}
+	r.GetActorName()
+	r = nil
+	r.GetActorName()
+}
+
+func TestRuleSuite_GetAfterSHA(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{AfterSHA: &zeroValue}
+	r.GetAfterSHA()
+	r = &RuleSuite{}
+	r.GetAfterSHA()
+	r = nil
+	r.GetAfterSHA()
+}
+
+func TestRuleSuite_GetBeforeSHA(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{BeforeSHA: &zeroValue}
+	r.GetBeforeSHA()
+	r = &RuleSuite{}
+	r.GetBeforeSHA()
+	r = nil
+	r.GetBeforeSHA()
+}
+
+func TestRuleSuite_GetEvaluationResult(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{EvaluationResult: &zeroValue}
+	r.GetEvaluationResult()
+	r = &RuleSuite{}
+	r.GetEvaluationResult()
+	r = nil
+	r.GetEvaluationResult()
+}
+
+func TestRuleSuite_GetID(tt *testing.T) {
+	var zeroValue int64
+	r := &RuleSuite{ID: &zeroValue}
+	r.GetID()
+	r = &RuleSuite{}
+	r.GetID()
+	r = nil
+	r.GetID()
+}
+
+func TestRuleSuite_GetPushedAt(tt *testing.T) {
+	var zeroValue Timestamp
+	r := &RuleSuite{PushedAt: &zeroValue}
+	r.GetPushedAt()
+	r = &RuleSuite{}
+	r.GetPushedAt()
+	r = nil
+	r.GetPushedAt()
+}
+
+func TestRuleSuite_GetRef(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{Ref: &zeroValue}
+	r.GetRef()
+	r = &RuleSuite{}
+	r.GetRef()
+	r = nil
+	r.GetRef()
+}
+
+func TestRuleSuite_GetRepositoryID(tt *testing.T) {
+	var zeroValue int64
+	r := &RuleSuite{RepositoryID: &zeroValue}
+	r.GetRepositoryID()
+	r = &RuleSuite{}
+	r.GetRepositoryID()
+	r = nil
+	r.GetRepositoryID()
+}
+
+func TestRuleSuite_GetRepositoryName(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{RepositoryName: &zeroValue}
+	r.GetRepositoryName()
+	r = &RuleSuite{}
+	r.GetRepositoryName()
+	r = nil
+	r.GetRepositoryName()
+}
+
+func TestRuleSuite_GetResult(tt *testing.T) {
+	var zeroValue string
+	r := &RuleSuite{Result: &zeroValue}
+	r.GetResult()
+	r = &RuleSuite{}
+	r.GetResult()
+	r = nil
+	r.GetResult()
+}
+
+func TestRuleViolation_GetCommit(tt *testing.T) {
+	r := &RuleViolation{}
+	r.GetCommit()
+	r = nil
+	r.GetCommit()
+}
+
+func TestRuleViolation_GetRule(tt *testing.T) {
+	r := &RuleViolation{}
+	r.GetRule()
+	r = nil
+	r.GetRule()
+}
+
+func TestRuleViolation_GetRuleset(tt *testing.T) {
+	r := &RuleViolation{}
+	r.GetRuleset()
+	r = nil
+	r.GetRuleset()
+}
+
 func TestRunner_GetBusy(tt *testing.T) {
 	var zeroValue bool
 	r := &Runner{Busy: &zeroValue}
@@ -24000,6 +24694,23 @@ func TestRunnerLabels_GetType(tt *testing.T) {
 	r.GetType()
 }
 
+func TestRunnerRelabel_GetRunner(tt *testing.T) {
+	r := &RunnerRelabel{}
+	r.GetRunner()
+	r = nil
+	r.GetRunner()
+}
+
+func TestRunnerSelector_GetBusy(tt *testing.T) {
+	var zeroValue bool
+	r := &RunnerSelector{Busy: &zeroValue}
+	r.GetBusy()
+	r = &RunnerSelector{}
+	r.GetBusy()
+	r = nil
+	r.GetBusy()
+}
+
 func TestSarifAnalysis_GetCheckoutURI(tt *testing.T) {
 	var zeroValue string
 	s := &SarifAnalysis{CheckoutURI: &zeroValue}
@@ -25640,6 +26351,41 @@ func TestSubscription_GetURL(tt *testing.T) {
 	s.GetURL()
 }
 
+func TestSyncDirectoryOptions_GetAuthor(tt *testing.T) {
+	s := &SyncDirectoryOptions{}
+	s.GetAuthor()
+	s = nil
+	s.GetAuthor()
+}
+
+func TestSyncDirectoryOptions_GetCommitOptions(tt *testing.T) {
+	s := &SyncDirectoryOptions{}
+	s.GetCommitOptions()
+	s = nil
+	s.GetCommitOptions()
+}
+
+func TestSyncDirectoryOptions_GetCommitter(tt *testing.T) {
+	s := &SyncDirectoryOptions{}
+	s.GetCommitter()
+	s = nil
+	s.GetCommitter()
+}
+
+func TestSyncDirectoryResult_GetCommit(tt *testing.T) {
+	s := &SyncDirectoryResult{}
+	s.GetCommit()
+	s = nil
+	s.GetCommit()
+}
+
+func TestSyncDirectoryResult_GetPullRequest(tt *testing.T) {
+	s := &SyncDirectoryResult{}
+	s.GetPullRequest()
+	s = nil
+	s.GetPullRequest()
+}
+
 func TestTag_GetMessage(tt *testing.T) {
 	var zeroValue string
 	t := &Tag{Message: &zeroValue}
@@ -28770,6 +29516,23 @@ func TestWorkflowJobEvent_GetWorkflowJob(tt *testing.T) {
 	w.GetWorkflowJob()
 }
 
+func TestWorkflowJobLog_GetJob(tt *testing.T) {
+	w := &WorkflowJobLog{}
+	w.GetJob()
+	w = nil
+	w.GetJob()
+}
+
+func TestWorkflowLogLine_GetProperties(tt *testing.T) {
+	zeroValue := map[string]string{}
+	w := &WorkflowLogLine{Properties: zeroValue}
+	w.GetProperties()
+	w = &WorkflowLogLine{}
+	w.GetProperties()
+	w = nil
+	w.GetProperties()
+}
+
 func TestWorkflowRun_GetActor(tt *testing.T) {
 	w := &WorkflowRun{}
 	w.GetActor()
@@ -29177,6 +29940,13 @@ func TestWorkflowRunJobRun_GetJobID(tt *testing.T) {
 	w.GetJobID()
 }
 
+func TestWorkflowRunResult_GetRun(tt *testing.T) {
+	w := &WorkflowRunResult{}
+	w.GetRun()
+	w = nil
+	w.GetRun()
+}
+
 func TestWorkflowRuns_GetTotalCount(tt *testing.T) {
 	var zeroValue int
 	w := &WorkflowRuns{TotalCount: &zeroValue}
@@ -29187,6 +29957,20 @@ func TestWorkflowRuns_GetTotalCount(tt *testing.T) {
 	w.GetTotalCount()
 }
 
+func TestWorkflowRunStatusChange_GetJob(tt *testing.T) {
+	w := &WorkflowRunStatusChange{}
+	w.GetJob()
+	w = nil
+	w.GetJob()
+}
+
+func TestWorkflowRunStatusChange_GetRun(tt *testing.T) {
+	w := &WorkflowRunStatusChange{}
+	w.GetRun()
+	w = nil
+	w.GetRun()
+}
+
 func TestWorkflowRunUsage_GetBillable(tt *testing.T) {
 	w := &WorkflowRunUsage{}
 	w.GetBillable()
@@ -29214,6 +29998,13 @@ func TestWorkflows_GetTotalCount(tt *testing.T) {
 	w.GetTotalCount()
 }
 
+func TestWorkflowStepLog_GetStep(tt *testing.T) {
+	w := &WorkflowStepLog{}
+	w.GetStep()
+	w = nil
+	w.GetStep()
+}
+
 func TestWorkflowUsage_GetBillable(tt *testing.T) {
 	w := &WorkflowUsage{}
 	w.GetBillable()
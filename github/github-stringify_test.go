@@ -1483,6 +1483,7 @@ func TestReleaseAsset_String(t *testing.T) {
 		State:              String(""),
 		ContentType:        String(""),
 		Size:               Int(0),
+		Digest:             String(""),
 		DownloadCount:      Int(0),
 		CreatedAt:          &Timestamp{},
 		UpdatedAt:          &Timestamp{},
@@ -1490,7 +1491,7 @@ func TestReleaseAsset_String(t *testing.T) {
 		Uploader:           &User{},
 		NodeID:             String(""),
 	}
-	want := `github.ReleaseAsset{ID:0, URL:"", Name:"", Label:"", State:"", ContentType:"", Size:0, DownloadCount:0, CreatedAt:github.Timestamp{0001-01-01 00:00:00 +0000 UTC}, UpdatedAt:github.Timestamp{0001-01-01 00:00:00 +0000 UTC}, BrowserDownloadURL:"", Uploader:github.User{}, NodeID:""}`
+	want := `github.ReleaseAsset{ID:0, URL:"", Name:"", Label:"", State:"", ContentType:"", Size:0, Digest:"", DownloadCount:0, CreatedAt:github.Timestamp{0001-01-01 00:00:00 +0000 UTC}, UpdatedAt:github.Timestamp{0001-01-01 00:00:00 +0000 UTC}, BrowserDownloadURL:"", Uploader:github.User{}, NodeID:""}`
 	if got := v.String(); got != want {
 		t.Errorf("ReleaseAsset.String = %v, want %v", got, want)
 	}
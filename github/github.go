@@ -1162,6 +1162,41 @@ func (r *AbuseRateLimitError) Is(target error) bool {
 		compareHTTPResponse(r.Response, v.Response)
 }
 
+// RetryOnRateLimit calls f until it succeeds or fails with an error other
+// than a *RateLimitError or *AbuseRateLimitError, waiting in between for the
+// rate limit to reset or for the time the abuse error asks for, at least one
+// second. It returns ctx.Err() if ctx is done while waiting.
+func RetryOnRateLimit(ctx context.Context, f func() (*Response, error)) error {
+	for {
+		_, err := f()
+		var wait time.Duration
+		var rateErr *RateLimitError
+		var abuseErr *AbuseRateLimitError
+		switch {
+		case errors.As(err, &rateErr):
+			wait = time.Until(rateErr.Rate.Reset.Time)
+		case errors.As(err, &abuseErr):
+			wait = time.Minute
+			if abuseErr.RetryAfter != nil {
+				wait = *abuseErr.RetryAfter
+			}
+		default:
+			return err
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
 // sanitizeURL redacts the client_secret parameter from the URL which may be
 // exposed to the user.
 func sanitizeURL(uri *url.URL) *url.URL {
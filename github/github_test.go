@@ -1975,6 +1975,41 @@ func TestAbuseRateLimitError_Is(t *testing.T) {
 	}
 }
 
+func TestRetryOnRateLimit(t *testing.T) {
+	ctx := context.Background()
+	retryAfter := time.Millisecond
+	var calls int
+	err := RetryOnRateLimit(ctx, func() (*Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &AbuseRateLimitError{RetryAfter: &retryAfter}
+		}
+		return nil, nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("RetryOnRateLimit returned %v after %v calls, want nil after 2", err, calls)
+	}
+
+	wantErr := errors.New("not found")
+	calls = 0
+	err = RetryOnRateLimit(ctx, func() (*Response, error) {
+		calls++
+		return nil, wantErr
+	})
+	if err != wantErr || calls != 1 {
+		t.Errorf("RetryOnRateLimit returned %v after %v calls, want %v after 1", err, calls, wantErr)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = RetryOnRateLimit(canceled, func() (*Response, error) {
+		return nil, &RateLimitError{Rate: Rate{Reset: Timestamp{time.Now().Add(time.Hour)}}}
+	})
+	if err != context.Canceled {
+		t.Errorf("RetryOnRateLimit returned %v, want %v", err, context.Canceled)
+	}
+}
+
 func TestAcceptedError_Is(t *testing.T) {
 	err := &AcceptedError{Raw: []byte("Github")}
 	testcases := map[string]struct {
@@ -0,0 +1,556 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Possible values for LabelSyncChange.Action and MilestoneSyncChange.Action.
+const (
+	SyncActionCreate = "create"
+	SyncActionUpdate = "update"
+	SyncActionRename = "rename"
+	SyncActionDelete = "delete"
+)
+
+// LabelSpec describes the desired state of a single label.
+type LabelSpec struct {
+	Name        string  `json:"name"`
+	Color       string  `json:"color"`
+	Description *string `json:"description,omitempty"`
+	// Aliases are former names of the label. If the label does not exist
+	// but one of its aliases does, the alias is renamed in place so that
+	// issues and pull requests keep the label.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// MilestoneSpec describes the desired state of a single milestone.
+type MilestoneSpec struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	// Possible values for State are: open, closed.
+	State *string    `json:"state,omitempty"`
+	DueOn *Timestamp `json:"due_on,omitempty"`
+}
+
+// LabelSyncSpec is a declarative description of the labels and milestones
+// a repository should have. It is decoded from JSON by ParseLabelSyncSpec.
+// Specs written in YAML are parsed by the separate
+// github.com/google/go-github/v56/labelsync module, so that this package
+// does not depend on a YAML parser.
+type LabelSyncSpec struct {
+	Labels     []*LabelSpec     `json:"labels,omitempty"`
+	Milestones []*MilestoneSpec `json:"milestones,omitempty"`
+
+	// DeleteUnmanagedLabels deletes labels that are not listed in Labels.
+	DeleteUnmanagedLabels bool `json:"delete_unmanaged_labels,omitempty"`
+	// DeleteUnmanagedMilestones deletes milestones that are not listed in Milestones.
+	DeleteUnmanagedMilestones bool `json:"delete_unmanaged_milestones,omitempty"`
+}
+
+// ParseLabelSyncSpec parses a JSON encoded LabelSyncSpec and validates it.
+func ParseLabelSyncSpec(data []byte) (*LabelSyncSpec, error) {
+	spec := new(LabelSyncSpec)
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate reports whether the spec is internally consistent. Label names
+// and aliases must be unique (case-insensitively, as on GitHub), colors
+// must be six hex digits and milestone titles must be unique.
+func (s *LabelSyncSpec) Validate() error {
+	names := make(map[string]string)
+	claim := func(name, owner string) error {
+		key := strings.ToLower(name)
+		if prev, ok := names[key]; ok {
+			return fmt.Errorf("label name %q used by both %q and %q", name, prev, owner)
+		}
+		names[key] = owner
+		return nil
+	}
+	for _, l := range s.Labels {
+		if l == nil || l.Name == "" {
+			return errors.New("label spec must have a name")
+		}
+		if !isHexColor(normalizeLabelColor(l.Color)) {
+			return fmt.Errorf("label %q has invalid color %q", l.Name, l.Color)
+		}
+		if err := claim(l.Name, l.Name); err != nil {
+			return err
+		}
+		for _, a := range l.Aliases {
+			if err := claim(a, l.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	titles := make(map[string]bool)
+	for _, m := range s.Milestones {
+		if m == nil || m.Title == "" {
+			return errors.New("milestone spec must have a title")
+		}
+		if titles[m.Title] {
+			return fmt.Errorf("milestone %q is listed more than once", m.Title)
+		}
+		titles[m.Title] = true
+		if m.State != nil && *m.State != "open" && *m.State != "closed" {
+			return fmt.Errorf("milestone %q has invalid state %q", m.Title, *m.State)
+		}
+	}
+	return nil
+}
+
+// LabelSyncChange is a single planned change to a label.
+type LabelSyncChange struct {
+	// Possible values for Action are: create, update, rename, delete.
+	Action string
+	// Current is the label as it exists now. It is nil for creates.
+	Current *Label
+	// Desired is the label as it should be. It is nil for deletes.
+	Desired *Label
+}
+
+// MilestoneSyncChange is a single planned change to a milestone.
+type MilestoneSyncChange struct {
+	// Possible values for Action are: create, update, delete.
+	Action string
+	// Current is the milestone as it exists now. It is nil for creates.
+	Current *Milestone
+	// Desired is the milestone as it should be. It is nil for deletes.
+	Desired *Milestone
+}
+
+// LabelSyncPlan is the set of changes needed to bring a repository in line
+// with a LabelSyncSpec.
+type LabelSyncPlan struct {
+	Owner      string
+	Repo       string
+	Labels     []*LabelSyncChange
+	Milestones []*MilestoneSyncChange
+}
+
+// Empty reports whether the plan contains no changes.
+func (p *LabelSyncPlan) Empty() bool {
+	return len(p.Labels) == 0 && len(p.Milestones) == 0
+}
+
+// WriteTo writes a human readable description of the plan to w, one change
+// per line. It is suitable for dry-run output.
+func (p *LabelSyncPlan) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	repo := p.Owner + "/" + p.Repo
+	if p.Empty() {
+		fmt.Fprintf(&b, "%v: no changes\n", repo)
+	}
+	for _, c := range p.Labels {
+		switch c.Action {
+		case SyncActionCreate:
+			fmt.Fprintf(&b, "%v: create label %q (#%v)\n", repo, c.Desired.GetName(), c.Desired.GetColor())
+		case SyncActionUpdate:
+			fmt.Fprintf(&b, "%v: update label %q\n", repo, c.Current.GetName())
+		case SyncActionRename:
+			fmt.Fprintf(&b, "%v: rename label %q to %q\n", repo, c.Current.GetName(), c.Desired.GetName())
+		case SyncActionDelete:
+			fmt.Fprintf(&b, "%v: delete label %q\n", repo, c.Current.GetName())
+		}
+	}
+	for _, c := range p.Milestones {
+		switch c.Action {
+		case SyncActionCreate:
+			fmt.Fprintf(&b, "%v: create milestone %q\n", repo, c.Desired.GetTitle())
+		case SyncActionUpdate:
+			fmt.Fprintf(&b, "%v: update milestone %q\n", repo, c.Current.GetTitle())
+		case SyncActionDelete:
+			fmt.Fprintf(&b, "%v: delete milestone %q\n", repo, c.Current.GetTitle())
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// PlanLabelSync compares the labels and milestones of a repository against
+// spec and returns the changes needed to reconcile them. No changes are made.
+//
+// GitHub API docs: https://docs.github.com/rest/issues/labels#list-labels-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#list-milestones
+//
+//meta:operation GET /repos/{owner}/{repo}/labels
+//meta:operation GET /repos/{owner}/{repo}/milestones
+func (s *IssuesService) PlanLabelSync(ctx context.Context, owner, repo string, spec *LabelSyncSpec) (*LabelSyncPlan, error) {
+	if spec == nil {
+		return nil, errors.New("spec must be provided")
+	}
+
+	var labels []*Label
+	opts := &ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var milestones []*Milestone
+	if len(spec.Milestones) > 0 || spec.DeleteUnmanagedMilestones {
+		mopts := &MilestoneListOptions{State: "all", ListOptions: ListOptions{PerPage: 100}}
+		for {
+			page, resp, err := s.ListMilestones(ctx, owner, repo, mopts)
+			if err != nil {
+				return nil, err
+			}
+			milestones = append(milestones, page...)
+			if resp.NextPage == 0 {
+				break
+			}
+			mopts.Page = resp.NextPage
+		}
+	}
+
+	return &LabelSyncPlan{
+		Owner:      owner,
+		Repo:       repo,
+		Labels:     planLabelChanges(labels, spec),
+		Milestones: planMilestoneChanges(milestones, spec),
+	}, nil
+}
+
+func planLabelChanges(current []*Label, spec *LabelSyncSpec) []*LabelSyncChange {
+	byName := make(map[string]*Label, len(current))
+	for _, l := range current {
+		byName[strings.ToLower(l.GetName())] = l
+	}
+
+	var changes []*LabelSyncChange
+	managed := make(map[string]bool)
+	for _, ls := range spec.Labels {
+		desired := &Label{
+			Name:        String(ls.Name),
+			Color:       String(normalizeLabelColor(ls.Color)),
+			Description: ls.Description,
+		}
+
+		existing := byName[strings.ToLower(ls.Name)]
+		action := SyncActionUpdate
+		if existing == nil {
+			action = SyncActionRename
+			for _, alias := range ls.Aliases {
+				if l := byName[strings.ToLower(alias)]; l != nil && !managed[strings.ToLower(alias)] {
+					existing = l
+					break
+				}
+			}
+		}
+
+		switch {
+		case existing == nil:
+			changes = append(changes, &LabelSyncChange{Action: SyncActionCreate, Desired: desired})
+			managed[strings.ToLower(ls.Name)] = true
+			continue
+		case action == SyncActionUpdate && !labelDiffers(existing, desired):
+		default:
+			changes = append(changes, &LabelSyncChange{Action: action, Current: existing, Desired: desired})
+		}
+		managed[strings.ToLower(existing.GetName())] = true
+		managed[strings.ToLower(ls.Name)] = true
+	}
+
+	if spec.DeleteUnmanagedLabels {
+		for _, l := range current {
+			if !managed[strings.ToLower(l.GetName())] {
+				changes = append(changes, &LabelSyncChange{Action: SyncActionDelete, Current: l})
+			}
+		}
+	}
+	return changes
+}
+
+func labelDiffers(current, desired *Label) bool {
+	if current.GetName() != desired.GetName() {
+		return true
+	}
+	if !strings.EqualFold(normalizeLabelColor(current.GetColor()), desired.GetColor()) {
+		return true
+	}
+	return desired.Description != nil && current.GetDescription() != desired.GetDescription()
+}
+
+func planMilestoneChanges(current []*Milestone, spec *LabelSyncSpec) []*MilestoneSyncChange {
+	byTitle := make(map[string]*Milestone, len(current))
+	for _, m := range current {
+		byTitle[m.GetTitle()] = m
+	}
+
+	var changes []*MilestoneSyncChange
+	for _, ms := range spec.Milestones {
+		desired := &Milestone{
+			Title:       String(ms.Title),
+			Description: ms.Description,
+			State:       ms.State,
+			DueOn:       ms.DueOn,
+		}
+		existing, ok := byTitle[ms.Title]
+		switch {
+		case !ok:
+			changes = append(changes, &MilestoneSyncChange{Action: SyncActionCreate, Desired: desired})
+		case milestoneDiffers(existing, desired):
+			changes = append(changes, &MilestoneSyncChange{Action: SyncActionUpdate, Current: existing, Desired: desired})
+		}
+	}
+
+	if spec.DeleteUnmanagedMilestones {
+		managed := make(map[string]bool, len(spec.Milestones))
+		for _, ms := range spec.Milestones {
+			managed[ms.Title] = true
+		}
+		for _, m := range current {
+			if !managed[m.GetTitle()] {
+				changes = append(changes, &MilestoneSyncChange{Action: SyncActionDelete, Current: m})
+			}
+		}
+	}
+	return changes
+}
+
+func milestoneDiffers(current, desired *Milestone) bool {
+	if desired.Description != nil && current.GetDescription() != desired.GetDescription() {
+		return true
+	}
+	if desired.State != nil && current.GetState() != desired.GetState() {
+		return true
+	}
+	if desired.DueOn != nil {
+		// GitHub stores due dates with day precision.
+		y1, m1, d1 := current.GetDueOn().UTC().Date()
+		y2, m2, d2 := desired.DueOn.UTC().Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+// ApplyLabelSync applies the changes in plan. Renames are applied first so
+// that a label can be renamed to a name another label is about to give up,
+// followed by updates, creates and finally deletes.
+//
+// Each request that fails with a rate limit error is retried once the
+// limit resets, unless ctx is done first.
+//
+// GitHub API docs: https://docs.github.com/rest/issues/labels#create-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/labels#delete-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/labels#update-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#create-a-milestone
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#delete-a-milestone
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#update-a-milestone
+//
+//meta:operation POST /repos/{owner}/{repo}/labels
+//meta:operation DELETE /repos/{owner}/{repo}/labels/{name}
+//meta:operation PATCH /repos/{owner}/{repo}/labels/{name}
+//meta:operation POST /repos/{owner}/{repo}/milestones
+//meta:operation DELETE /repos/{owner}/{repo}/milestones/{milestone_number}
+//meta:operation PATCH /repos/{owner}/{repo}/milestones/{milestone_number}
+func (s *IssuesService) ApplyLabelSync(ctx context.Context, plan *LabelSyncPlan) error {
+	order := map[string]int{SyncActionRename: 0, SyncActionUpdate: 1, SyncActionCreate: 2, SyncActionDelete: 3}
+	labels := make([]*LabelSyncChange, len(plan.Labels))
+	copy(labels, plan.Labels)
+	sort.SliceStable(labels, func(i, j int) bool { return order[labels[i].Action] < order[labels[j].Action] })
+
+	owner, repo := plan.Owner, plan.Repo
+	for _, c := range labels {
+		var err error
+		switch c.Action {
+		case SyncActionCreate:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				_, resp, err := s.CreateLabel(ctx, owner, repo, c.Desired)
+				return resp, err
+			})
+		case SyncActionUpdate, SyncActionRename:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				_, resp, err := s.EditLabel(ctx, owner, repo, c.Current.GetName(), c.Desired)
+				return resp, err
+			})
+		case SyncActionDelete:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				return s.DeleteLabel(ctx, owner, repo, c.Current.GetName())
+			})
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("%v label %q: %w", c.Action, labelChangeName(c), err)
+		}
+	}
+
+	for _, c := range plan.Milestones {
+		var err error
+		switch c.Action {
+		case SyncActionCreate:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				_, resp, err := s.CreateMilestone(ctx, owner, repo, c.Desired)
+				return resp, err
+			})
+		case SyncActionUpdate:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				_, resp, err := s.EditMilestone(ctx, owner, repo, c.Current.GetNumber(), c.Desired)
+				return resp, err
+			})
+		case SyncActionDelete:
+			err = RetryOnRateLimit(ctx, func() (*Response, error) {
+				return s.DeleteMilestone(ctx, owner, repo, c.Current.GetNumber())
+			})
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			title := c.Desired.GetTitle()
+			if c.Current != nil {
+				title = c.Current.GetTitle()
+			}
+			return fmt.Errorf("%v milestone %q: %w", c.Action, title, err)
+		}
+	}
+	return nil
+}
+
+func labelChangeName(c *LabelSyncChange) string {
+	if c.Current != nil {
+		return c.Current.GetName()
+	}
+	return c.Desired.GetName()
+}
+
+// LabelSyncOptions specifies optional parameters to IssuesService.SyncLabels.
+type LabelSyncOptions struct {
+	// DryRun computes the plans without applying them.
+	DryRun bool
+	// Concurrency is the maximum number of repositories processed at
+	// once. Defaults to 4.
+	Concurrency int
+}
+
+// LabelSyncResult is the outcome of synchronising a single repository.
+type LabelSyncResult struct {
+	Owner string
+	Repo  string
+	Plan  *LabelSyncPlan
+	// Applied reports whether the plan was applied.
+	Applied bool
+	Err     error
+}
+
+// SyncLabels reconciles the labels and milestones of every repository in
+// repos, given as "owner/repo", against spec. Repositories are processed
+// concurrently. A result is returned for every repository in the order
+// given; failures are reported per repository in LabelSyncResult.Err.
+//
+// GitHub API docs: https://docs.github.com/rest/issues/labels#create-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/labels#delete-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/labels#list-labels-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/issues/labels#update-a-label
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#create-a-milestone
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#delete-a-milestone
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#list-milestones
+// GitHub API docs: https://docs.github.com/rest/issues/milestones#update-a-milestone
+//
+//meta:operation GET /repos/{owner}/{repo}/labels
+//meta:operation POST /repos/{owner}/{repo}/labels
+//meta:operation DELETE /repos/{owner}/{repo}/labels/{name}
+//meta:operation PATCH /repos/{owner}/{repo}/labels/{name}
+//meta:operation GET /repos/{owner}/{repo}/milestones
+//meta:operation POST /repos/{owner}/{repo}/milestones
+//meta:operation DELETE /repos/{owner}/{repo}/milestones/{milestone_number}
+//meta:operation PATCH /repos/{owner}/{repo}/milestones/{milestone_number}
+func (s *IssuesService) SyncLabels(ctx context.Context, repos []string, spec *LabelSyncSpec, opts *LabelSyncOptions) ([]*LabelSyncResult, error) {
+	if spec == nil {
+		return nil, errors.New("spec must be provided")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &LabelSyncOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	results := make([]*LabelSyncResult, len(repos))
+	for i, full := range repos {
+		parts := strings.SplitN(full, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid repository %q, want owner/repo", full)
+		}
+		results[i] = &LabelSyncResult{Owner: parts[0], Repo: parts[1]}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, r := range results {
+		wg.Add(1)
+		go func(r *LabelSyncResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			err := RetryOnRateLimit(ctx, func() (*Response, error) {
+				plan, err := s.PlanLabelSync(ctx, r.Owner, r.Repo, spec)
+				r.Plan = plan
+				return nil, err
+			})
+			if err != nil {
+				r.Err = err
+				return
+			}
+			if opts.DryRun || r.Plan.Empty() {
+				return
+			}
+			r.Err = s.ApplyLabelSync(ctx, r.Plan)
+			r.Applied = r.Err == nil
+		}(r)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func normalizeLabelColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLabelSyncSpec(t *testing.T) {
+	spec, err := ParseLabelSyncSpec([]byte(`{
+		"labels": [{"name": "bug", "color": "#D73A4A", "aliases": ["defect"]}],
+		"milestones": [{"title": "v1", "state": "open"}],
+		"delete_unmanaged_labels": true
+	}`))
+	if err != nil {
+		t.Fatalf("ParseLabelSyncSpec returned error: %v", err)
+	}
+
+	want := &LabelSyncSpec{
+		Labels:                []*LabelSpec{{Name: "bug", Color: "#D73A4A", Aliases: []string{"defect"}}},
+		Milestones:            []*MilestoneSpec{{Title: "v1", State: String("open")}},
+		DeleteUnmanagedLabels: true,
+	}
+	if !cmp.Equal(spec, want) {
+		t.Errorf("ParseLabelSyncSpec returned %+v, want %+v", spec, want)
+	}
+
+	invalid := []string{
+		`{"labels": [{"color": "ffffff"}]}`,
+		`{"labels": [{"name": "bug", "color": "red"}]}`,
+		`{"labels": [{"name": "bug", "color": "ffffff"}, {"name": "Bug", "color": "000000"}]}`,
+		`{"labels": [{"name": "bug", "color": "ffffff"}, {"name": "defect", "color": "000000", "aliases": ["bug"]}]}`,
+		`{"milestones": [{"title": "v1"}, {"title": "v1"}]}`,
+		`{"milestones": [{"title": "v1", "state": "done"}]}`,
+		`{`,
+	}
+	for _, data := range invalid {
+		if _, err := ParseLabelSyncSpec([]byte(data)); err == nil {
+			t.Errorf("ParseLabelSyncSpec(%v) returned nil error, want error", data)
+		}
+	}
+}
+
+func TestIssuesService_PlanLabelSync(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"name": "defect", "color": "d73a4a"},
+			{"name": "docs", "color": "0075CA"},
+			{"name": "wontfix", "color": "ffffff"},
+			{"name": "stale", "color": "eeeeee"}
+		]`)
+	})
+	mux.HandleFunc("/repos/o/r/milestones", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"state": "all", "per_page": "100"})
+		fmt.Fprint(w, `[{"number": 1, "title": "v1", "state": "open"}, {"number": 2, "title": "old", "state": "closed"}]`)
+	})
+
+	spec := &LabelSyncSpec{
+		Labels: []*LabelSpec{
+			{Name: "bug", Color: "d73a4a", Aliases: []string{"defect"}},
+			{Name: "docs", Color: "#0075ca"},
+			{Name: "wontfix", Color: "000000", Description: String("Will not be worked on")},
+			{Name: "feature", Color: "a2eeef"},
+		},
+		Milestones: []*MilestoneSpec{
+			{Title: "v1", State: String("closed")},
+			{Title: "v2"},
+		},
+		DeleteUnmanagedLabels: true,
+	}
+
+	ctx := context.Background()
+	plan, err := client.Issues.PlanLabelSync(ctx, "o", "r", spec)
+	if err != nil {
+		t.Fatalf("Issues.PlanLabelSync returned error: %v", err)
+	}
+
+	want := &LabelSyncPlan{
+		Owner: "o",
+		Repo:  "r",
+		Labels: []*LabelSyncChange{
+			{
+				Action:  SyncActionRename,
+				Current: &Label{Name: String("defect"), Color: String("d73a4a")},
+				Desired: &Label{Name: String("bug"), Color: String("d73a4a")},
+			},
+			{
+				Action:  SyncActionUpdate,
+				Current: &Label{Name: String("wontfix"), Color: String("ffffff")},
+				Desired: &Label{Name: String("wontfix"), Color: String("000000"), Description: String("Will not be worked on")},
+			},
+			{
+				Action:  SyncActionCreate,
+				Desired: &Label{Name: String("feature"), Color: String("a2eeef")},
+			},
+			{
+				Action:  SyncActionDelete,
+				Current: &Label{Name: String("stale"), Color: String("eeeeee")},
+			},
+		},
+		Milestones: []*MilestoneSyncChange{
+			{
+				Action:  SyncActionUpdate,
+				Current: &Milestone{Number: Int(1), Title: String("v1"), State: String("open")},
+				Desired: &Milestone{Title: String("v1"), State: String("closed")},
+			},
+			{
+				Action:  SyncActionCreate,
+				Desired: &Milestone{Title: String("v2")},
+			},
+		},
+	}
+	if !cmp.Equal(plan, want) {
+		t.Errorf("Issues.PlanLabelSync returned diff (-want +got):\n%v", cmp.Diff(want, plan))
+	}
+
+	var b strings.Builder
+	if _, err := plan.WriteTo(&b); err != nil {
+		t.Fatalf("LabelSyncPlan.WriteTo returned error: %v", err)
+	}
+	wantOutput := `o/r: rename label "defect" to "bug"
+o/r: update label "wontfix"
+o/r: create label "feature" (#a2eeef)
+o/r: delete label "stale"
+o/r: update milestone "v1"
+o/r: create milestone "v2"
+`
+	if got := b.String(); got != wantOutput {
+		t.Errorf("LabelSyncPlan.WriteTo wrote:\n%v\nwant:\n%v", got, wantOutput)
+	}
+
+	const methodName = "PlanLabelSync"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Issues.PlanLabelSync(ctx, "o", "r", nil)
+		return err
+	})
+}
+
+func TestIssuesService_ApplyLabelSync(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}
+
+	mux.HandleFunc("/repos/o/r/labels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"feature","color":"a2eeef"}`+"\n")
+		record(r)
+		fmt.Fprint(w, `{"name": "feature"}`)
+	})
+	mux.HandleFunc("/repos/o/r/labels/defect", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"name":"bug","color":"d73a4a"}`+"\n")
+		record(r)
+		fmt.Fprint(w, `{"name": "bug"}`)
+	})
+	mux.HandleFunc("/repos/o/r/labels/stale", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		record(r)
+	})
+	mux.HandleFunc("/repos/o/r/milestones/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"state":"closed","title":"v1"}`+"\n")
+		record(r)
+		fmt.Fprint(w, `{"number": 1}`)
+	})
+
+	plan := &LabelSyncPlan{
+		Owner: "o",
+		Repo:  "r",
+		Labels: []*LabelSyncChange{
+			{Action: SyncActionDelete, Current: &Label{Name: String("stale")}},
+			{Action: SyncActionCreate, Desired: &Label{Name: String("feature"), Color: String("a2eeef")}},
+			{
+				Action:  SyncActionRename,
+				Current: &Label{Name: String("defect")},
+				Desired: &Label{Name: String("bug"), Color: String("d73a4a")},
+			},
+		},
+		Milestones: []*MilestoneSyncChange{
+			{
+				Action:  SyncActionUpdate,
+				Current: &Milestone{Number: Int(1), Title: String("v1")},
+				Desired: &Milestone{Title: String("v1"), State: String("closed")},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if err := client.Issues.ApplyLabelSync(ctx, plan); err != nil {
+		t.Fatalf("Issues.ApplyLabelSync returned error: %v", err)
+	}
+
+	want := []string{
+		"PATCH /repos/o/r/labels/defect",
+		"POST /repos/o/r/labels",
+		"DELETE /repos/o/r/labels/stale",
+		"PATCH /repos/o/r/milestones/1",
+	}
+	if !cmp.Equal(calls, want) {
+		t.Errorf("Issues.ApplyLabelSync made calls %v, want %v", calls, want)
+	}
+
+	plan.Labels = []*LabelSyncChange{{Action: SyncActionDelete, Current: &Label{Name: String("missing")}}}
+	if err := client.Issues.ApplyLabelSync(ctx, plan); err == nil {
+		t.Error("Issues.ApplyLabelSync returned nil error, want error")
+	}
+}
+
+func TestIssuesService_SyncLabels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, repo := range []string{"a", "b"} {
+		repo := repo
+		mux.HandleFunc("/repos/o/"+repo+"/labels", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			if repo == "a" {
+				fmt.Fprint(w, `[{"name": "bug", "color": "d73a4a"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		})
+	}
+
+	spec := &LabelSyncSpec{Labels: []*LabelSpec{{Name: "bug", Color: "d73a4a"}}}
+
+	ctx := context.Background()
+	results, err := client.Issues.SyncLabels(ctx, []string{"o/a", "o/b"}, spec, &LabelSyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Issues.SyncLabels returned error: %v", err)
+	}
+
+	want := []*LabelSyncResult{
+		{Owner: "o", Repo: "a", Plan: &LabelSyncPlan{Owner: "o", Repo: "a"}},
+		{
+			Owner: "o",
+			Repo:  "b",
+			Plan: &LabelSyncPlan{
+				Owner: "o",
+				Repo:  "b",
+				Labels: []*LabelSyncChange{
+					{Action: SyncActionCreate, Desired: &Label{Name: String("bug"), Color: String("d73a4a")}},
+				},
+			},
+		},
+	}
+	if !cmp.Equal(results, want) {
+		t.Errorf("Issues.SyncLabels returned diff (-want +got):\n%v", cmp.Diff(want, results))
+	}
+
+	if _, err := client.Issues.SyncLabels(ctx, []string{"o"}, spec, nil); err == nil {
+		t.Error("Issues.SyncLabels returned nil error for invalid repository, want error")
+	}
+	if _, err := client.Issues.SyncLabels(ctx, []string{"o/a"}, nil, nil); err == nil {
+		t.Error("Issues.SyncLabels returned nil error for nil spec, want error")
+	}
+}
@@ -113,3 +113,51 @@ func (s *OrganizationsService) DeleteOrganizationRuleset(ctx context.Context, or
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// ListRuleSuites lists the rule suites evaluated for pushes to repositories in an organization.
+//
+// GitHub API docs: https://docs.github.com/rest/orgs/rule-suites#list-organization-rule-suites
+//
+//meta:operation GET /orgs/{org}/rulesets/rule-suites
+func (s *OrganizationsService) ListRuleSuites(ctx context.Context, org string, opts *RuleSuiteListOptions) ([]*RuleSuite, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/rulesets/rule-suites", org)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suites []*RuleSuite
+	resp, err := s.client.Do(ctx, req, &suites)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return suites, resp, nil
+}
+
+// GetRuleSuite gets a rule suite for an organization, including the outcome of every rule evaluated.
+//
+// GitHub API docs: https://docs.github.com/rest/orgs/rule-suites#get-an-organization-rule-suite
+//
+//meta:operation GET /orgs/{org}/rulesets/rule-suites/{rule_suite_id}
+func (s *OrganizationsService) GetRuleSuite(ctx context.Context, org string, ruleSuiteID int64) (*RuleSuite, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/rulesets/rule-suites/%v", org, ruleSuiteID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suite *RuleSuite
+	resp, err := s.client.Do(ctx, req, &suite)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return suite, resp, nil
+}
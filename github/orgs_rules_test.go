@@ -932,3 +932,148 @@ func TestOrganizationsService_DeleteOrganizationRuleset(t *testing.T) {
 		return client.Organizations.DeleteOrganizationRuleset(ctx, "0", 26110)
 	})
 }
+
+func TestOrganizationsService_ListRuleSuites(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/rulesets/rule-suites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"repository_name": "repo", "rule_suite_result": "fail", "time_period": "hour"})
+		fmt.Fprint(w, `[{"id": 21, "repository_name": "repo", "result": "fail"}]`)
+	})
+
+	opts := &RuleSuiteListOptions{RepositoryName: "repo", RuleSuiteResult: "fail", TimePeriod: "hour"}
+	ctx := context.Background()
+	suites, _, err := client.Organizations.ListRuleSuites(ctx, "o", opts)
+	if err != nil {
+		t.Errorf("Organizations.ListRuleSuites returned error: %v", err)
+	}
+
+	want := []*RuleSuite{{ID: Int64(21), RepositoryName: String("repo"), Result: String("fail")}}
+	if !cmp.Equal(suites, want) {
+		t.Errorf("Organizations.ListRuleSuites returned %+v, want %+v", suites, want)
+	}
+
+	const methodName = "ListRuleSuites"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Organizations.ListRuleSuites(ctx, "\n", opts)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Organizations.ListRuleSuites(ctx, "o", opts)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestOrganizationsService_GetRuleSuite(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/rulesets/rule-suites/21", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 21, "rule_evaluations": [{"rule_source": {"type": "ruleset", "id": 2}, "result": "pass", "rule_type": "pull_request"}]}`)
+	})
+
+	ctx := context.Background()
+	suite, _, err := client.Organizations.GetRuleSuite(ctx, "o", 21)
+	if err != nil {
+		t.Errorf("Organizations.GetRuleSuite returned error: %v", err)
+	}
+
+	want := &RuleSuite{
+		ID: Int64(21),
+		RuleEvaluations: []*RuleEvaluation{{
+			RuleSource: &RuleSource{Type: String("ruleset"), ID: Int64(2)},
+			Result:     String("pass"),
+			RuleType:   String("pull_request"),
+		}},
+	}
+	if !cmp.Equal(suite, want) {
+		t.Errorf("Organizations.GetRuleSuite returned %+v, want %+v", suite, want)
+	}
+
+	const methodName = "GetRuleSuite"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Organizations.GetRuleSuite(ctx, "\n", 21)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Organizations.GetRuleSuite(ctx, "o", 21)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestOrganizationsService_CreateOrganizationRuleset_RepoProperty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"tiered","target":"branch","source":"","enforcement":"active","conditions":{"ref_name":{"include":["~DEFAULT_BRANCH"],"exclude":[]},"repository_property":{"include":[{"name":"tier","property_values":["gold","silver"]}],"exclude":[]}},"rules":[{"type":"deletion"}]}`+"\n")
+		fmt.Fprint(w, `{
+			"id": 21,
+			"name": "tiered",
+			"target": "branch",
+			"source_type": "Organization",
+			"source": "o",
+			"enforcement": "active",
+			"conditions": {
+				"ref_name": {
+					"include": ["~DEFAULT_BRANCH"],
+					"exclude": []
+				},
+				"repository_property": {
+					"include": [{"name": "tier", "property_values": ["gold", "silver"]}],
+					"exclude": []
+				}
+			},
+			"rules": [{"type": "deletion"}]
+		}`)
+	})
+
+	conditions := &RulesetConditions{
+		RefName: &RulesetRefConditionParameters{
+			Include: []string{"~DEFAULT_BRANCH"},
+			Exclude: []string{},
+		},
+		RepositoryProperty: &RulesetRepositoryPropertyConditionParameters{
+			Include: []*RulesetRepositoryPropertyTargetParameters{{Name: "tier", Values: []string{"gold", "silver"}}},
+			Exclude: []*RulesetRepositoryPropertyTargetParameters{},
+		},
+	}
+
+	ctx := context.Background()
+	ruleset, _, err := client.Organizations.CreateOrganizationRuleset(ctx, "o", &Ruleset{
+		Name:        "tiered",
+		Target:      String("branch"),
+		Enforcement: "active",
+		Conditions:  conditions,
+		Rules:       []*RepositoryRule{NewDeletionRule()},
+	})
+	if err != nil {
+		t.Errorf("Organizations.CreateOrganizationRuleset returned error: %v", err)
+	}
+
+	want := &Ruleset{
+		ID:          Int64(21),
+		Name:        "tiered",
+		Target:      String("branch"),
+		SourceType:  String("Organization"),
+		Source:      "o",
+		Enforcement: "active",
+		Conditions:  conditions,
+		Rules:       []*RepositoryRule{NewDeletionRule()},
+	}
+	if !cmp.Equal(ruleset, want) {
+		t.Errorf("Organizations.CreateOrganizationRuleset returned %+v, want %+v", ruleset, want)
+	}
+}
@@ -98,6 +98,11 @@ type Repository struct {
 	// Options for configuring Advanced Security and Secret Scanning
 	SecurityAndAnalysis *SecurityAndAnalysis `json:"security_and_analysis,omitempty"`
 
+	// CustomProperties maps custom property names to their values, as
+	// defined by the organization that owns the repository. A value is a
+	// string, or a []interface{} of strings for multi_select properties.
+	CustomProperties map[string]interface{} `json:"custom_properties,omitempty"`
+
 	// Creating an organization repository. Required for non-owners.
 	TeamID *int64 `json:"team_id,omitempty"`
 
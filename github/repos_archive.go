@@ -0,0 +1,452 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSymlinkHops bounds the number of symbolic links followed when
+// resolving a path in an archive.
+const maxSymlinkHops = 40
+
+// ExtractArchive downloads the tarball of a repository and extracts it
+// into dir, which is created if needed. The top-level directory GitHub
+// adds to archives, named after the owner, repository and commit, is
+// stripped.
+//
+// Entries that would be written outside dir, or beneath a symbolic link,
+// cause an error. Symbolic links are recreated as links and
+// executable files keep their executable mode.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/contents#download-a-repository-archive-tar
+//
+//meta:operation GET /repos/{owner}/{repo}/tarball/{ref}
+func (s *RepositoriesService) ExtractArchive(ctx context.Context, owner, repo string, opts *RepositoryContentGetOptions, dir string) error {
+	rc, err := s.openTarball(ctx, owner, repo, opts)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return extractTarball(rc, dir)
+}
+
+// GetArchiveFS downloads the tarball of a repository and returns its
+// contents as a read-only, in-memory file system. The top-level directory
+// GitHub adds to archives is stripped. Symbolic links are followed when
+// opening a file, and are reported with fs.ModeSymlink by ReadDir.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/contents#download-a-repository-archive-tar
+//
+//meta:operation GET /repos/{owner}/{repo}/tarball/{ref}
+func (s *RepositoriesService) GetArchiveFS(ctx context.Context, owner, repo string, opts *RepositoryContentGetOptions) (fs.FS, error) {
+	rc, err := s.openTarball(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return newArchiveFS(rc)
+}
+
+func (s *RepositoriesService) openTarball(ctx context.Context, owner, repo string, opts *RepositoryContentGetOptions) (io.ReadCloser, error) {
+	u := fmt.Sprintf("repos/%s/%s/%s", owner, repo, Tarball)
+	if opts != nil && opts.Ref != "" {
+		u += fmt.Sprintf("/%s", opts.Ref)
+	}
+	resp, err := s.client.openDownload(ctx, u, &DownloadToFileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return &gzipReadCloser{Reader: zr, body: resp.Body}, nil
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (r *gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+// archiveEntryName returns the name of a tar entry with GitHub's top-level
+// directory removed, or "" if the entry should be skipped.
+func archiveEntryName(hdr *tar.Header) (string, error) {
+	switch hdr.Typeflag {
+	case tar.TypeDir, tar.TypeReg, tar.TypeSymlink:
+	default:
+		// This includes the pax_global_header GitHub stores the commit in.
+		return "", nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(hdr.Name, "./"), "/", 2)
+	if len(parts) < 2 {
+		return "", nil
+	}
+	name := strings.TrimSuffix(parts[1], "/")
+	if name == "" {
+		return "", nil
+	}
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("archive entry %q has an invalid path", hdr.Name)
+	}
+	return name, nil
+}
+
+// archiveFileMode returns the mode a file is extracted with. Git only
+// records whether a file is executable.
+func archiveFileMode(hdr *tar.Header) fs.FileMode {
+	if hdr.Mode&0o111 != 0 {
+		return 0o755
+	}
+	return 0o644
+}
+
+// extractTarball extracts the entries of the tar stream r into dir.
+func extractTarball(r io.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, err := archiveEntryName(hdr)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+
+		// Git does not store entries beneath a symbolic link, so rather
+		// than resolve links, which may point outside root, refuse to
+		// extract through them before anything is created.
+		dirName := path.Dir(name)
+		if hdr.Typeflag == tar.TypeDir {
+			dirName = name
+		}
+		if err := checkArchiveDir(root, dirName); err != nil {
+			return fmt.Errorf("archive entry %q: %v", name, err)
+		}
+		target := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dirName)), 0o755); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			// Created above.
+		case tar.TypeSymlink:
+			linkTarget := path.Join(path.Dir(name), hdr.Linkname)
+			if path.IsAbs(hdr.Linkname) || !fs.ValidPath(linkTarget) {
+				return fmt.Errorf("archive entry %q links outside the archive to %q", name, hdr.Linkname)
+			}
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			// Replace rather than write through whatever is at target,
+			// which may be a symbolic link.
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, archiveFileMode(hdr))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkArchiveDir returns an error if any existing element of the
+// slash-separated path name below root is not a directory, including if it
+// is a symbolic link.
+func checkArchiveDir(root, name string) error {
+	if name == "." {
+		return nil
+	}
+	p := root
+	for _, elem := range strings.Split(name, "/") {
+		p = filepath.Join(p, elem)
+		fi, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%v is a symbolic link", p)
+		}
+		if !fi.IsDir() {
+			return fmt.Errorf("%v is not a directory", p)
+		}
+	}
+	return nil
+}
+
+// archiveFS is an in-memory fs.FS holding the contents of an archive.
+type archiveFS struct {
+	files map[string]*archiveFile
+}
+
+type archiveFile struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	data    []byte
+	// link is the target of a symbolic link.
+	link string
+	// entries are the base names of a directory's children, sorted.
+	entries []string
+}
+
+func (f *archiveFile) Name() string               { return path.Base(f.name) }
+func (f *archiveFile) Size() int64                { return int64(len(f.data)) }
+func (f *archiveFile) Mode() fs.FileMode          { return f.mode }
+func (f *archiveFile) ModTime() time.Time         { return f.modTime }
+func (f *archiveFile) IsDir() bool                { return f.mode.IsDir() }
+func (f *archiveFile) Sys() interface{}           { return nil }
+func (f *archiveFile) Type() fs.FileMode          { return f.mode.Type() }
+func (f *archiveFile) Info() (fs.FileInfo, error) { return f, nil }
+
+// newArchiveFS reads the tar stream r into an archiveFS.
+func newArchiveFS(r io.Reader) (*archiveFS, error) {
+	afs := &archiveFS{files: map[string]*archiveFile{
+		".": {name: ".", mode: fs.ModeDir | 0o755},
+	}}
+
+	var addDir func(name string, modTime time.Time) *archiveFile
+	addDir = func(name string, modTime time.Time) *archiveFile {
+		if f, ok := afs.files[name]; ok {
+			return f
+		}
+		addDir(path.Dir(name), modTime)
+		f := &archiveFile{name: name, mode: fs.ModeDir | 0o755, modTime: modTime}
+		afs.files[name] = f
+		return f
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name, err := archiveEntryName(hdr)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			addDir(name, hdr.ModTime)
+		case tar.TypeSymlink:
+			addDir(path.Dir(name), hdr.ModTime)
+			afs.files[name] = &archiveFile{name: name, mode: fs.ModeSymlink | 0o777, modTime: hdr.ModTime, link: hdr.Linkname}
+		default:
+			addDir(path.Dir(name), hdr.ModTime)
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, tr); err != nil {
+				return nil, err
+			}
+			afs.files[name] = &archiveFile{name: name, mode: archiveFileMode(hdr), modTime: hdr.ModTime, data: buf.Bytes()}
+		}
+	}
+
+	for name := range afs.files {
+		if name == "." {
+			continue
+		}
+		parent := afs.files[path.Dir(name)]
+		parent.entries = append(parent.entries, path.Base(name))
+	}
+	for _, f := range afs.files {
+		sort.Strings(f.entries)
+	}
+	return afs, nil
+}
+
+// resolve returns the file name refers to, following symbolic links.
+func (afs *archiveFS) resolve(op, name string) (*archiveFile, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	hops := 0
+	resolved := "."
+	rest := strings.Split(name, "/")
+	if name == "." {
+		rest = nil
+	}
+	for len(rest) > 0 {
+		next := path.Join(resolved, rest[0])
+		rest = rest[1:]
+		f, ok := afs.files[next]
+		if !ok {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		if f.link == "" {
+			resolved = next
+			continue
+		}
+
+		hops++
+		target := path.Join(resolved, f.link)
+		if hops > maxSymlinkHops || path.IsAbs(f.link) || !fs.ValidPath(target) {
+			return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("symbolic link cannot be resolved within the archive")}
+		}
+		resolved = "."
+		if target != "." {
+			rest = append(strings.Split(target, "/"), rest...)
+		}
+	}
+	return afs.files[resolved], nil
+}
+
+func (afs *archiveFS) Open(name string) (fs.File, error) {
+	f, err := afs.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if f.IsDir() {
+		return &openArchiveDir{afs: afs, f: f}, nil
+	}
+	return &openArchiveFile{f: f, r: bytes.NewReader(f.data)}, nil
+}
+
+func (afs *archiveFS) Stat(name string) (fs.FileInfo, error) {
+	return afs.resolve("stat", name)
+}
+
+func (afs *archiveFS) ReadFile(name string) ([]byte, error) {
+	f, err := afs.resolve("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if f.IsDir() {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+func (afs *archiveFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := afs.resolve("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return afs.dirEntries(f), nil
+}
+
+func (afs *archiveFS) dirEntries(dir *archiveFile) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(dir.entries))
+	for _, e := range dir.entries {
+		entries = append(entries, afs.files[path.Join(dir.name, e)])
+	}
+	return entries
+}
+
+type openArchiveFile struct {
+	f *archiveFile
+	r *bytes.Reader
+}
+
+func (o *openArchiveFile) Stat() (fs.FileInfo, error) { return o.f, nil }
+func (o *openArchiveFile) Read(b []byte) (int, error) { return o.r.Read(b) }
+func (o *openArchiveFile) Seek(offset int64, whence int) (int64, error) {
+	return o.r.Seek(offset, whence)
+}
+func (o *openArchiveFile) ReadAt(b []byte, off int64) (int, error) { return o.r.ReadAt(b, off) }
+func (o *openArchiveFile) Close() error                            { return nil }
+
+type openArchiveDir struct {
+	afs    *archiveFS
+	f      *archiveFile
+	offset int
+}
+
+func (d *openArchiveDir) Stat() (fs.FileInfo, error) { return d.f, nil }
+func (d *openArchiveDir) Close() error               { return nil }
+
+func (d *openArchiveDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.f.name, Err: errors.New("is a directory")}
+}
+
+func (d *openArchiveDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.afs.dirEntries(d.f)[d.offset:]
+	if n <= 0 {
+		d.offset += len(entries)
+		return entries, nil
+	}
+	if len(entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(entries) {
+		n = len(entries)
+	}
+	d.offset += n
+	return entries[:n], nil
+}
@@ -0,0 +1,323 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testTarEntry struct {
+	name     string
+	typeflag byte
+	mode     int64
+	body     string
+	link     string
+}
+
+func testTarball(t *testing.T, entries []testTarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Mode:     e.mode,
+			Size:     int64(len(e.body)),
+			Linkname: e.link,
+		}
+		if e.typeflag == tar.TypeXGlobalHeader {
+			hdr.Size = 0
+			hdr.PAXRecords = map[string]string{"comment": "abc123"}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var testRepoArchive = []testTarEntry{
+	{name: "pax_global_header", typeflag: tar.TypeXGlobalHeader},
+	{name: "o-r-abc123/", typeflag: tar.TypeDir, mode: 0o775},
+	{name: "o-r-abc123/README.md", typeflag: tar.TypeReg, mode: 0o664, body: "hello"},
+	{name: "o-r-abc123/bin/", typeflag: tar.TypeDir, mode: 0o775},
+	{name: "o-r-abc123/bin/run.sh", typeflag: tar.TypeReg, mode: 0o775, body: "#!/bin/sh\n"},
+	{name: "o-r-abc123/docs/guide/intro.md", typeflag: tar.TypeReg, mode: 0o664, body: "intro"},
+	{name: "o-r-abc123/LINK.md", typeflag: tar.TypeSymlink, link: "README.md"},
+	{name: "o-r-abc123/docs/run", typeflag: tar.TypeSymlink, link: "../bin"},
+}
+
+func TestRepositoriesService_ExtractArchive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testTarball(t, testRepoArchive)
+	mux.HandleFunc("/repos/o/r/tarball/main", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write(archive)
+	})
+
+	dir := filepath.Join(t.TempDir(), "out")
+	ctx := context.Background()
+	opts := &RepositoryContentGetOptions{Ref: "main"}
+	if err := client.Repositories.ExtractArchive(ctx, "o", "r", opts, dir); err != nil {
+		t.Fatalf("Repositories.ExtractArchive returned error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"README.md":           "hello",
+		"LINK.md":             "hello",
+		"bin/run.sh":          "#!/bin/sh\n",
+		"docs/guide/intro.md": "intro",
+		"docs/run/run.sh":     "#!/bin/sh\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("ReadFile(%v) returned error: %v", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%v = %q, want %q", name, got, want)
+		}
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "bin", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm()&0o100 == 0 {
+		t.Errorf("bin/run.sh mode = %v, want executable", fi.Mode())
+	}
+	fi, err = os.Stat(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm()&0o111 != 0 {
+		t.Errorf("README.md mode = %v, want not executable", fi.Mode())
+	}
+	fi, err = os.Lstat(filepath.Join(dir, "LINK.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 {
+		t.Errorf("LINK.md mode = %v, want symbolic link", fi.Mode())
+	}
+
+	const methodName = "ExtractArchive"
+	testBadOptions(t, methodName, func() (err error) {
+		return client.Repositories.ExtractArchive(ctx, "\n", "\n", opts, dir)
+	})
+}
+
+func TestRepositoriesService_ExtractArchive_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/tarball", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	err := client.Repositories.ExtractArchive(ctx, "o", "r", nil, t.TempDir())
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("Repositories.ExtractArchive returned error %v, want *ErrorResponse", err)
+	}
+}
+
+func TestExtractTarball_rejectsEscapes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+	tests := map[string][]testTarEntry{
+		"parent path": {
+			{name: "o-r-abc123/../../evil", typeflag: tar.TypeReg, mode: 0o644, body: "x"},
+		},
+		"absolute link": {
+			{name: "o-r-abc123/evil", typeflag: tar.TypeSymlink, link: "/etc/passwd"},
+		},
+		"relative link": {
+			{name: "o-r-abc123/evil", typeflag: tar.TypeSymlink, link: "../../outside"},
+		},
+		"write through link": {
+			{name: "o-r-abc123/a", typeflag: tar.TypeSymlink, link: "b/../.."},
+		},
+	}
+
+	for name, entries := range tests {
+		t.Run(name, func(t *testing.T) {
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "out")
+			zr, err := gzip.NewReader(bytes.NewReader(testTarball(t, entries)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := extractTarball(zr, dir); err == nil {
+				t.Error("extractTarball returned nil error, want error")
+			}
+			if _, err := os.Lstat(filepath.Join(parent, "evil")); err == nil {
+				t.Error("extractTarball wrote outside the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarball_symlinkChain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "out")
+
+	// Each link is lexically inside the archive, but M resolves to the
+	// parent of dir on disk.
+	zr, err := gzip.NewReader(bytes.NewReader(testTarball(t, []testTarEntry{
+		{name: "o-r-abc123/a/b/c/L", typeflag: tar.TypeSymlink, link: "../../.."},
+		{name: "o-r-abc123/a/b/c/M", typeflag: tar.TypeSymlink, link: "L/.."},
+		{name: "o-r-abc123/a/b/c/M/escaped/deep/f", typeflag: tar.TypeReg, mode: 0o644, body: "x"},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := extractTarball(zr, dir); err == nil {
+		t.Error("extractTarball returned nil error, want error")
+	}
+	if _, err := os.Lstat(filepath.Join(parent, "escaped")); err == nil {
+		t.Error("extractTarball created a directory outside the destination")
+	}
+}
+
+func TestExtractTarball_existingSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "out")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(parent, filepath.Join(dir, "sub")); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(testTarball(t, []testTarEntry{
+		{name: "o-r-abc123/sub/evil", typeflag: tar.TypeReg, mode: 0o644, body: "x"},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := extractTarball(zr, dir); err == nil {
+		t.Error("extractTarball returned nil error, want error")
+	}
+	if _, err := os.Lstat(filepath.Join(parent, "evil")); err == nil {
+		t.Error("extractTarball wrote through a symbolic link")
+	}
+}
+
+func TestRepositoriesService_GetArchiveFS(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := testTarball(t, testRepoArchive)
+	mux.HandleFunc("/repos/o/r/tarball/main", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write(archive)
+	})
+
+	ctx := context.Background()
+	opts := &RepositoryContentGetOptions{Ref: "main"}
+	fsys, err := client.Repositories.GetArchiveFS(ctx, "o", "r", opts)
+	if err != nil {
+		t.Fatalf("Repositories.GetArchiveFS returned error: %v", err)
+	}
+
+	if err := fstest.TestFS(fsys, "README.md", "LINK.md", "bin/run.sh", "docs/guide/intro.md"); err != nil {
+		t.Error(err)
+	}
+
+	got, err := fs.ReadFile(fsys, "docs/run/run.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "#!/bin/sh\n"; string(got) != want {
+		t.Errorf("docs/run/run.sh = %q, want %q", got, want)
+	}
+
+	fi, err := fs.Stat(fsys, "bin/run.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fs.FileMode(0o755); fi.Mode() != want {
+		t.Errorf("bin/run.sh mode = %v, want %v", fi.Mode(), want)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+		if e.Name() == "LINK.md" && e.Type() != fs.ModeSymlink {
+			t.Errorf("LINK.md type = %v, want %v", e.Type(), fs.ModeSymlink)
+		}
+	}
+	if want := []string{"LINK.md", "README.md", "bin", "docs"}; !cmp.Equal(names, want) {
+		t.Errorf("ReadDir(.) = %v, want %v", names, want)
+	}
+
+	const methodName = "GetArchiveFS"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Repositories.GetArchiveFS(ctx, "\n", "\n", opts)
+		return err
+	})
+}
+
+func TestArchiveFS_escapingLink(t *testing.T) {
+	zr, err := gzip.NewReader(bytes.NewReader(testTarball(t, []testTarEntry{
+		{name: "o-r-abc123/up", typeflag: tar.TypeSymlink, link: "../outside"},
+		{name: "o-r-abc123/loop", typeflag: tar.TypeSymlink, link: "loop"},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := newArchiveFS(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"up", "loop", "../x"} {
+		if _, err := fsys.Open(name); err == nil {
+			t.Errorf("Open(%q) returned nil error, want error", name)
+		}
+	}
+}
@@ -357,3 +357,20 @@ func (s *RepositoriesService) GetArchiveLink(ctx context.Context, owner, repo st
 
 	return parsedURL, newResponse(resp), nil
 }
+
+// DownloadArchiveToFile downloads an archive of a repository to path,
+// resuming interrupted transfers and verifying the checksum in opts, if
+// any. The file at path is only replaced once the download is complete.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/contents#download-a-repository-archive-tar
+// GitHub API docs: https://docs.github.com/rest/repos/contents#download-a-repository-archive-zip
+//
+//meta:operation GET /repos/{owner}/{repo}/tarball/{ref}
+//meta:operation GET /repos/{owner}/{repo}/zipball/{ref}
+func (s *RepositoriesService) DownloadArchiveToFile(ctx context.Context, owner, repo string, archiveformat ArchiveFormat, opts *RepositoryContentGetOptions, path string, downloadOpts *DownloadToFileOptions) error {
+	u := fmt.Sprintf("repos/%s/%s/%s", owner, repo, archiveformat)
+	if opts != nil && opts.Ref != "" {
+		u += fmt.Sprintf("/%s", opts.Ref)
+	}
+	return s.client.downloadToFile(ctx, u, path, downloadOpts)
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetAllCustomPropertyValues gets all custom property values that are set for a repository.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/properties#get-all-custom-property-values-for-a-repository
+//
+//meta:operation GET /repos/{owner}/{repo}/properties/values
+func (s *RepositoriesService) GetAllCustomPropertyValues(ctx context.Context, org, repo string) ([]*CustomPropertyValue, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/properties/values", org, repo)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var customPropertiesValues []*CustomPropertyValue
+	resp, err := s.client.Do(ctx, req, &customPropertiesValues)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return customPropertiesValues, resp, nil
+}
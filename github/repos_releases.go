@@ -66,13 +66,16 @@ type GenerateNotesOptions struct {
 
 // ReleaseAsset represents a GitHub release asset in a repository.
 type ReleaseAsset struct {
-	ID                 *int64     `json:"id,omitempty"`
-	URL                *string    `json:"url,omitempty"`
-	Name               *string    `json:"name,omitempty"`
-	Label              *string    `json:"label,omitempty"`
-	State              *string    `json:"state,omitempty"`
-	ContentType        *string    `json:"content_type,omitempty"`
-	Size               *int       `json:"size,omitempty"`
+	ID          *int64  `json:"id,omitempty"`
+	URL         *string `json:"url,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Label       *string `json:"label,omitempty"`
+	State       *string `json:"state,omitempty"`
+	ContentType *string `json:"content_type,omitempty"`
+	Size        *int    `json:"size,omitempty"`
+	// Digest is the checksum of the asset's content, such as
+	// "sha256:<hex>". It is not reported for every asset.
+	Digest             *string    `json:"digest,omitempty"`
 	DownloadCount      *int       `json:"download_count,omitempty"`
 	CreatedAt          *Timestamp `json:"created_at,omitempty"`
 	UpdatedAt          *Timestamp `json:"updated_at,omitempty"`
@@ -436,7 +439,7 @@ func (s *RepositoriesService) DeleteReleaseAsset(ctx context.Context, owner, rep
 }
 
 // UploadReleaseAsset creates an asset by uploading a file into a release repository.
-// To upload assets that cannot be represented by an os.File, call NewUploadRequest directly.
+// To upload assets that cannot be represented by an os.File, use UploadReleaseAssetFromReader.
 //
 // GitHub API docs: https://docs.github.com/rest/releases/assets#upload-a-release-asset
 //
@@ -0,0 +1,82 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DownloadReleaseAssetToFile downloads a release asset to path, resuming
+// interrupted transfers and verifying the checksum in opts, if any. The
+// file at path is only replaced once the download is complete and verified.
+//
+// GitHub API docs: https://docs.github.com/rest/releases/assets#get-a-release-asset
+//
+//meta:operation GET /repos/{owner}/{repo}/releases/assets/{asset_id}
+func (s *RepositoriesService) DownloadReleaseAssetToFile(ctx context.Context, owner, repo string, id int64, path string, opts *DownloadToFileOptions) error {
+	u := fmt.Sprintf("repos/%s/%s/releases/assets/%d", owner, repo, id)
+	return s.client.downloadToFile(ctx, u, path, opts, func(req *http.Request) {
+		req.Header.Set("Accept", defaultMediaType)
+	})
+}
+
+// GetReleaseAssetChecksum looks up the checksum of the asset called name
+// in a checksums asset of the same release, such as "SHA256SUMS" or
+// "checksums.txt", in the format written by sha256sum. The result is
+// suitable for DownloadToFileOptions.Checksum.
+//
+// GitHub API docs: https://docs.github.com/rest/releases/assets#get-a-release-asset
+// GitHub API docs: https://docs.github.com/rest/releases/assets#list-release-assets
+//
+//meta:operation GET /repos/{owner}/{repo}/releases/assets/{asset_id}
+//meta:operation GET /repos/{owner}/{repo}/releases/{release_id}/assets
+func (s *RepositoriesService) GetReleaseAssetChecksum(ctx context.Context, owner, repo string, releaseID int64, checksumsAsset, name string) (string, error) {
+	var sumsID int64
+	opts := &ListOptions{PerPage: 100}
+	for sumsID == 0 {
+		assets, resp, err := s.ListReleaseAssets(ctx, owner, repo, releaseID, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, a := range assets {
+			if a.GetName() == checksumsAsset {
+				sumsID = a.GetID()
+				break
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if sumsID == 0 {
+		return "", fmt.Errorf("release %v has no asset %q", releaseID, checksumsAsset)
+	}
+
+	rc, _, err := s.DownloadReleaseAsset(ctx, owner, repo, sumsID, http.DefaultClient)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	sums, err := parseChecksums(rc)
+	if err != nil {
+		return "", err
+	}
+	sum, ok := sums[name]
+	if !ok {
+		return "", fmt.Errorf("%v has no checksum for %q", checksumsAsset, name)
+	}
+	// Validate the digest so a malformed entry is reported here rather
+	// than when the download is verified.
+	algo, digest, _, err := parseChecksum(sum)
+	if err != nil {
+		return "", err
+	}
+	return algo + ":" + digest, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRepositoriesService_GetReleaseAssetChecksum(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	sum := strings.Repeat("ab", 32)
+	mux.HandleFunc("/repos/o/r/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":2,"name":"tool_linux_amd64.tar.gz"},{"id":3,"name":"SHA256SUMS"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/assets/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", defaultMediaType)
+		fmt.Fprintf(w, "# generated\n%v  tool_darwin_arm64.tar.gz\n%v *tool_linux_amd64.tar.gz\n", strings.Repeat("cd", 32), strings.ToUpper(sum))
+	})
+
+	ctx := context.Background()
+	got, err := client.Repositories.GetReleaseAssetChecksum(ctx, "o", "r", 1, "SHA256SUMS", "tool_linux_amd64.tar.gz")
+	if err != nil {
+		t.Fatalf("Repositories.GetReleaseAssetChecksum returned error: %v", err)
+	}
+	if want := "sha256:" + sum; got != want {
+		t.Errorf("Repositories.GetReleaseAssetChecksum returned %v, want %v", got, want)
+	}
+
+	if _, err := client.Repositories.GetReleaseAssetChecksum(ctx, "o", "r", 1, "SHA256SUMS", "tool_windows.zip"); err == nil {
+		t.Error("Repositories.GetReleaseAssetChecksum returned nil error for an unlisted asset, want error")
+	}
+	if _, err := client.Repositories.GetReleaseAssetChecksum(ctx, "o", "r", 1, "checksums.txt", "tool_linux_amd64.tar.gz"); err == nil {
+		t.Error("Repositories.GetReleaseAssetChecksum returned nil error for a missing checksums asset, want error")
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ReleaseAssetSource describes an asset to upload with
+// RepositoriesService.PublishRelease.
+type ReleaseAssetSource struct {
+	Name      string
+	Label     string
+	MediaType string
+	// Size is the number of bytes Open's reader produces, or -1 if it is
+	// unknown. An asset left by an earlier run is only reused if its size
+	// is known and matches and GitHub reports a SHA-256 digest of it that
+	// matches the content.
+	Size int64
+	// Open returns the content of the asset. It may be called more than
+	// once, for example to compute a checksum and then to upload.
+	Open func() (io.ReadCloser, error)
+}
+
+// PublishReleaseOptions specifies the release created by
+// RepositoriesService.PublishRelease.
+type PublishReleaseOptions struct {
+	// TagName is the tag to release. It is required.
+	TagName string
+	// TargetCommitish is the branch or commit the tag is created at if it
+	// does not exist yet.
+	TargetCommitish string
+	// PreviousTagName is the tag release notes are generated from. It
+	// defaults to the previous release.
+	PreviousTagName string
+
+	Name       string
+	Body       string
+	Prerelease bool
+	// MakeLatest can be one of: "true", "false", or "legacy".
+	MakeLatest string
+	// GenerateNotes appends release notes generated by GitHub to Body, and
+	// uses the generated name if Name is empty.
+	GenerateNotes bool
+
+	Assets []*ReleaseAssetSource
+	// ChecksumsAssetName, if set, is the name of an asset listing the
+	// SHA-256 checksum of every other asset, in the format written by
+	// sha256sum, for example "SHA256SUMS".
+	ChecksumsAssetName string
+	// UploadOptions are passed to UploadReleaseAssetFromReader.
+	UploadOptions *ReleaseAssetUploadOptions
+
+	// KeepDraftOnFailure keeps the draft release when a step before
+	// publishing fails, so that running PublishRelease again resumes it.
+	// By default a draft created by the failed call, and the tag if it was
+	// created, are removed. A draft reused from an earlier call is kept.
+	KeepDraftOnFailure bool
+}
+
+// PublishRelease cuts a release in one call: it finds or creates the tag,
+// generates release notes, creates a draft release, uploads the assets and
+// a checksums file, and finally publishes the release.
+//
+// It is safe to call again after a failure. A draft release for the same
+// tag is reused, and assets it already has with the expected content are
+// not uploaded again. If the release has already been published, it is
+// returned unchanged.
+//
+// GitHub API docs: https://docs.github.com/rest/commits/commits#get-a-commit
+// GitHub API docs: https://docs.github.com/rest/git/refs#create-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#delete-a-reference
+// GitHub API docs: https://docs.github.com/rest/git/refs#get-a-reference
+// GitHub API docs: https://docs.github.com/rest/releases/assets#delete-a-release-asset
+// GitHub API docs: https://docs.github.com/rest/releases/assets#list-release-assets
+// GitHub API docs: https://docs.github.com/rest/releases/assets#upload-a-release-asset
+// GitHub API docs: https://docs.github.com/rest/releases/releases#create-a-release
+// GitHub API docs: https://docs.github.com/rest/releases/releases#delete-a-release
+// GitHub API docs: https://docs.github.com/rest/releases/releases#generate-release-notes-content-for-a-release
+// GitHub API docs: https://docs.github.com/rest/releases/releases#list-releases
+// GitHub API docs: https://docs.github.com/rest/releases/releases#update-a-release
+//
+//meta:operation GET /repos/{owner}/{repo}/commits/{ref}
+//meta:operation GET /repos/{owner}/{repo}/git/ref/{ref}
+//meta:operation POST /repos/{owner}/{repo}/git/refs
+//meta:operation DELETE /repos/{owner}/{repo}/git/refs/{ref}
+//meta:operation GET /repos/{owner}/{repo}/releases
+//meta:operation POST /repos/{owner}/{repo}/releases
+//meta:operation DELETE /repos/{owner}/{repo}/releases/assets/{asset_id}
+//meta:operation POST /repos/{owner}/{repo}/releases/generate-notes
+//meta:operation DELETE /repos/{owner}/{repo}/releases/{release_id}
+//meta:operation PATCH /repos/{owner}/{repo}/releases/{release_id}
+//meta:operation GET /repos/{owner}/{repo}/releases/{release_id}/assets
+//meta:operation POST /repos/{owner}/{repo}/releases/{release_id}/assets
+func (s *RepositoriesService) PublishRelease(ctx context.Context, owner, repo string, opts *PublishReleaseOptions) (*RepositoryRelease, error) {
+	if opts == nil || opts.TagName == "" {
+		return nil, errors.New("a tag name is required to publish a release")
+	}
+
+	existing, err := s.findReleaseByTag(ctx, owner, repo, opts.TagName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !existing.GetDraft() {
+		return existing, nil
+	}
+
+	createdTag, err := s.ensureTag(ctx, owner, repo, opts.TagName, opts.TargetCommitish)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := existing
+	release, err := s.publishDraft(ctx, owner, repo, opts, &draft)
+	if err == nil {
+		return release, nil
+	}
+	if opts.KeepDraftOnFailure {
+		return nil, err
+	}
+
+	// Roll back everything this run left behind. The release is fetched
+	// again first: publishing may have succeeded even though the response
+	// was lost, and a published release must not be deleted.
+	var rollback []string
+	if draft != nil {
+		current, _, rerr := s.GetRelease(ctx, owner, repo, draft.GetID())
+		switch {
+		case rerr != nil:
+			return nil, fmt.Errorf("%w (rollback failed: fetching release: %v)", err, rerr)
+		case !current.GetDraft():
+			return current, nil
+		case existing == nil:
+			if _, rerr := s.DeleteRelease(ctx, owner, repo, draft.GetID()); rerr != nil {
+				rollback = append(rollback, fmt.Sprintf("deleting draft release: %v", rerr))
+			}
+		}
+	}
+	if createdTag {
+		if _, rerr := s.client.Git.DeleteRef(ctx, owner, repo, "tags/"+opts.TagName); rerr != nil {
+			rollback = append(rollback, fmt.Sprintf("deleting tag: %v", rerr))
+		}
+	}
+	if len(rollback) > 0 {
+		return nil, fmt.Errorf("%w (rollback failed: %v)", err, strings.Join(rollback, "; "))
+	}
+	return nil, err
+}
+
+// publishDraft creates or updates the draft release, uploads its assets
+// and publishes it. *draft is set as soon as the draft exists so that the
+// caller can roll it back.
+func (s *RepositoriesService) publishDraft(ctx context.Context, owner, repo string, opts *PublishReleaseOptions, draft **RepositoryRelease) (*RepositoryRelease, error) {
+	name, body := opts.Name, opts.Body
+	if opts.GenerateNotes {
+		notesOpts := &GenerateNotesOptions{TagName: opts.TagName}
+		if opts.PreviousTagName != "" {
+			notesOpts.PreviousTagName = String(opts.PreviousTagName)
+		}
+		if opts.TargetCommitish != "" {
+			notesOpts.TargetCommitish = String(opts.TargetCommitish)
+		}
+		notes, _, err := s.GenerateReleaseNotes(ctx, owner, repo, notesOpts)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = notes.Name
+		}
+		if body == "" {
+			body = notes.Body
+		} else {
+			body += "\n\n" + notes.Body
+		}
+	}
+
+	rel := &RepositoryRelease{
+		TagName:    String(opts.TagName),
+		Draft:      Bool(true),
+		Prerelease: Bool(opts.Prerelease),
+	}
+	if name != "" {
+		rel.Name = String(name)
+	}
+	if body != "" {
+		rel.Body = String(body)
+	}
+	if opts.TargetCommitish != "" {
+		rel.TargetCommitish = String(opts.TargetCommitish)
+	}
+
+	if *draft == nil {
+		created, _, err := s.CreateRelease(ctx, owner, repo, rel)
+		if err != nil {
+			return nil, err
+		}
+		*draft = created
+	} else if _, _, err := s.EditRelease(ctx, owner, repo, (*draft).GetID(), rel); err != nil {
+		return nil, err
+	}
+	id := (*draft).GetID()
+
+	uploaded, err := s.listAllReleaseAssets(ctx, owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var sums strings.Builder
+	for _, a := range opts.Assets {
+		if err := s.uploadReleaseAssetSource(ctx, owner, repo, id, a, uploaded[a.Name], opts.UploadOptions); err != nil {
+			return nil, fmt.Errorf("uploading %v: %w", a.Name, err)
+		}
+		if opts.ChecksumsAssetName != "" {
+			sum, err := sha256Source(a)
+			if err != nil {
+				return nil, fmt.Errorf("checksumming %v: %w", a.Name, err)
+			}
+			fmt.Fprintf(&sums, "%x  %v\n", sum, a.Name)
+		}
+	}
+
+	if opts.ChecksumsAssetName != "" {
+		// The checksums of a resumed release may be stale, so they are
+		// always uploaded afresh.
+		if old := uploaded[opts.ChecksumsAssetName]; old != nil {
+			if _, err := s.DeleteReleaseAsset(ctx, owner, repo, old.GetID()); err != nil {
+				return nil, err
+			}
+		}
+		content := sums.String()
+		uploadOpts := &UploadOptions{Name: opts.ChecksumsAssetName, MediaType: "text/plain; charset=utf-8"}
+		if _, _, err := s.UploadReleaseAssetFromReader(ctx, owner, repo, id, uploadOpts, strings.NewReader(content), int64(len(content)), opts.UploadOptions); err != nil {
+			return nil, fmt.Errorf("uploading %v: %w", opts.ChecksumsAssetName, err)
+		}
+	}
+
+	publish := &RepositoryRelease{Draft: Bool(false)}
+	if opts.MakeLatest != "" {
+		publish.MakeLatest = String(opts.MakeLatest)
+	}
+	release, _, err := s.EditRelease(ctx, owner, repo, id, publish)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// findReleaseByTag returns the release, draft or not, for tag. Unlike
+// GetReleaseByTag, it also finds draft releases.
+func (s *RepositoriesService) findReleaseByTag(ctx context.Context, owner, repo, tag string) (*RepositoryRelease, error) {
+	opts := &ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := s.ListReleases(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			if r.GetTagName() == tag {
+				return r, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ensureTag creates a lightweight tag at target unless the tag exists. It
+// reports whether the tag was created.
+func (s *RepositoriesService) ensureTag(ctx context.Context, owner, repo, tag, target string) (bool, error) {
+	_, resp, err := s.client.Git.GetRef(ctx, owner, repo, "tags/"+tag)
+	if err == nil {
+		return false, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return false, err
+	}
+	if target == "" {
+		return false, fmt.Errorf("tag %q does not exist and no target commitish was given", tag)
+	}
+
+	sha, _, err := s.GetCommitSHA1(ctx, owner, repo, target, "")
+	if err != nil {
+		return false, err
+	}
+	if _, _, err := s.client.Git.CreateRef(ctx, owner, repo, &Reference{
+		Ref:    String("refs/tags/" + tag),
+		Object: &GitObject{SHA: String(sha)},
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *RepositoriesService) listAllReleaseAssets(ctx context.Context, owner, repo string, id int64) (map[string]*ReleaseAsset, error) {
+	assets := make(map[string]*ReleaseAsset)
+	opts := &ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.ListReleaseAssets(ctx, owner, repo, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			assets[a.GetName()] = a
+		}
+		if resp.NextPage == 0 {
+			return assets, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// uploadReleaseAssetSource uploads a unless old is a complete upload of
+// the same content. Any other old asset is replaced, including one whose
+// content cannot be compared.
+func (s *RepositoriesService) uploadReleaseAssetSource(ctx context.Context, owner, repo string, id int64, a *ReleaseAssetSource, old *ReleaseAsset, uploadOpts *ReleaseAssetUploadOptions) error {
+	if old != nil {
+		digest := old.GetDigest()
+		if old.GetState() == "uploaded" && a.Size >= 0 && int64(old.GetSize()) == a.Size && strings.HasPrefix(digest, "sha256:") {
+			sum, err := sha256Source(a)
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(strings.TrimPrefix(digest, "sha256:"), hex.EncodeToString(sum)) {
+				return nil
+			}
+		}
+		if _, err := s.DeleteReleaseAsset(ctx, owner, repo, old.GetID()); err != nil {
+			return err
+		}
+	}
+
+	rc, err := a.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	opts := &UploadOptions{Name: a.Name, Label: a.Label, MediaType: a.MediaType}
+	_, _, err = s.UploadReleaseAssetFromReader(ctx, owner, repo, id, opts, rc, a.Size, uploadOpts)
+	return err
+}
+
+func sha256Source(a *ReleaseAssetSource) ([]byte, error) {
+	rc, err := a.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
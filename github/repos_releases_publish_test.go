@@ -0,0 +1,341 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func stringAssetSource(name, content string) *ReleaseAssetSource {
+	return &ReleaseAssetSource{
+		Name: name,
+		Size: int64(len(content)),
+		Open: func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(content)), nil },
+	}
+}
+
+func unknownSize(a *ReleaseAssetSource) *ReleaseAssetSource {
+	a.Size = -1
+	return a
+}
+
+func TestRepositoriesService_PublishRelease(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var steps []string
+	step := func(s string) { steps = append(steps, s) }
+
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			step("list releases")
+			// A draft left behind by an earlier, interrupted run.
+			fmt.Fprint(w, `[{"id":2,"tag_name":"v0.9.0"},{"id":1,"tag_name":"v1.0.0","draft":true}]`)
+		default:
+			t.Errorf("unexpected %v %v", r.Method, r.URL)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		step("get tag")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/r/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		step("resolve target")
+		fmt.Fprint(w, "abc123")
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"ref":"refs/tags/v1.0.0","sha":"abc123"}`+"\n")
+		step("create tag")
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.0"}`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/generate-notes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"tag_name":"v1.0.0","previous_tag_name":"v0.9.0","target_commitish":"main"}`+"\n")
+		step("generate notes")
+		fmt.Fprint(w, `{"name":"v1.0.0","body":"* Fixed things"}`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"draft":false`) {
+			step("publish")
+			if want := `{"draft":false,"make_latest":"true"}` + "\n"; string(body) != want {
+				t.Errorf("publish request body is %s, want %s", body, want)
+			}
+			fmt.Fprint(w, `{"id":1,"tag_name":"v1.0.0","draft":false}`)
+			return
+		}
+		step("update draft")
+		if want := `{"tag_name":"v1.0.0","target_commitish":"main","name":"v1.0.0","body":"Highlights\n\n* Fixed things","draft":true,"prerelease":false}` + "\n"; string(body) != want {
+			t.Errorf("update request body is %s, want %s", body, want)
+		}
+		fmt.Fprint(w, `{"id":1,"tag_name":"v1.0.0","draft":true}`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			step("list assets")
+			// a.txt is complete; c.txt has the right size but other
+			// content, and d.txt has no digest to compare.
+			fmt.Fprintf(w, `[
+				{"id":10,"name":"a.txt","size":3,"state":"uploaded","digest":"sha256:%x"},
+				{"id":11,"name":"b.txt","size":1,"state":"starter"},
+				{"id":12,"name":"SHA256SUMS","size":5,"state":"uploaded"},
+				{"id":13,"name":"c.txt","size":2,"state":"uploaded","digest":"sha256:%x"},
+				{"id":14,"name":"d.txt","size":2,"state":"uploaded"},
+				{"id":15,"name":"e.txt","size":2,"state":"uploaded","digest":"sha256:%x"}
+			]`, sha256.Sum256([]byte("aaa")), sha256.Sum256([]byte("xx")), sha256.Sum256([]byte("ee")))
+		case "POST":
+			name := r.URL.Query().Get("name")
+			step("upload " + name)
+			body, _ := io.ReadAll(r.Body)
+			if name == "SHA256SUMS" {
+				var want string
+				for _, f := range []string{"a.txt:aaa", "b.txt:bbbb", "c.txt:cc", "d.txt:dd", "e.txt:ee"} {
+					kv := strings.SplitN(f, ":", 2)
+					want += fmt.Sprintf("%x  %v\n", sha256.Sum256([]byte(kv[1])), kv[0])
+				}
+				if string(body) != want {
+					t.Errorf("checksums file is %q, want %q", body, want)
+				}
+			}
+			fmt.Fprintf(w, `{"name":%q}`, name)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/releases/assets/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		step("delete asset " + strings.TrimPrefix(r.URL.Path, "/repos/o/r/releases/assets/"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	release, err := client.Repositories.PublishRelease(ctx, "o", "r", &PublishReleaseOptions{
+		TagName:         "v1.0.0",
+		TargetCommitish: "main",
+		PreviousTagName: "v0.9.0",
+		Body:            "Highlights",
+		GenerateNotes:   true,
+		MakeLatest:      "true",
+		Assets: []*ReleaseAssetSource{
+			stringAssetSource("a.txt", "aaa"),
+			stringAssetSource("b.txt", "bbbb"),
+			stringAssetSource("c.txt", "cc"),
+			stringAssetSource("d.txt", "dd"),
+			unknownSize(stringAssetSource("e.txt", "ee")),
+		},
+		ChecksumsAssetName: "SHA256SUMS",
+	})
+	if err != nil {
+		t.Fatalf("Repositories.PublishRelease returned error: %v", err)
+	}
+	if release.GetDraft() || release.GetID() != 1 {
+		t.Errorf("Repositories.PublishRelease returned %+v, want published release 1", release)
+	}
+
+	want := []string{
+		"list releases",
+		"get tag",
+		"resolve target",
+		"create tag",
+		"generate notes",
+		"update draft",
+		"list assets",
+		"delete asset 11",
+		"upload b.txt",
+		"delete asset 13",
+		"upload c.txt",
+		"delete asset 14",
+		"upload d.txt",
+		// An asset of unknown size is always uploaded again.
+		"delete asset 15",
+		"upload e.txt",
+		"delete asset 12",
+		"upload SHA256SUMS",
+		"publish",
+	}
+	if !cmp.Equal(steps, want) {
+		t.Errorf("Repositories.PublishRelease steps differ (-want +got):\n%v", cmp.Diff(want, steps))
+	}
+}
+
+func TestRepositoriesService_PublishRelease_alreadyPublished(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":1,"tag_name":"v1.0.0","draft":false}]`)
+	})
+
+	release, err := client.Repositories.PublishRelease(context.Background(), "o", "r", &PublishReleaseOptions{TagName: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("Repositories.PublishRelease returned error: %v", err)
+	}
+	if release.GetID() != 1 {
+		t.Errorf("Repositories.PublishRelease returned %+v, want the existing release", release)
+	}
+}
+
+func TestRepositoriesService_PublishRelease_rollback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var deletedRelease, deletedTag bool
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[]`)
+		case "POST":
+			testBody(t, r, `{"tag_name":"v2","target_commitish":"main","draft":true,"prerelease":true}`+"\n")
+			fmt.Fprint(w, `{"id":5,"tag_name":"v2","draft":true}`)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/r/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "abc123")
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v2"}`)
+	})
+	mux.HandleFunc("/repos/o/r/git/refs/tags/v2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deletedTag = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/releases/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"id":5,"tag_name":"v2","draft":true}`)
+			return
+		}
+		testMethod(t, r, "DELETE")
+		deletedRelease = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/releases/5/assets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		http.Error(w, `{"message":"Bad Request"}`, http.StatusBadRequest)
+	})
+
+	opts := &PublishReleaseOptions{
+		TagName:         "v2",
+		TargetCommitish: "main",
+		Prerelease:      true,
+		Assets:          []*ReleaseAssetSource{stringAssetSource("a.txt", "aaa")},
+	}
+	if _, err := client.Repositories.PublishRelease(context.Background(), "o", "r", opts); err == nil {
+		t.Fatal("Repositories.PublishRelease returned nil error, want error")
+	}
+	if !deletedRelease || !deletedTag {
+		t.Errorf("Repositories.PublishRelease rolled back release: %v, tag: %v; want both", deletedRelease, deletedTag)
+	}
+
+	deletedRelease, deletedTag = false, false
+	opts.KeepDraftOnFailure = true
+	if _, err := client.Repositories.PublishRelease(context.Background(), "o", "r", opts); err == nil {
+		t.Fatal("Repositories.PublishRelease returned nil error, want error")
+	}
+	if deletedRelease || deletedTag {
+		t.Error("Repositories.PublishRelease rolled back with KeepDraftOnFailure set")
+	}
+
+	if _, err := client.Repositories.PublishRelease(context.Background(), "o", "r", nil); err == nil {
+		t.Error("Repositories.PublishRelease returned nil error without a tag name, want error")
+	}
+}
+
+func TestRepositoriesService_PublishRelease_rollbackReusedDraft(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":5,"tag_name":"v2","draft":true}]`)
+	})
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v2"}`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":5,"tag_name":"v2","draft":true}`)
+		case "PATCH":
+			fmt.Fprint(w, `{"id":5,"tag_name":"v2","draft":true}`)
+		default:
+			t.Errorf("Request method: %v, want GET or PATCH", r.Method)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/releases/5/assets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		http.Error(w, `{"message":"Bad Request"}`, http.StatusBadRequest)
+	})
+
+	opts := &PublishReleaseOptions{
+		TagName: "v2",
+		Assets:  []*ReleaseAssetSource{stringAssetSource("a.txt", "aaa")},
+	}
+	if _, err := client.Repositories.PublishRelease(context.Background(), "o", "r", opts); err == nil {
+		t.Fatal("Repositories.PublishRelease returned nil error, want error")
+	}
+}
+
+func TestRepositoriesService_PublishRelease_publishResponseLost(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var published bool
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[]`)
+		case "POST":
+			fmt.Fprint(w, `{"id":5,"tag_name":"v2","draft":true}`)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/git/ref/tags/v2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v2"}`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"id":5,"tag_name":"v2","draft":%v}`, !published)
+		case "PATCH":
+			// The release is published, but the client sees an error.
+			published = true
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			t.Errorf("Request method: %v, want GET or PATCH", r.Method)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/releases/5/assets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	release, err := client.Repositories.PublishRelease(context.Background(), "o", "r", &PublishReleaseOptions{TagName: "v2"})
+	if err != nil {
+		t.Fatalf("Repositories.PublishRelease returned error: %v", err)
+	}
+	if release.GetID() != 5 || release.GetDraft() {
+		t.Errorf("Repositories.PublishRelease returned %+v, want the published release", release)
+	}
+}
@@ -770,6 +770,7 @@ func TestReleaseAsset_Marshal(t *testing.T) {
 		State:              String("state"),
 		ContentType:        String("ct"),
 		Size:               Int(1),
+		Digest:             String("sha256:abc"),
 		DownloadCount:      Int(1),
 		CreatedAt:          &Timestamp{referenceTime},
 		UpdatedAt:          &Timestamp{referenceTime},
@@ -786,6 +787,7 @@ func TestReleaseAsset_Marshal(t *testing.T) {
 		"state": "state",
 		"content_type": "ct",
 		"size": 1,
+		"digest": "sha256:abc",
 		"download_count": 1,
 		"created_at": ` + referenceTimeStr + `,
 		"updated_at": ` + referenceTimeStr + `,
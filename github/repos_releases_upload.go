@@ -0,0 +1,272 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// sniffLen is the number of bytes http.DetectContentType considers.
+	sniffLen = 512
+
+	defaultUploadRetryDelay = time.Second
+)
+
+// ReleaseAssetUploadOptions specifies optional parameters to the
+// RepositoriesService.UploadReleaseAssetFromReader method.
+type ReleaseAssetUploadOptions struct {
+	// Progress, if set, is called after every chunk of the asset is sent
+	// with the number of bytes sent so far and the total size. It starts
+	// again from zero when an upload is retried.
+	Progress func(sent, total int64)
+
+	// MaxRetries is the number of times a failed upload is retried.
+	// Before each retry, any partially uploaded asset with the same name
+	// is deleted from the release. If the failed attempt did upload the
+	// asset completely, and its size matches, that asset is returned
+	// instead; if its size does not match, the upload fails. An asset with
+	// the same name that exists before the first attempt is never
+	// replaced or returned: the upload fails with GitHub's 422 error.
+	MaxRetries int
+
+	// RetryDelay is the delay before the first retry. It is doubled for
+	// every further retry and defaults to one second.
+	RetryDelay time.Duration
+
+	// TempDir is the directory used to spool readers whose size is
+	// unknown or that must be re-read for a retry. It defaults to
+	// os.TempDir.
+	TempDir string
+}
+
+// UploadReleaseAssetFromReader creates an asset by uploading the contents
+// of r into a release. opts.Name is required.
+//
+// size is the number of bytes r will produce. If it is negative, r is
+// first spooled to a temporary file to measure it. A temporary file is
+// also used when retries are requested and r is not an io.ReadSeeker.
+//
+// The media type is taken from opts.MediaType, then from the extension of
+// opts.Name and finally by sniffing the first bytes of the content.
+//
+// GitHub API docs: https://docs.github.com/rest/releases/assets#delete-a-release-asset
+// GitHub API docs: https://docs.github.com/rest/releases/assets#list-release-assets
+// GitHub API docs: https://docs.github.com/rest/releases/assets#upload-a-release-asset
+//
+//meta:operation DELETE /repos/{owner}/{repo}/releases/assets/{asset_id}
+//meta:operation GET /repos/{owner}/{repo}/releases/{release_id}/assets
+//meta:operation POST /repos/{owner}/{repo}/releases/{release_id}/assets
+func (s *RepositoriesService) UploadReleaseAssetFromReader(ctx context.Context, owner, repo string, id int64, opts *UploadOptions, r io.Reader, size int64, uploadOpts *ReleaseAssetUploadOptions) (*ReleaseAsset, *Response, error) {
+	if opts == nil || opts.Name == "" {
+		return nil, nil, errors.New("the asset to upload must have a name")
+	}
+	if uploadOpts == nil {
+		uploadOpts = &ReleaseAssetUploadOptions{}
+	}
+
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets", owner, repo, id)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, ok := r.(io.ReadSeeker)
+	if !ok && (size < 0 || uploadOpts.MaxRetries > 0) {
+		f, n, err := spoolToTempFile(r, uploadOpts.TempDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() {
+			f.Close()
+			os.Remove(f.Name())
+		}()
+		body, size = f, n
+	}
+
+	var start int64
+	if body != nil {
+		if start, err = body.Seek(0, io.SeekCurrent); err != nil {
+			return nil, nil, err
+		}
+		if size < 0 {
+			end, err := body.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, nil, err
+			}
+			size = end - start
+		}
+	}
+
+	mediaType := opts.MediaType
+	if mediaType == "" {
+		mediaType = mime.TypeByExtension(filepath.Ext(opts.Name))
+	}
+	if mediaType == "" {
+		var head []byte
+		if body != nil {
+			if _, err := body.Seek(start, io.SeekStart); err != nil {
+				return nil, nil, err
+			}
+			head, err = readHead(body)
+		} else {
+			head, err = readHead(r)
+			r = io.MultiReader(bytes.NewReader(head), r)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		mediaType = http.DetectContentType(head)
+	}
+
+	delay := uploadOpts.RetryDelay
+	if delay <= 0 {
+		delay = defaultUploadRetryDelay
+	}
+	for attempt := 0; ; attempt++ {
+		content := r
+		if body != nil {
+			if _, err := body.Seek(start, io.SeekStart); err != nil {
+				return nil, nil, err
+			}
+			// The transport closes the request body, so hide Close from it to
+			// keep a spooled file open for a retry.
+			content = io.NopCloser(body)
+		}
+		if uploadOpts.Progress != nil {
+			content = &progressReader{r: content, total: size, progress: uploadOpts.Progress}
+		}
+
+		req, err := s.client.NewUploadRequest(u, content, size, mediaType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		asset := new(ReleaseAsset)
+		resp, err := s.client.Do(ctx, req, asset)
+		if err == nil {
+			return asset, resp, nil
+		}
+		if attempt >= uploadOpts.MaxRetries || ctx.Err() != nil || !retryableUploadError(err, attempt > 0) {
+			return nil, resp, err
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, resp, ctx.Err()
+		case <-t.C:
+		}
+		delay *= 2
+
+		existing, listResp, listErr := s.deletePartialReleaseAsset(ctx, owner, repo, id, opts.Name)
+		if listErr != nil {
+			return nil, listResp, listErr
+		}
+		if existing != nil {
+			if int64(existing.GetSize()) == size {
+				return existing, listResp, nil
+			}
+			return nil, resp, err
+		}
+	}
+}
+
+// retryableUploadError reports whether err may be resolved by uploading
+// again. Client errors are final, except for a 422 after a retry, which
+// GitHub returns when an earlier, interrupted attempt left an asset with
+// the same name. On the first attempt a 422 means the asset existed
+// before the upload, so it is final too.
+func retryableUploadError(err error, retried bool) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return true
+	}
+	code := errResp.Response.StatusCode
+	return code >= 500 || retried && code == http.StatusUnprocessableEntity
+}
+
+// deletePartialReleaseAsset deletes the asset called name from the release
+// if it was not uploaded completely. An asset that was uploaded completely
+// is returned instead.
+func (s *RepositoriesService) deletePartialReleaseAsset(ctx context.Context, owner, repo string, id int64, name string) (*ReleaseAsset, *Response, error) {
+	opts := &ListOptions{PerPage: 100}
+	for {
+		assets, resp, err := s.ListReleaseAssets(ctx, owner, repo, id, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, a := range assets {
+			if a.GetName() != name {
+				continue
+			}
+			if a.GetState() == "uploaded" {
+				return a, resp, nil
+			}
+			resp, err := s.DeleteReleaseAsset(ctx, owner, repo, a.GetID())
+			return nil, resp, err
+		}
+		if resp.NextPage == 0 {
+			return nil, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// spoolToTempFile copies r into a new temporary file in dir, returning the
+// file and the number of bytes written. The caller removes the file.
+func spoolToTempFile(r io.Reader, dir string) (*os.File, int64, error) {
+	f, err := os.CreateTemp(dir, "go-github-upload-")
+	if err != nil {
+		return nil, 0, err
+	}
+	n, err := io.Copy(f, r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, 0, err
+	}
+	return f, n, nil
+}
+
+func readHead(r io.Reader) ([]byte, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return head[:n], err
+}
+
+// progressReader reports the number of bytes read through it.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
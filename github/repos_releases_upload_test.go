@@ -0,0 +1,239 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRepositoriesService_UploadReleaseAssetFromReader(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          *UploadOptions
+		reader        func() io.Reader
+		size          int64
+		wantMediaType string
+	}{
+		{
+			name:          "seeker with extension",
+			opts:          &UploadOptions{Name: "n.txt"},
+			reader:        func() io.Reader { return strings.NewReader("Upload me !\n") },
+			size:          12,
+			wantMediaType: "text/plain; charset=utf-8",
+		},
+		{
+			name:          "stream with sniffed type",
+			opts:          &UploadOptions{Name: "n", Label: "l"},
+			reader:        func() io.Reader { return io.MultiReader(strings.NewReader("Upload me !\n")) },
+			size:          12,
+			wantMediaType: "text/plain; charset=utf-8",
+		},
+		{
+			name:          "stream of unknown size",
+			opts:          &UploadOptions{Name: "n", MediaType: "image/png"},
+			reader:        func() io.Reader { return io.MultiReader(strings.NewReader("Upload me !\n")) },
+			size:          -1,
+			wantMediaType: "image/png",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc(fmt.Sprintf("/repos/o/r/releases/%d/assets", i), func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				testHeader(t, r, "Content-Type", tt.wantMediaType)
+				testHeader(t, r, "Content-Length", "12")
+				want := values{"name": tt.opts.Name}
+				if tt.opts.Label != "" {
+					want["label"] = tt.opts.Label
+				}
+				testFormValues(t, r, want)
+				testBody(t, r, "Upload me !\n")
+				fmt.Fprint(w, `{"id":1}`)
+			})
+
+			var progress []int64
+			uploadOpts := &ReleaseAssetUploadOptions{
+				Progress: func(sent, total int64) {
+					if total != 12 {
+						t.Errorf("Progress called with total %v, want 12", total)
+					}
+					progress = append(progress, sent)
+				},
+				TempDir: t.TempDir(),
+			}
+
+			ctx := context.Background()
+			asset, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", int64(i), tt.opts, tt.reader(), tt.size, uploadOpts)
+			if err != nil {
+				t.Fatalf("Repositories.UploadReleaseAssetFromReader returned error: %v", err)
+			}
+			if want := (&ReleaseAsset{ID: Int64(1)}); !cmp.Equal(asset, want) {
+				t.Errorf("Repositories.UploadReleaseAssetFromReader returned %+v, want %+v", asset, want)
+			}
+			if len(progress) == 0 || progress[len(progress)-1] != 12 {
+				t.Errorf("Progress reported %v, want to end at 12", progress)
+			}
+		})
+	}
+}
+
+func TestRepositoriesService_UploadReleaseAssetFromReader_retry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var uploads, deleted int
+	mux.HandleFunc("/repos/o/r/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			testBody(t, r, "payload")
+			uploads++
+			switch uploads {
+			case 1:
+				w.WriteHeader(http.StatusBadGateway)
+			case 2:
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"message":"Validation Failed","errors":[{"resource":"ReleaseAsset","code":"already_exists","field":"name"}]}`)
+			default:
+				fmt.Fprint(w, `{"id":3,"name":"n.bin","state":"uploaded"}`)
+			}
+		case "GET":
+			testFormValues(t, r, values{"per_page": "100"})
+			fmt.Fprint(w, `[{"id":1,"name":"other","state":"starter"},{"id":2,"name":"n.bin","state":"starter"}]`)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/releases/assets/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	opts := &UploadOptions{Name: "n.bin"}
+	reader := io.MultiReader(strings.NewReader("payload"))
+	asset, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, opts, reader, 7, &ReleaseAssetUploadOptions{MaxRetries: 2, RetryDelay: time.Millisecond, TempDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Repositories.UploadReleaseAssetFromReader returned error: %v", err)
+	}
+	if asset.GetID() != 3 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader returned asset %+v, want ID 3", asset)
+	}
+	if uploads != 3 || deleted != 2 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader made %v uploads and %v deletions, want 3 and 2", uploads, deleted)
+	}
+
+	uploads = 0
+	reader = io.MultiReader(strings.NewReader("payload"))
+	if _, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, opts, reader, 7, nil); err == nil {
+		t.Error("Repositories.UploadReleaseAssetFromReader returned nil error without retries, want error")
+	}
+	if uploads != 1 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader made %v uploads without retries, want 1", uploads)
+	}
+
+	if _, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, &UploadOptions{}, reader, 7, nil); err == nil {
+		t.Error("Repositories.UploadReleaseAssetFromReader returned nil error without a name, want error")
+	}
+
+	const methodName = "UploadReleaseAssetFromReader"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Repositories.UploadReleaseAssetFromReader(ctx, "\n", "\n", 1, opts, strings.NewReader("x"), 1, nil)
+		return err
+	})
+}
+
+func TestRepositoriesService_UploadReleaseAssetFromReader_alreadyUploaded(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var uploads int
+	var uploadStatus int
+	var existing string
+	mux.HandleFunc("/repos/o/r/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			uploads++
+			w.WriteHeader(uploadStatus)
+			fmt.Fprint(w, `{"message":"failed"}`)
+		case "GET":
+			fmt.Fprint(w, existing)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/releases/assets/2", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a completely uploaded asset was deleted")
+	})
+
+	ctx := context.Background()
+	opts := &UploadOptions{Name: "n.bin"}
+	uploadOpts := &ReleaseAssetUploadOptions{MaxRetries: 3, RetryDelay: time.Millisecond}
+
+	// The upload succeeded, but its response was lost.
+	uploadStatus = http.StatusBadGateway
+	existing = `[{"id":2,"name":"n.bin","state":"uploaded","size":7}]`
+	asset, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, opts, strings.NewReader("payload"), 7, uploadOpts)
+	if err != nil {
+		t.Fatalf("Repositories.UploadReleaseAssetFromReader returned error: %v", err)
+	}
+	if asset.GetID() != 2 || uploads != 1 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader returned asset %+v after %v uploads, want ID 2 after 1", asset, uploads)
+	}
+
+	// A different asset with the same name exists.
+	uploads = 0
+	uploadStatus = http.StatusUnprocessableEntity
+	existing = `[{"id":2,"name":"n.bin","state":"uploaded","size":3}]`
+	_, _, err = client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, opts, strings.NewReader("payload"), 7, uploadOpts)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader returned %v, want a 422 error", err)
+	}
+	if uploads != 1 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader made %v uploads, want 1", uploads)
+	}
+
+	// An asset with the same name and size existed before the upload; it
+	// was not uploaded by this call, so it is not returned.
+	uploads = 0
+	existing = `[{"id":2,"name":"n.bin","state":"uploaded","size":7}]`
+	asset, _, err = client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, opts, strings.NewReader("payload"), 7, uploadOpts)
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnprocessableEntity || asset != nil {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader returned %+v, %v; want a 422 error", asset, err)
+	}
+	if uploads != 1 {
+		t.Errorf("Repositories.UploadReleaseAssetFromReader made %v uploads, want 1", uploads)
+	}
+}
+
+func TestRepositoriesService_UploadReleaseAssetFromReader_retryCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/repos/o/r/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			t.Error("assets were listed after the context was canceled")
+		}
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, _, err := client.Repositories.UploadReleaseAssetFromReader(ctx, "o", "r", 1, &UploadOptions{Name: "n.bin"}, strings.NewReader("payload"), 7, &ReleaseAssetUploadOptions{MaxRetries: 3, RetryDelay: time.Hour})
+	if err == nil {
+		t.Error("Repositories.UploadReleaseAssetFromReader returned nil error, want error")
+	}
+}
@@ -48,12 +48,25 @@ type RulesetRepositoryIDsConditionParameters struct {
 	RepositoryIDs []int64 `json:"repository_ids,omitempty"`
 }
 
+// RulesetRepositoryPropertyTargetParameters represents a custom property name and the values to target.
+type RulesetRepositoryPropertyTargetParameters struct {
+	Name   string   `json:"name"`
+	Values []string `json:"property_values"`
+}
+
+// RulesetRepositoryPropertyConditionParameters represents the conditions object for repository_property.
+type RulesetRepositoryPropertyConditionParameters struct {
+	Include []*RulesetRepositoryPropertyTargetParameters `json:"include"`
+	Exclude []*RulesetRepositoryPropertyTargetParameters `json:"exclude"`
+}
+
 // RulesetConditions represents the conditions object in a ruleset.
-// Set either RepositoryName or RepositoryID, not both.
+// Set only one of RepositoryName, RepositoryID or RepositoryProperty.
 type RulesetConditions struct {
-	RefName        *RulesetRefConditionParameters             `json:"ref_name,omitempty"`
-	RepositoryName *RulesetRepositoryNamesConditionParameters `json:"repository_name,omitempty"`
-	RepositoryID   *RulesetRepositoryIDsConditionParameters   `json:"repository_id,omitempty"`
+	RefName            *RulesetRefConditionParameters                `json:"ref_name,omitempty"`
+	RepositoryName     *RulesetRepositoryNamesConditionParameters    `json:"repository_name,omitempty"`
+	RepositoryID       *RulesetRepositoryIDsConditionParameters      `json:"repository_id,omitempty"`
+	RepositoryProperty *RulesetRepositoryPropertyConditionParameters `json:"repository_property,omitempty"`
 }
 
 // RulePatternParameters represents the rule pattern parameters.
@@ -474,3 +487,114 @@ func (s *RepositoriesService) DeleteRuleset(ctx context.Context, owner, repo str
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// RuleSuite represents the evaluation of all rules that applied to a single push.
+type RuleSuite struct {
+	ID             *int64     `json:"id,omitempty"`
+	ActorID        *int64     `json:"actor_id,omitempty"`
+	ActorName      *string    `json:"actor_name,omitempty"`
+	BeforeSHA      *string    `json:"before_sha,omitempty"`
+	AfterSHA       *string    `json:"after_sha,omitempty"`
+	Ref            *string    `json:"ref,omitempty"`
+	RepositoryID   *int64     `json:"repository_id,omitempty"`
+	RepositoryName *string    `json:"repository_name,omitempty"`
+	PushedAt       *Timestamp `json:"pushed_at,omitempty"`
+	// Possible values for Result are: pass, fail, bypass
+	Result *string `json:"result,omitempty"`
+	// EvaluationResult is the result of the rules in "evaluate" mode.
+	// Possible values for EvaluationResult are: pass, fail
+	EvaluationResult *string `json:"evaluation_result,omitempty"`
+	// RuleEvaluations is only populated by the get endpoints.
+	RuleEvaluations []*RuleEvaluation `json:"rule_evaluations,omitempty"`
+}
+
+// RuleEvaluation represents the outcome of a single rule within a RuleSuite.
+type RuleEvaluation struct {
+	RuleSource *RuleSource `json:"rule_source,omitempty"`
+	// Possible values for Enforcement are: active, evaluate, deleted ruleset
+	Enforcement *string `json:"enforcement,omitempty"`
+	// Possible values for Result are: pass, fail
+	Result   *string `json:"result,omitempty"`
+	RuleType *string `json:"rule_type,omitempty"`
+	Details  *string `json:"details,omitempty"`
+}
+
+// RuleSource represents the ruleset or protected branch a RuleEvaluation comes from.
+type RuleSource struct {
+	// Possible values for Type are: ruleset, protected_branch
+	Type *string `json:"type,omitempty"`
+	ID   *int64  `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// RuleSuiteListOptions specifies the optional parameters to the
+// RepositoriesService.ListRuleSuites and OrganizationsService.ListRuleSuites methods.
+type RuleSuiteListOptions struct {
+	// Ref filters rule suites by the ref they were evaluated against.
+	Ref string `url:"ref,omitempty"`
+
+	// RepositoryName filters rule suites by repository name. It is only
+	// used by OrganizationsService.ListRuleSuites.
+	RepositoryName string `url:"repository_name,omitempty"`
+
+	// TimePeriod filters rule suites by how recently they were evaluated.
+	// Possible values are: hour, day, week, month. Default is "day".
+	TimePeriod string `url:"time_period,omitempty"`
+
+	// ActorName filters rule suites by the login of the user who pushed.
+	ActorName string `url:"actor_name,omitempty"`
+
+	// RuleSuiteResult filters rule suites by their result.
+	// Possible values are: pass, fail, bypass, all. Default is "all".
+	RuleSuiteResult string `url:"rule_suite_result,omitempty"`
+
+	ListOptions
+}
+
+// ListRuleSuites lists the rule suites evaluated for pushes to a repository.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/rule-suites#list-repository-rule-suites
+//
+//meta:operation GET /repos/{owner}/{repo}/rulesets/rule-suites
+func (s *RepositoriesService) ListRuleSuites(ctx context.Context, owner, repo string, opts *RuleSuiteListOptions) ([]*RuleSuite, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/rulesets/rule-suites", owner, repo)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suites []*RuleSuite
+	resp, err := s.client.Do(ctx, req, &suites)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return suites, resp, nil
+}
+
+// GetRuleSuite gets a rule suite for a repository, including the outcome of every rule evaluated.
+//
+// GitHub API docs: https://docs.github.com/rest/repos/rule-suites#get-a-repository-rule-suite
+//
+//meta:operation GET /repos/{owner}/{repo}/rulesets/rule-suites/{rule_suite_id}
+func (s *RepositoriesService) GetRuleSuite(ctx context.Context, owner, repo string, ruleSuiteID int64) (*RuleSuite, *Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/rulesets/rule-suites/%v", owner, repo, ruleSuiteID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suite *RuleSuite
+	resp, err := s.client.Do(ctx, req, &suite)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return suite, resp, nil
+}
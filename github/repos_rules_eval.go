@@ -0,0 +1,338 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RefUpdate describes a change to a single ref, as a push would make it,
+// for local evaluation of repository rules.
+type RefUpdate struct {
+	// Ref is the full name of the ref, such as refs/heads/main or refs/tags/v1.0.0.
+	Ref string
+	// Before is the SHA the ref pointed at. Leave it empty, or set it to
+	// all zeros, when the ref is being created.
+	Before string
+	// After is the SHA the ref will point at. Leave it empty, or set it to
+	// all zeros, when the ref is being deleted.
+	After string
+	// NonFastForward reports whether After is not a descendant of Before.
+	NonFastForward bool
+	// DefaultBranch is the name of the repository's default branch. It is
+	// used to resolve ~DEFAULT_BRANCH in ref name conditions.
+	DefaultBranch string
+	// Commits are the new commits being pushed. Only Message, Author,
+	// Committer, Parents and Verification are consulted.
+	Commits []*Commit
+}
+
+// IsCreation reports whether the update creates the ref.
+func (u *RefUpdate) IsCreation() bool {
+	return isNullSHA(u.Before)
+}
+
+// IsDeletion reports whether the update deletes the ref.
+func (u *RefUpdate) IsDeletion() bool {
+	return isNullSHA(u.After)
+}
+
+func isNullSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+// RuleViolation is a rule that a RefUpdate would break.
+type RuleViolation struct {
+	// Ruleset is the ruleset the rule belongs to. It is nil when the rules
+	// were evaluated with EvaluateRules.
+	Ruleset *Ruleset
+	Rule    *RepositoryRule
+	// Commit is the offending commit for commit based rules.
+	Commit  *Commit
+	Message string
+}
+
+func (v *RuleViolation) Error() string {
+	if v.Ruleset != nil {
+		return fmt.Sprintf("ruleset %q: %v", v.Ruleset.Name, v.Message)
+	}
+	return v.Message
+}
+
+// EvaluateRuleset reports the rules of rs that u would violate. Rulesets
+// that are disabled, target a different kind of ref or whose ref name
+// conditions do not match u.Ref produce no violations. Rulesets in
+// "evaluate" mode are reported like active ones.
+func EvaluateRuleset(rs *Ruleset, u *RefUpdate) ([]*RuleViolation, error) {
+	if rs == nil || rs.Enforcement == "disabled" {
+		return nil, nil
+	}
+
+	prefix := "refs/heads/"
+	if rs.GetTarget() == "tag" {
+		prefix = "refs/tags/"
+	}
+	if !strings.HasPrefix(u.Ref, prefix) {
+		return nil, nil
+	}
+	if rs.Conditions != nil && rs.Conditions.RefName != nil {
+		ok, err := rs.Conditions.RefName.MatchRef(u.Ref, u.DefaultBranch)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	violations, err := EvaluateRules(rs.Rules, u)
+	if err != nil {
+		return nil, fmt.Errorf("ruleset %q: %w", rs.Name, err)
+	}
+	for _, v := range violations {
+		v.Ruleset = rs
+	}
+	return violations, nil
+}
+
+// EvaluateRules reports the rules that u would violate. The rules are
+// assumed to already apply to u.Ref, as those returned by
+// RepositoriesService.GetRulesForBranch do.
+//
+// Rules that depend on state only GitHub knows are not evaluated; these
+// are required_status_checks and required_deployments. A pull_request rule
+// is reported for any update that is not a creation or deletion, since
+// such a change must be made through a pull request. Regular expression
+// patterns are matched with RE2 syntax; see RulePatternParameters.Matches.
+func EvaluateRules(rules []*RepositoryRule, u *RefUpdate) ([]*RuleViolation, error) {
+	var violations []*RuleViolation
+	add := func(rule *RepositoryRule, commit *Commit, format string, args ...interface{}) {
+		violations = append(violations, &RuleViolation{Rule: rule, Commit: commit, Message: fmt.Sprintf(format, args...)})
+	}
+
+	creation, deletion := u.IsCreation(), u.IsDeletion()
+	update := !creation && !deletion
+
+	for _, rule := range rules {
+		switch rule.Type {
+		case "creation":
+			if creation {
+				add(rule, nil, "creating %v is restricted", u.Ref)
+			}
+		case "deletion":
+			if deletion {
+				add(rule, nil, "deleting %v is restricted", u.Ref)
+			}
+		case "update":
+			if update {
+				add(rule, nil, "updating %v is restricted", u.Ref)
+			}
+		case "non_fast_forward":
+			if update && u.NonFastForward {
+				add(rule, nil, "force pushing to %v is not allowed", u.Ref)
+			}
+		case "pull_request":
+			if update {
+				add(rule, nil, "changes to %v must be made through a pull request", u.Ref)
+			}
+		case "required_linear_history":
+			if deletion {
+				continue
+			}
+			for _, c := range u.Commits {
+				if len(c.Parents) > 1 {
+					add(rule, c, "merge commit %v is not allowed", c.GetSHA())
+				}
+			}
+		case "required_signatures":
+			if deletion {
+				continue
+			}
+			for _, c := range u.Commits {
+				if !c.GetVerification().GetVerified() {
+					add(rule, c, "commit %v does not have a verified signature", c.GetSHA())
+				}
+			}
+		case "commit_message_pattern", "commit_author_email_pattern", "committer_email_pattern":
+			if deletion {
+				continue
+			}
+			params, err := rulePatternParameters(rule)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range u.Commits {
+				var value string
+				switch rule.Type {
+				case "commit_message_pattern":
+					value = c.GetMessage()
+				case "commit_author_email_pattern":
+					value = c.GetAuthor().GetEmail()
+				default:
+					value = c.GetCommitter().GetEmail()
+				}
+				ok, err := params.Allows(value)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					add(rule, c, "commit %v: %v %q %v", c.GetSHA(), strings.ReplaceAll(strings.TrimSuffix(rule.Type, "_pattern"), "_", " "), value, params.describe())
+				}
+			}
+		case "branch_name_pattern", "tag_name_pattern":
+			prefix := "refs/heads/"
+			if rule.Type == "tag_name_pattern" {
+				prefix = "refs/tags/"
+			}
+			if deletion || !strings.HasPrefix(u.Ref, prefix) {
+				continue
+			}
+			params, err := rulePatternParameters(rule)
+			if err != nil {
+				return nil, err
+			}
+			name := strings.TrimPrefix(u.Ref, prefix)
+			ok, err := params.Allows(name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				add(rule, nil, "name %q %v", name, params.describe())
+			}
+		}
+	}
+	return violations, nil
+}
+
+func rulePatternParameters(rule *RepositoryRule) (*RulePatternParameters, error) {
+	params := new(RulePatternParameters)
+	if rule.Parameters == nil {
+		return nil, fmt.Errorf("rule %v has no parameters", rule.Type)
+	}
+	if err := json.Unmarshal(*rule.Parameters, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Matches reports whether value matches the operator and pattern of p,
+// ignoring Negate.
+//
+// The "regex" operator is evaluated with Go's regexp package, which uses
+// RE2 syntax. GitHub evaluates these patterns with Onigmo, so patterns
+// using features RE2 lacks, such as lookaheads and backreferences, fail to
+// compile and an error is returned.
+func (p *RulePatternParameters) Matches(value string) (bool, error) {
+	switch p.Operator {
+	case "starts_with":
+		return strings.HasPrefix(value, p.Pattern), nil
+	case "ends_with":
+		return strings.HasSuffix(value, p.Pattern), nil
+	case "contains":
+		return strings.Contains(value, p.Pattern), nil
+	case "regex":
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(value), nil
+	}
+	return false, fmt.Errorf("unknown pattern operator %q", p.Operator)
+}
+
+// Allows reports whether value satisfies the rule: it must match the
+// pattern or, if Negate is set, must not match it.
+func (p *RulePatternParameters) Allows(value string) (bool, error) {
+	ok, err := p.Matches(value)
+	if err != nil {
+		return false, err
+	}
+	return ok != p.GetNegate(), nil
+}
+
+func (p *RulePatternParameters) describe() string {
+	op := map[string]string{
+		"starts_with": "start with",
+		"ends_with":   "end with",
+		"contains":    "contain",
+		"regex":       "match",
+	}[p.Operator]
+	if p.GetNegate() {
+		return fmt.Sprintf("must not %v %q", op, p.Pattern)
+	}
+	return fmt.Sprintf("must %v %q", op, p.Pattern)
+}
+
+// MatchRef reports whether ref is selected by the condition: it must match
+// an Include pattern and no Exclude pattern. Patterns are fnmatch style,
+// where "*" matches within a path segment and "**" across segments.
+// The special patterns ~ALL and ~DEFAULT_BRANCH match every ref and the
+// given default branch respectively.
+func (c *RulesetRefConditionParameters) MatchRef(ref, defaultBranch string) (bool, error) {
+	included, err := matchRefPatterns(c.Include, ref, defaultBranch)
+	if err != nil || !included {
+		return false, err
+	}
+	excluded, err := matchRefPatterns(c.Exclude, ref, defaultBranch)
+	if err != nil {
+		return false, err
+	}
+	return !excluded, nil
+}
+
+func matchRefPatterns(patterns []string, ref, defaultBranch string) (bool, error) {
+	for _, p := range patterns {
+		switch p {
+		case "~ALL":
+			return true, nil
+		case "~DEFAULT_BRANCH":
+			if defaultBranch != "" && ref == "refs/heads/"+defaultBranch {
+				return true, nil
+			}
+			continue
+		}
+		re, err := refPatternRegexp(p)
+		if err != nil {
+			return false, err
+		}
+		if re.MatchString(ref) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func refPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	var star bool
+	for _, c := range pattern {
+		if star {
+			star = false
+			if c == '*' {
+				b.WriteString(".*")
+				continue
+			}
+			b.WriteString("[^/]*")
+		}
+		switch c {
+		case '*':
+			star = true
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if star {
+		b.WriteString("[^/]*")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
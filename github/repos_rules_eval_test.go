@@ -0,0 +1,174 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRulePatternParameters_Allows(t *testing.T) {
+	tests := []struct {
+		params RulePatternParameters
+		value  string
+		want   bool
+	}{
+		{RulePatternParameters{Operator: "starts_with", Pattern: "feat"}, "feature/x", true},
+		{RulePatternParameters{Operator: "starts_with", Pattern: "feat"}, "fix/x", false},
+		{RulePatternParameters{Operator: "ends_with", Pattern: "@example.com"}, "a@example.com", true},
+		{RulePatternParameters{Operator: "contains", Pattern: "WIP", Negate: Bool(true)}, "WIP: stuff", false},
+		{RulePatternParameters{Operator: "contains", Pattern: "WIP", Negate: Bool(true)}, "done", true},
+		{RulePatternParameters{Operator: "regex", Pattern: `^v\d+\.\d+\.\d+$`}, "v1.2.3", true},
+		{RulePatternParameters{Operator: "regex", Pattern: `^v\d+\.\d+\.\d+$`}, "v1.2", false},
+	}
+	for _, tt := range tests {
+		got, err := tt.params.Allows(tt.value)
+		if err != nil {
+			t.Errorf("Allows(%q) with %+v returned error: %v", tt.value, tt.params, err)
+		}
+		if got != tt.want {
+			t.Errorf("Allows(%q) with %+v = %v, want %v", tt.value, tt.params, got, tt.want)
+		}
+	}
+
+	for _, p := range []RulePatternParameters{{Operator: "regex", Pattern: "("}, {Operator: "regex", Pattern: "^(?!main$)"}, {Operator: "bogus"}} {
+		if _, err := p.Allows("x"); err == nil {
+			t.Errorf("Allows with %+v returned nil error, want error", p)
+		}
+	}
+}
+
+func TestRulesetRefConditionParameters_MatchRef(t *testing.T) {
+	tests := []struct {
+		cond RulesetRefConditionParameters
+		ref  string
+		want bool
+	}{
+		{RulesetRefConditionParameters{Include: []string{"~ALL"}}, "refs/heads/x", true},
+		{RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}, "refs/heads/main", true},
+		{RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}, "refs/heads/dev", false},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/release/*"}}, "refs/heads/release/1.0", true},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/release/*"}}, "refs/heads/release/1.0/hotfix", false},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/release/**"}}, "refs/heads/release/1.0/hotfix", true},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/v?"}}, "refs/heads/v1", true},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/a.b"}}, "refs/heads/aXb", false},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/café/*"}}, "refs/heads/café/x", true},
+		{RulesetRefConditionParameters{Include: []string{"refs/heads/caf?"}}, "refs/heads/café", true},
+		{RulesetRefConditionParameters{Include: []string{"~ALL"}, Exclude: []string{"refs/heads/dependabot/**"}}, "refs/heads/dependabot/npm/x", false},
+		{RulesetRefConditionParameters{Include: []string{"~ALL"}, Exclude: []string{"~DEFAULT_BRANCH"}}, "refs/heads/main", false},
+		{RulesetRefConditionParameters{}, "refs/heads/main", false},
+	}
+	for _, tt := range tests {
+		got, err := tt.cond.MatchRef(tt.ref, "main")
+		if err != nil {
+			t.Errorf("MatchRef(%q) with %+v returned error: %v", tt.ref, tt.cond, err)
+		}
+		if got != tt.want {
+			t.Errorf("MatchRef(%q) with %+v = %v, want %v", tt.ref, tt.cond, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	signed := &Commit{SHA: String("a"), Message: String("feat: x"), Author: &CommitAuthor{Email: String("a@example.com")}, Verification: &SignatureVerification{Verified: Bool(true)}}
+	merge := &Commit{SHA: String("b"), Message: String("WIP merge"), Author: &CommitAuthor{Email: String("b@evil.com")}, Parents: []*Commit{{}, {}}}
+
+	rules := []*RepositoryRule{
+		NewCreationRule(),
+		NewDeletionRule(),
+		NewNonFastForwardRule(),
+		NewRequiredLinearHistoryRule(),
+		NewRequiredSignaturesRule(),
+		NewCommitMessagePatternRule(&RulePatternParameters{Operator: "contains", Pattern: "WIP", Negate: Bool(true)}),
+		NewCommitAuthorEmailPatternRule(&RulePatternParameters{Operator: "ends_with", Pattern: "@example.com"}),
+		NewBranchNamePatternRule(&RulePatternParameters{Operator: "regex", Pattern: "^(main|feature/.+)$"}),
+		NewRequiredStatusChecksRule(&RequiredStatusChecksRuleParameters{RequiredStatusChecks: []RuleRequiredStatusChecks{{Context: "ci"}}}),
+	}
+
+	got, err := EvaluateRules(rules, &RefUpdate{
+		Ref:            "refs/heads/topic",
+		Before:         "1111",
+		After:          "2222",
+		NonFastForward: true,
+		Commits:        []*Commit{signed, merge},
+	})
+	if err != nil {
+		t.Fatalf("EvaluateRules returned error: %v", err)
+	}
+
+	want := []*RuleViolation{
+		{Rule: rules[2], Message: "force pushing to refs/heads/topic is not allowed"},
+		{Rule: rules[3], Commit: merge, Message: "merge commit b is not allowed"},
+		{Rule: rules[4], Commit: merge, Message: "commit b does not have a verified signature"},
+		{Rule: rules[5], Commit: merge, Message: `commit b: commit message "WIP merge" must not contain "WIP"`},
+		{Rule: rules[6], Commit: merge, Message: `commit b: commit author email "b@evil.com" must end with "@example.com"`},
+		{Rule: rules[7], Message: `name "topic" must match "^(main|feature/.+)$"`},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("EvaluateRules returned diff (-want +got):\n%v", cmp.Diff(want, got))
+	}
+
+	got, err = EvaluateRules(rules, &RefUpdate{Ref: "refs/heads/main", Before: "1111", After: "0000000000000000000000000000000000000000"})
+	if err != nil {
+		t.Fatalf("EvaluateRules returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].Rule != rules[1] {
+		t.Errorf("EvaluateRules for deletion returned %+v, want only the deletion rule", got)
+	}
+
+	got, err = EvaluateRules(rules, &RefUpdate{Ref: "refs/heads/feature/x", After: "2222", Commits: []*Commit{signed}})
+	if err != nil {
+		t.Fatalf("EvaluateRules returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].Rule != rules[0] {
+		t.Errorf("EvaluateRules for creation returned %+v, want only the creation rule", got)
+	}
+
+	bad := []*RepositoryRule{{Type: "branch_name_pattern"}}
+	if _, err := EvaluateRules(bad, &RefUpdate{Ref: "refs/heads/x", After: "1"}); err == nil {
+		t.Error("EvaluateRules returned nil error for rule without parameters, want error")
+	}
+}
+
+func TestEvaluateRuleset(t *testing.T) {
+	rs := &Ruleset{
+		Name:        "protect",
+		Target:      String("branch"),
+		Enforcement: "active",
+		Conditions: &RulesetConditions{
+			RefName: &RulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}},
+		},
+		Rules: []*RepositoryRule{NewPullRequestRule(&PullRequestRuleParameters{RequiredApprovingReviewCount: 1})},
+	}
+
+	u := &RefUpdate{Ref: "refs/heads/main", Before: "1", After: "2", DefaultBranch: "main"}
+	got, err := EvaluateRuleset(rs, u)
+	if err != nil {
+		t.Fatalf("EvaluateRuleset returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].Ruleset != rs {
+		t.Fatalf("EvaluateRuleset returned %+v, want one violation from the ruleset", got)
+	}
+	if want := `ruleset "protect": changes to refs/heads/main must be made through a pull request`; got[0].Error() != want {
+		t.Errorf("RuleViolation.Error() = %q, want %q", got[0].Error(), want)
+	}
+
+	for _, u := range []*RefUpdate{
+		{Ref: "refs/heads/dev", Before: "1", After: "2", DefaultBranch: "main"},
+		{Ref: "refs/tags/main", Before: "1", After: "2", DefaultBranch: "main"},
+	} {
+		got, err := EvaluateRuleset(rs, u)
+		if err != nil || len(got) != 0 {
+			t.Errorf("EvaluateRuleset(%v) returned %+v, %v, want no violations", u.Ref, got, err)
+		}
+	}
+
+	rs.Enforcement = "disabled"
+	if got, _ := EvaluateRuleset(rs, u); len(got) != 0 {
+		t.Errorf("EvaluateRuleset for disabled ruleset returned %+v, want no violations", got)
+	}
+}
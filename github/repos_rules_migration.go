@@ -0,0 +1,266 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// repositoryAdminRoleID is the ID of the built-in repository admin role,
+// used as a BypassActor with an ActorType of RepositoryRole.
+const repositoryAdminRoleID = 5
+
+// RulesetMigration describes how the classic protection of one branch maps
+// onto a repository ruleset.
+type RulesetMigration struct {
+	Branch     string
+	Protection *Protection
+	// Ruleset is the equivalent ruleset. It is not created on GitHub until
+	// RepositoriesService.MigrateBranchProtection is called.
+	Ruleset *Ruleset
+	// Unsupported lists the protection settings that have no ruleset
+	// equivalent and would be lost by the migration.
+	Unsupported []string
+}
+
+// ConvertProtectionToRuleset converts the classic protection of branch into
+// an equivalent ruleset targeting only that branch. It also returns a
+// description of every setting that could not be converted.
+//
+// Admins are given an "always" bypass unless EnforceAdmins is enabled,
+// mirroring how classic protection treats them. Push restrictions become an
+// update rule. The actors allowed to push or to bypass pull requests are
+// reported as unsupported rather than made bypass actors, as a bypass
+// actor is exempt from every rule of the ruleset.
+func ConvertProtectionToRuleset(branch string, p *Protection) (*Ruleset, []string) {
+	rs := &Ruleset{
+		Name:        "Migrated protection for " + branch,
+		Target:      String("branch"),
+		Enforcement: "active",
+		Conditions: &RulesetConditions{
+			RefName: &RulesetRefConditionParameters{
+				Include: []string{"refs/heads/" + branch},
+				Exclude: []string{},
+			},
+		},
+	}
+	if p == nil {
+		return rs, nil
+	}
+
+	var unsupported []string
+	if p.EnforceAdmins == nil || !p.EnforceAdmins.Enabled {
+		rs.BypassActors = []*BypassActor{
+			{ActorType: String("RepositoryRole"), ActorID: Int64(repositoryAdminRoleID), BypassMode: String("always")},
+		}
+	}
+
+	if p.AllowDeletions == nil || !p.AllowDeletions.Enabled {
+		rs.Rules = append(rs.Rules, NewDeletionRule())
+	}
+	if p.AllowForcePushes == nil || !p.AllowForcePushes.Enabled {
+		rs.Rules = append(rs.Rules, NewNonFastForwardRule())
+	}
+	if p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled {
+		rs.Rules = append(rs.Rules, NewRequiredLinearHistoryRule())
+	}
+	if p.RequiredSignatures != nil && p.RequiredSignatures.GetEnabled() {
+		rs.Rules = append(rs.Rules, NewRequiredSignaturesRule())
+	}
+
+	if c := p.RequiredStatusChecks; c != nil {
+		params := &RequiredStatusChecksRuleParameters{StrictRequiredStatusChecksPolicy: c.Strict}
+		if len(c.Checks) > 0 {
+			for _, check := range c.Checks {
+				rc := RuleRequiredStatusChecks{Context: check.Context}
+				if check.GetAppID() > 0 {
+					rc.IntegrationID = check.AppID
+				}
+				params.RequiredStatusChecks = append(params.RequiredStatusChecks, rc)
+			}
+		} else {
+			for _, name := range c.Contexts {
+				params.RequiredStatusChecks = append(params.RequiredStatusChecks, RuleRequiredStatusChecks{Context: name})
+			}
+		}
+		rs.Rules = append(rs.Rules, NewRequiredStatusChecksRule(params))
+	}
+
+	resolution := p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled
+	if r := p.RequiredPullRequestReviews; r != nil {
+		rs.Rules = append(rs.Rules, NewPullRequestRule(&PullRequestRuleParameters{
+			DismissStaleReviewsOnPush:      r.DismissStaleReviews,
+			RequireCodeOwnerReview:         r.RequireCodeOwnerReviews,
+			RequireLastPushApproval:        r.RequireLastPushApproval,
+			RequiredApprovingReviewCount:   r.RequiredApprovingReviewCount,
+			RequiredReviewThreadResolution: resolution,
+		}))
+		if d := r.DismissalRestrictions; d != nil && len(d.Users)+len(d.Teams)+len(d.Apps) > 0 {
+			unsupported = append(unsupported, "required_pull_request_reviews.dismissal_restrictions: rulesets do not restrict who can dismiss reviews")
+		}
+		if b := r.BypassPullRequestAllowances; b != nil {
+			// Classic allowances let these actors push without a pull
+			// request; a ruleset bypass would exempt them from every rule.
+			unsupported = append(unsupported, actorsUnsupported("required_pull_request_reviews.bypass_pull_request_allowances", "may push without a pull request", b.Users, b.Teams, b.Apps)...)
+		}
+	} else if resolution {
+		unsupported = append(unsupported, "required_conversation_resolution: rulesets only support it as part of a pull request rule")
+	}
+
+	lock := p.LockBranch != nil && p.LockBranch.GetEnabled()
+	if lock || p.Restrictions != nil {
+		var params *UpdateAllowsFetchAndMergeRuleParameters
+		if lock && p.AllowForkSyncing != nil && p.AllowForkSyncing.GetEnabled() {
+			params = &UpdateAllowsFetchAndMergeRuleParameters{UpdateAllowsFetchAndMerge: true}
+		}
+		rs.Rules = append(rs.Rules, NewUpdateRule(params))
+	}
+	if r := p.Restrictions; r != nil {
+		// A locked branch cannot be pushed to by anyone, so the push
+		// allowances are only carried over when the branch is not locked.
+		if !lock {
+			unsupported = append(unsupported, actorsUnsupported("restrictions", "may push", r.Users, r.Teams, r.Apps)...)
+		}
+		if p.BlockCreations != nil && p.BlockCreations.GetEnabled() {
+			rs.Rules = append(rs.Rules, NewCreationRule())
+		}
+	}
+
+	return rs, unsupported
+}
+
+// actorsUnsupported describes the users, teams and apps that are granted
+// an allowance by the classic protection setting field, which rulesets
+// can only grant through a bypass of every rule.
+func actorsUnsupported(field, allowance string, users []*User, teams []*Team, apps []*App) []string {
+	var unsupported []string
+	describe := func(actor string) {
+		unsupported = append(unsupported, fmt.Sprintf("%v: %v %v; a ruleset bypass would exempt it from every rule", field, actor, allowance))
+	}
+	for _, u := range users {
+		describe(fmt.Sprintf("user %q", u.GetLogin()))
+	}
+	for _, t := range teams {
+		describe(fmt.Sprintf("team %q", t.GetSlug()))
+	}
+	for _, a := range apps {
+		describe(fmt.Sprintf("app %q", a.GetSlug()))
+	}
+	return unsupported
+}
+
+// PlanRulesetMigration converts the classic protection of every protected
+// branch in a repository into an equivalent ruleset. Nothing is changed on
+// GitHub.
+//
+// GitHub API docs: https://docs.github.com/rest/branches/branch-protection#get-branch-protection
+// GitHub API docs: https://docs.github.com/rest/branches/branches#list-branches
+//
+//meta:operation GET /repos/{owner}/{repo}/branches
+//meta:operation GET /repos/{owner}/{repo}/branches/{branch}/protection
+func (s *RepositoriesService) PlanRulesetMigration(ctx context.Context, owner, repo string) ([]*RulesetMigration, error) {
+	var migrations []*RulesetMigration
+	opts := &BranchListOptions{Protected: Bool(true), ListOptions: ListOptions{PerPage: 100}}
+	for {
+		branches, resp, err := s.ListBranches(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range branches {
+			p, _, err := s.GetBranchProtection(ctx, owner, repo, b.GetName())
+			if errors.Is(err, ErrBranchNotProtected) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			rs, unsupported := ConvertProtectionToRuleset(b.GetName(), p)
+			rs.Source = owner + "/" + repo
+			migrations = append(migrations, &RulesetMigration{
+				Branch:      b.GetName(),
+				Protection:  p,
+				Ruleset:     rs,
+				Unsupported: unsupported,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return migrations, nil
+}
+
+// RulesetMigrationOptions specifies optional parameters to the
+// RepositoriesService.MigrateBranchProtection method.
+type RulesetMigrationOptions struct {
+	// AllowUnsupported permits the migration of a branch whose protection
+	// includes settings that have no ruleset equivalent. By default such a
+	// migration is refused.
+	AllowUnsupported bool
+	// RemoveProtection removes the classic branch protection once the
+	// ruleset has been created.
+	RemoveProtection bool
+	// AuditLog, if set, receives one line for every step taken.
+	AuditLog io.Writer
+}
+
+// MigrateBranchProtection creates the ruleset described by m and, if
+// requested, removes the classic protection it replaces.
+//
+// The ruleset is always created before the protection is removed, so the
+// branch is never left unprotected. If removing the protection fails, the
+// created ruleset is returned along with the error.
+//
+// GitHub API docs: https://docs.github.com/rest/branches/branch-protection#delete-branch-protection
+// GitHub API docs: https://docs.github.com/rest/repos/rules#create-a-repository-ruleset
+//
+//meta:operation DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+//meta:operation POST /repos/{owner}/{repo}/rulesets
+func (s *RepositoriesService) MigrateBranchProtection(ctx context.Context, owner, repo string, m *RulesetMigration, opts *RulesetMigrationOptions) (*Ruleset, error) {
+	if m == nil || m.Ruleset == nil {
+		return nil, errors.New("migration must include a ruleset")
+	}
+	if opts == nil {
+		opts = &RulesetMigrationOptions{}
+	}
+	audit := func(format string, args ...interface{}) {
+		if opts.AuditLog != nil {
+			fmt.Fprintf(opts.AuditLog, "%v/%v: "+format+"\n", append([]interface{}{owner, repo}, args...)...)
+		}
+	}
+
+	if len(m.Unsupported) > 0 {
+		if !opts.AllowUnsupported {
+			return nil, fmt.Errorf("branch %q has protection settings without a ruleset equivalent: %v", m.Branch, strings.Join(m.Unsupported, "; "))
+		}
+		for _, u := range m.Unsupported {
+			audit("branch %q: dropping unsupported setting: %v", m.Branch, u)
+		}
+	}
+
+	rs, _, err := s.CreateRuleset(ctx, owner, repo, m.Ruleset)
+	if err != nil {
+		audit("branch %q: creating ruleset %q failed: %v", m.Branch, m.Ruleset.Name, err)
+		return nil, err
+	}
+	audit("branch %q: created ruleset %q (id %v)", m.Branch, rs.Name, rs.GetID())
+
+	if !opts.RemoveProtection {
+		return rs, nil
+	}
+	if _, err := s.RemoveBranchProtection(ctx, owner, repo, m.Branch); err != nil {
+		audit("branch %q: removing classic protection failed: %v", m.Branch, err)
+		return rs, err
+	}
+	audit("branch %q: removed classic protection", m.Branch)
+
+	return rs, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertProtectionToRuleset(t *testing.T) {
+	p := &Protection{
+		RequiredStatusChecks: &RequiredStatusChecks{
+			Strict: true,
+			Checks: []*RequiredStatusCheck{{Context: "ci", AppID: Int64(15)}, {Context: "lint", AppID: Int64(-1)}},
+		},
+		RequiredPullRequestReviews: &PullRequestReviewsEnforcement{
+			DismissStaleReviews:          true,
+			RequiredApprovingReviewCount: 2,
+			BypassPullRequestAllowances: &BypassPullRequestAllowances{
+				Users: []*User{{Login: String("octocat")}},
+				Teams: []*Team{{ID: Int64(9), Slug: String("core")}},
+			},
+			DismissalRestrictions: &DismissalRestrictions{Teams: []*Team{{ID: Int64(9)}}},
+		},
+		EnforceAdmins:                  &AdminEnforcement{Enabled: false},
+		RequireLinearHistory:           &RequireLinearHistory{Enabled: true},
+		AllowForcePushes:               &AllowForcePushes{Enabled: false},
+		AllowDeletions:                 &AllowDeletions{Enabled: true},
+		RequiredConversationResolution: &RequiredConversationResolution{Enabled: true},
+		Restrictions: &BranchRestrictions{
+			Teams: []*Team{{ID: Int64(9), Slug: String("core")}},
+			Apps:  []*App{{ID: Int64(3), Slug: String("deployer")}},
+		},
+		BlockCreations: &BlockCreations{Enabled: Bool(true)},
+	}
+
+	rs, unsupported := ConvertProtectionToRuleset("main", p)
+
+	want := &Ruleset{
+		Name:        "Migrated protection for main",
+		Target:      String("branch"),
+		Enforcement: "active",
+		BypassActors: []*BypassActor{
+			{ActorType: String("RepositoryRole"), ActorID: Int64(5), BypassMode: String("always")},
+		},
+		Conditions: &RulesetConditions{
+			RefName: &RulesetRefConditionParameters{Include: []string{"refs/heads/main"}, Exclude: []string{}},
+		},
+		Rules: []*RepositoryRule{
+			NewNonFastForwardRule(),
+			NewRequiredLinearHistoryRule(),
+			NewRequiredStatusChecksRule(&RequiredStatusChecksRuleParameters{
+				RequiredStatusChecks: []RuleRequiredStatusChecks{
+					{Context: "ci", IntegrationID: Int64(15)},
+					{Context: "lint"},
+				},
+				StrictRequiredStatusChecksPolicy: true,
+			}),
+			NewPullRequestRule(&PullRequestRuleParameters{
+				DismissStaleReviewsOnPush:      true,
+				RequiredApprovingReviewCount:   2,
+				RequiredReviewThreadResolution: true,
+			}),
+			NewUpdateRule(nil),
+			NewCreationRule(),
+		},
+	}
+	if !cmp.Equal(rs, want) {
+		t.Errorf("ConvertProtectionToRuleset returned diff (-want +got):\n%v", cmp.Diff(want, rs))
+	}
+
+	wantUnsupported := []string{
+		"required_pull_request_reviews.dismissal_restrictions: rulesets do not restrict who can dismiss reviews",
+		`required_pull_request_reviews.bypass_pull_request_allowances: user "octocat" may push without a pull request; a ruleset bypass would exempt it from every rule`,
+		`required_pull_request_reviews.bypass_pull_request_allowances: team "core" may push without a pull request; a ruleset bypass would exempt it from every rule`,
+		`restrictions: team "core" may push; a ruleset bypass would exempt it from every rule`,
+		`restrictions: app "deployer" may push; a ruleset bypass would exempt it from every rule`,
+	}
+	if !cmp.Equal(unsupported, wantUnsupported) {
+		t.Errorf("ConvertProtectionToRuleset returned unsupported %v, want %v", unsupported, wantUnsupported)
+	}
+}
+
+func TestConvertProtectionToRuleset_bypassModes(t *testing.T) {
+	p := &Protection{
+		EnforceAdmins: &AdminEnforcement{Enabled: true},
+		RequiredPullRequestReviews: &PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			BypassPullRequestAllowances: &BypassPullRequestAllowances{
+				Teams: []*Team{{ID: Int64(9), Slug: String("core")}},
+				Apps:  []*App{{ID: Int64(3), Slug: String("deployer")}},
+			},
+		},
+		Restrictions: &BranchRestrictions{Teams: []*Team{{ID: Int64(10), Slug: String("release")}}},
+	}
+
+	rs, unsupported := ConvertProtectionToRuleset("main", p)
+
+	// No actor may bypass in any mode: a "pull_request" bypass reverses the
+	// classic allowance, and an "always" bypass exempts it from every rule.
+	for _, b := range rs.BypassActors {
+		t.Errorf("ConvertProtectionToRuleset returned bypass actor %v %v with mode %q, want none", b.GetActorType(), b.GetActorID(), b.GetBypassMode())
+	}
+	if len(unsupported) != 3 {
+		t.Errorf("ConvertProtectionToRuleset returned unsupported %v, want one entry per allowed actor", unsupported)
+	}
+}
+
+func TestConvertProtectionToRuleset_lockedBranch(t *testing.T) {
+	p := &Protection{
+		EnforceAdmins:                  &AdminEnforcement{Enabled: true},
+		AllowForcePushes:               &AllowForcePushes{Enabled: true},
+		AllowDeletions:                 &AllowDeletions{Enabled: true},
+		RequiredConversationResolution: &RequiredConversationResolution{Enabled: true},
+		LockBranch:                     &LockBranch{Enabled: Bool(true)},
+		AllowForkSyncing:               &AllowForkSyncing{Enabled: Bool(true)},
+	}
+
+	rs, unsupported := ConvertProtectionToRuleset("release", p)
+
+	wantRules := []*RepositoryRule{
+		NewUpdateRule(&UpdateAllowsFetchAndMergeRuleParameters{UpdateAllowsFetchAndMerge: true}),
+	}
+	if !cmp.Equal(rs.Rules, wantRules) {
+		t.Errorf("ConvertProtectionToRuleset returned rules %+v, want %+v", rs.Rules, wantRules)
+	}
+	if rs.BypassActors != nil {
+		t.Errorf("ConvertProtectionToRuleset returned bypass actors %+v, want none", rs.BypassActors)
+	}
+	if len(unsupported) != 1 || !strings.HasPrefix(unsupported[0], "required_conversation_resolution") {
+		t.Errorf("ConvertProtectionToRuleset returned unsupported %v, want conversation resolution", unsupported)
+	}
+}
+
+func TestRepositoriesService_PlanRulesetMigration(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/branches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"protected": "true", "per_page": "100"})
+		fmt.Fprint(w, `[{"name": "main"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"enforce_admins": {"enabled": true}, "allow_deletions": {"enabled": false}, "allow_force_pushes": {"enabled": true}}`)
+	})
+
+	ctx := context.Background()
+	got, err := client.Repositories.PlanRulesetMigration(ctx, "o", "r")
+	if err != nil {
+		t.Fatalf("Repositories.PlanRulesetMigration returned error: %v", err)
+	}
+
+	want := []*RulesetMigration{{
+		Branch: "main",
+		Protection: &Protection{
+			EnforceAdmins:    &AdminEnforcement{Enabled: true},
+			AllowDeletions:   &AllowDeletions{Enabled: false},
+			AllowForcePushes: &AllowForcePushes{Enabled: true},
+		},
+		Ruleset: &Ruleset{
+			Name:        "Migrated protection for main",
+			Target:      String("branch"),
+			Source:      "o/r",
+			Enforcement: "active",
+			Conditions: &RulesetConditions{
+				RefName: &RulesetRefConditionParameters{Include: []string{"refs/heads/main"}, Exclude: []string{}},
+			},
+			Rules: []*RepositoryRule{NewDeletionRule()},
+		},
+	}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Repositories.PlanRulesetMigration returned diff (-want +got):\n%v", cmp.Diff(want, got))
+	}
+
+	const methodName = "PlanRulesetMigration"
+	testBadOptions(t, methodName, func() (err error) {
+		_, err = client.Repositories.PlanRulesetMigration(ctx, "\n", "\n")
+		return err
+	})
+}
+
+func TestRepositoriesService_MigrateBranchProtection(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var removed bool
+	mux.HandleFunc("/repos/o/r/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id": 42, "name": "Migrated protection for main", "source": "o/r", "enforcement": "active"}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		removed = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	m := &RulesetMigration{
+		Branch:      "main",
+		Ruleset:     &Ruleset{Name: "Migrated protection for main", Source: "o/r", Enforcement: "active"},
+		Unsupported: []string{"restrictions: user \"octocat\" cannot be a bypass actor"},
+	}
+
+	ctx := context.Background()
+	if _, err := client.Repositories.MigrateBranchProtection(ctx, "o", "r", m, nil); err == nil {
+		t.Fatal("Repositories.MigrateBranchProtection returned nil error for unsupported settings, want error")
+	}
+
+	var log strings.Builder
+	rs, err := client.Repositories.MigrateBranchProtection(ctx, "o", "r", m, &RulesetMigrationOptions{
+		AllowUnsupported: true,
+		RemoveProtection: true,
+		AuditLog:         &log,
+	})
+	if err != nil {
+		t.Fatalf("Repositories.MigrateBranchProtection returned error: %v", err)
+	}
+	if rs.GetID() != 42 {
+		t.Errorf("Repositories.MigrateBranchProtection returned ruleset ID %v, want 42", rs.GetID())
+	}
+	if !removed {
+		t.Error("Repositories.MigrateBranchProtection did not remove the branch protection")
+	}
+
+	wantLog := `o/r: branch "main": dropping unsupported setting: restrictions: user "octocat" cannot be a bypass actor
+o/r: branch "main": created ruleset "Migrated protection for main" (id 42)
+o/r: branch "main": removed classic protection
+`
+	if got := log.String(); got != wantLog {
+		t.Errorf("Repositories.MigrateBranchProtection audit log is:\n%v\nwant:\n%v", got, wantLog)
+	}
+
+	if _, err := client.Repositories.MigrateBranchProtection(ctx, "o", "r", nil, nil); err == nil {
+		t.Error("Repositories.MigrateBranchProtection returned nil error for nil migration, want error")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -547,3 +548,135 @@ func TestRepositoriesService_DeleteRuleset(t *testing.T) {
 		return client.Repositories.DeleteRuleset(ctx, "o", "repo", 42)
 	})
 }
+
+func TestRepositoriesService_ListRuleSuites(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/repo/rulesets/rule-suites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ref":               "refs/heads/main",
+			"time_period":       "week",
+			"actor_name":        "octocat",
+			"rule_suite_result": "bypass",
+			"page":              "2",
+		})
+		fmt.Fprint(w, `[{
+			"id": 21,
+			"actor_id": 12,
+			"actor_name": "octocat",
+			"before_sha": "893f768e172fb1bc9c5d6f3dd48557e45f14e01d",
+			"after_sha": "dedd88641a362b6b4ea872da4847d6131a164d01",
+			"ref": "refs/heads/main",
+			"repository_id": 404,
+			"repository_name": "repo",
+			"pushed_at": "2023-07-06T08:43:03Z",
+			"result": "bypass",
+			"evaluation_result": "fail"
+		}]`)
+	})
+
+	opts := &RuleSuiteListOptions{
+		Ref:             "refs/heads/main",
+		TimePeriod:      "week",
+		ActorName:       "octocat",
+		RuleSuiteResult: "bypass",
+		ListOptions:     ListOptions{Page: 2},
+	}
+	ctx := context.Background()
+	suites, _, err := client.Repositories.ListRuleSuites(ctx, "o", "repo", opts)
+	if err != nil {
+		t.Errorf("Repositories.ListRuleSuites returned error: %v", err)
+	}
+
+	want := []*RuleSuite{{
+		ID:               Int64(21),
+		ActorID:          Int64(12),
+		ActorName:        String("octocat"),
+		BeforeSHA:        String("893f768e172fb1bc9c5d6f3dd48557e45f14e01d"),
+		AfterSHA:         String("dedd88641a362b6b4ea872da4847d6131a164d01"),
+		Ref:              String("refs/heads/main"),
+		RepositoryID:     Int64(404),
+		RepositoryName:   String("repo"),
+		PushedAt:         &Timestamp{time.Date(2023, time.July, 6, 8, 43, 3, 0, time.UTC)},
+		Result:           String("bypass"),
+		EvaluationResult: String("fail"),
+	}}
+	if !cmp.Equal(suites, want) {
+		t.Errorf("Repositories.ListRuleSuites returned %+v, want %+v", suites, want)
+	}
+
+	const methodName = "ListRuleSuites"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Repositories.ListRuleSuites(ctx, "\n", "\n", opts)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Repositories.ListRuleSuites(ctx, "o", "repo", opts)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestRepositoriesService_GetRuleSuite(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/repo/rulesets/rule-suites/21", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"id": 21,
+			"actor_name": "octocat",
+			"ref": "refs/heads/main",
+			"result": "fail",
+			"rule_evaluations": [{
+				"rule_source": {"type": "ruleset", "id": 2, "name": "Require signed commits"},
+				"enforcement": "active",
+				"result": "fail",
+				"rule_type": "required_signatures",
+				"details": "Commit dedd886 is not signed"
+			}]
+		}`)
+	})
+
+	ctx := context.Background()
+	suite, _, err := client.Repositories.GetRuleSuite(ctx, "o", "repo", 21)
+	if err != nil {
+		t.Errorf("Repositories.GetRuleSuite returned error: %v", err)
+	}
+
+	want := &RuleSuite{
+		ID:        Int64(21),
+		ActorName: String("octocat"),
+		Ref:       String("refs/heads/main"),
+		Result:    String("fail"),
+		RuleEvaluations: []*RuleEvaluation{{
+			RuleSource:  &RuleSource{Type: String("ruleset"), ID: Int64(2), Name: String("Require signed commits")},
+			Enforcement: String("active"),
+			Result:      String("fail"),
+			RuleType:    String("required_signatures"),
+			Details:     String("Commit dedd886 is not signed"),
+		}},
+	}
+	if !cmp.Equal(suite, want) {
+		t.Errorf("Repositories.GetRuleSuite returned %+v, want %+v", suite, want)
+	}
+
+	const methodName = "GetRuleSuite"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Repositories.GetRuleSuite(ctx, "\n", "\n", 21)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Repositories.GetRuleSuite(ctx, "o", "repo", 21)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,816 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Possible values for RepositorySettingsChange.Section.
+const (
+	SettingsSectionRepository         = "repository"
+	SettingsSectionTopics             = "topics"
+	SettingsSectionActionsPermissions = "actions_permissions"
+	SettingsSectionCollaborators      = "collaborators"
+	SettingsSectionTeams              = "teams"
+	SettingsSectionAutolinks          = "autolinks"
+	SettingsSectionEnvironments       = "environments"
+	SettingsSectionBranchProtection   = "branch_protection"
+	SettingsSectionRulesets           = "rulesets"
+	SettingsSectionArchived           = "archived"
+)
+
+// RepositorySettings is a versionable document describing the settings of
+// a single repository. It is produced by RepositoriesService.ExportSettings
+// and compared against a desired document with DiffRepositorySettings.
+//
+// A nil section is unmanaged: it is neither exported into a diff nor
+// changed. A non-nil section, even an empty one, is authoritative, so
+// entries missing from it are removed from the repository.
+type RepositorySettings struct {
+	// Repository holds the general settings such as merge methods and
+	// features. Only the fields that are set are managed. A change to
+	// Archived is made after every other change when archiving, as an
+	// archived repository is read-only, and before them when unarchiving.
+	Repository *Repository `json:"repository,omitempty"`
+	Topics     []string    `json:"topics"`
+	// ActionsPermissions only manages the Enabled and AllowedActions fields.
+	ActionsPermissions *ActionsPermissionsRepository `json:"actions_permissions,omitempty"`
+	// Collaborators maps user logins to their permission on the repository.
+	// Users with a pending invitation are included with the permission they
+	// were invited with.
+	// Possible permission values are: pull, triage, push, maintain, admin.
+	Collaborators map[string]string `json:"collaborators"`
+	// Teams maps team slugs to their permission on the repository.
+	// Possible permission values are: pull, triage, push, maintain, admin.
+	Teams map[string]string `json:"teams"`
+	// Autolinks are keyed by their KeyPrefix.
+	Autolinks []*AutolinkOptions `json:"autolinks"`
+	// Environments maps environment names to their configuration.
+	Environments map[string]*CreateUpdateEnvironment `json:"environments"`
+	// BranchProtection maps branch names to their protection.
+	BranchProtection map[string]*ProtectionRequest `json:"branch_protection"`
+	// Rulesets are keyed by their Name. Rulesets inherited from the
+	// organization are not part of the document.
+	Rulesets []*Ruleset `json:"rulesets"`
+}
+
+// RepositorySettingsChange is a single difference between two
+// RepositorySettings documents.
+type RepositorySettingsChange struct {
+	// Section is the part of the document the change belongs to.
+	Section string
+	// Key identifies the entry within the section, such as a branch,
+	// login or ruleset name. It is empty for single-valued sections.
+	Key string
+	// Possible values for Action are: create, update, delete.
+	Action string
+	// Current is the current value. It is nil for creates.
+	Current interface{}
+	// Desired is the desired value. It is nil for deletes.
+	Desired interface{}
+}
+
+func (c *RepositorySettingsChange) describe() string {
+	if c.Key == "" {
+		return fmt.Sprintf("%v %v", c.Action, c.Section)
+	}
+	return fmt.Sprintf("%v %v %q", c.Action, c.Section, c.Key)
+}
+
+// ExportSettings reads the current settings of a repository into a
+// RepositorySettings document with every section populated.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/permissions#get-github-actions-permissions-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/branches/branch-protection#get-branch-protection
+// GitHub API docs: https://docs.github.com/rest/branches/branches#list-branches
+// GitHub API docs: https://docs.github.com/rest/collaborators/collaborators#list-repository-collaborators
+// GitHub API docs: https://docs.github.com/rest/collaborators/invitations#list-repository-invitations
+// GitHub API docs: https://docs.github.com/rest/deployments/environments#list-environments
+// GitHub API docs: https://docs.github.com/rest/repos/autolinks#list-all-autolinks-of-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/repos#get-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/repos#list-repository-teams
+// GitHub API docs: https://docs.github.com/rest/repos/rules#get-a-repository-ruleset
+// GitHub API docs: https://docs.github.com/rest/repos/rules#get-all-repository-rulesets
+//
+//meta:operation GET /repos/{owner}/{repo}
+//meta:operation GET /repos/{owner}/{repo}/actions/permissions
+//meta:operation GET /repos/{owner}/{repo}/autolinks
+//meta:operation GET /repos/{owner}/{repo}/branches
+//meta:operation GET /repos/{owner}/{repo}/branches/{branch}/protection
+//meta:operation GET /repos/{owner}/{repo}/collaborators
+//meta:operation GET /repos/{owner}/{repo}/environments
+//meta:operation GET /repos/{owner}/{repo}/invitations
+//meta:operation GET /repos/{owner}/{repo}/rulesets
+//meta:operation GET /repos/{owner}/{repo}/rulesets/{ruleset_id}
+//meta:operation GET /repos/{owner}/{repo}/teams
+func (s *RepositoriesService) ExportSettings(ctx context.Context, owner, repo string) (*RepositorySettings, error) {
+	r, _, err := s.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &RepositorySettings{
+		Repository: &Repository{
+			Description:              r.Description,
+			Homepage:                 r.Homepage,
+			Visibility:               r.Visibility,
+			DefaultBranch:            r.DefaultBranch,
+			HasIssues:                r.HasIssues,
+			HasProjects:              r.HasProjects,
+			HasWiki:                  r.HasWiki,
+			HasDiscussions:           r.HasDiscussions,
+			IsTemplate:               r.IsTemplate,
+			AllowMergeCommit:         r.AllowMergeCommit,
+			AllowSquashMerge:         r.AllowSquashMerge,
+			AllowRebaseMerge:         r.AllowRebaseMerge,
+			AllowAutoMerge:           r.AllowAutoMerge,
+			AllowUpdateBranch:        r.AllowUpdateBranch,
+			AllowForking:             r.AllowForking,
+			DeleteBranchOnMerge:      r.DeleteBranchOnMerge,
+			WebCommitSignoffRequired: r.WebCommitSignoffRequired,
+			SquashMergeCommitTitle:   r.SquashMergeCommitTitle,
+			SquashMergeCommitMessage: r.SquashMergeCommitMessage,
+			MergeCommitTitle:         r.MergeCommitTitle,
+			MergeCommitMessage:       r.MergeCommitMessage,
+			Archived:                 r.Archived,
+		},
+		Topics:           append([]string{}, r.Topics...),
+		Collaborators:    map[string]string{},
+		Teams:            map[string]string{},
+		Autolinks:        []*AutolinkOptions{},
+		Environments:     map[string]*CreateUpdateEnvironment{},
+		BranchProtection: map[string]*ProtectionRequest{},
+		Rulesets:         []*Ruleset{},
+	}
+
+	perms, _, err := s.GetActionsPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	settings.ActionsPermissions = &ActionsPermissionsRepository{Enabled: perms.Enabled, AllowedActions: perms.AllowedActions}
+
+	copts := &ListCollaboratorsOptions{Affiliation: "direct", ListOptions: ListOptions{PerPage: 100}}
+	for {
+		users, resp, err := s.ListCollaborators(ctx, owner, repo, copts)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			settings.Collaborators[u.GetLogin()] = normalizeRepoPermission(u.GetRoleName())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		copts.Page = resp.NextPage
+	}
+
+	// Invitees are not collaborators until they accept, but they must be
+	// part of the current state so that they are not invited again.
+	opts := &ListOptions{PerPage: 100}
+	for {
+		invites, resp, err := s.ListInvitations(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invites {
+			login := inv.GetInvitee().GetLogin()
+			if _, ok := settings.Collaborators[login]; login != "" && !ok {
+				settings.Collaborators[login] = normalizeRepoPermission(inv.GetPermissions())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	opts = &ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := s.ListTeams(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			settings.Teams[t.GetSlug()] = normalizeRepoPermission(t.GetPermission())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	autolinks, _, err := s.ListAutolinks(ctx, owner, repo, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range autolinks {
+		settings.Autolinks = append(settings.Autolinks, &AutolinkOptions{
+			KeyPrefix:      a.KeyPrefix,
+			URLTemplate:    a.URLTemplate,
+			IsAlphanumeric: a.IsAlphanumeric,
+		})
+	}
+
+	eopts := &EnvironmentListOptions{ListOptions: ListOptions{PerPage: 100}}
+	for {
+		envs, resp, err := s.ListEnvironments(ctx, owner, repo, eopts)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range envs.Environments {
+			settings.Environments[e.GetName()] = environmentRequest(e)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		eopts.Page = resp.NextPage
+	}
+
+	bopts := &BranchListOptions{Protected: Bool(true), ListOptions: ListOptions{PerPage: 100}}
+	for {
+		branches, resp, err := s.ListBranches(ctx, owner, repo, bopts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range branches {
+			p, _, err := s.GetBranchProtection(ctx, owner, repo, b.GetName())
+			if errors.Is(err, ErrBranchNotProtected) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			settings.BranchProtection[b.GetName()] = protectionRequest(p)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		bopts.Page = resp.NextPage
+	}
+
+	rulesets, _, err := s.GetAllRulesets(ctx, owner, repo, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range rulesets {
+		full, _, err := s.GetRuleset(ctx, owner, repo, rs.GetID(), false)
+		if err != nil {
+			return nil, err
+		}
+		settings.Rulesets = append(settings.Rulesets, full)
+	}
+
+	return settings, nil
+}
+
+// DiffRepositorySettings returns the changes needed to turn current into
+// desired, in the order they should be applied. Sections that are nil in
+// desired are skipped.
+//
+// Archiving or unarchiving the repository is a separate change in the
+// SettingsSectionArchived section, whose Desired value is a bool. It comes
+// first when unarchiving and last when archiving.
+func DiffRepositorySettings(current, desired *RepositorySettings) ([]*RepositorySettingsChange, error) {
+	if current == nil || desired == nil {
+		return nil, errors.New("both current and desired settings must be provided")
+	}
+
+	var changes []*RepositorySettingsChange
+	var archive *RepositorySettingsChange
+
+	if desired.Repository != nil {
+		want := *desired.Repository
+		if want.Archived != nil && want.GetArchived() != current.Repository.GetArchived() {
+			archive = &RepositorySettingsChange{Section: SettingsSectionArchived, Action: SyncActionUpdate, Current: current.Repository.GetArchived(), Desired: want.GetArchived()}
+			if !want.GetArchived() {
+				changes = append(changes, archive)
+				archive = nil
+			}
+		}
+		want.Archived = nil
+		cur := current.Repository
+		if cur != nil {
+			c := *cur
+			c.Archived = nil
+			cur = &c
+		}
+
+		patch, err := repositoryPatch(cur, &want)
+		if err != nil {
+			return nil, err
+		}
+		if patch != nil {
+			changes = append(changes, &RepositorySettingsChange{Section: SettingsSectionRepository, Action: SyncActionUpdate, Current: current.Repository, Desired: patch})
+		}
+	}
+
+	if desired.Topics != nil {
+		cur := append([]string{}, current.Topics...)
+		want := append([]string{}, desired.Topics...)
+		sort.Strings(cur)
+		sort.Strings(want)
+		if !jsonEqual(cur, want) {
+			changes = append(changes, &RepositorySettingsChange{Section: SettingsSectionTopics, Action: SyncActionUpdate, Current: current.Topics, Desired: desired.Topics})
+		}
+	}
+
+	if desired.ActionsPermissions != nil {
+		want := &ActionsPermissionsRepository{Enabled: desired.ActionsPermissions.Enabled, AllowedActions: desired.ActionsPermissions.AllowedActions}
+		var cur *ActionsPermissionsRepository
+		if current.ActionsPermissions != nil {
+			cur = &ActionsPermissionsRepository{Enabled: current.ActionsPermissions.Enabled, AllowedActions: current.ActionsPermissions.AllowedActions}
+		}
+		if !jsonEqual(cur, want) {
+			changes = append(changes, &RepositorySettingsChange{Section: SettingsSectionActionsPermissions, Action: SyncActionUpdate, Current: current.ActionsPermissions, Desired: want})
+		}
+	}
+
+	if desired.Collaborators != nil {
+		changes = append(changes, diffPermissions(SettingsSectionCollaborators, current.Collaborators, desired.Collaborators)...)
+	}
+	if desired.Teams != nil {
+		changes = append(changes, diffPermissions(SettingsSectionTeams, current.Teams, desired.Teams)...)
+	}
+
+	if desired.Autolinks != nil {
+		cur := make(map[string]interface{}, len(current.Autolinks))
+		for _, a := range current.Autolinks {
+			cur[a.GetKeyPrefix()] = a
+		}
+		want := make(map[string]interface{}, len(desired.Autolinks))
+		for _, a := range desired.Autolinks {
+			want[a.GetKeyPrefix()] = a
+		}
+		changes = append(changes, diffKeyed(SettingsSectionAutolinks, cur, want)...)
+	}
+
+	if desired.Environments != nil {
+		cur := make(map[string]interface{}, len(current.Environments))
+		for k, v := range current.Environments {
+			cur[k] = v
+		}
+		want := make(map[string]interface{}, len(desired.Environments))
+		for k, v := range desired.Environments {
+			want[k] = v
+		}
+		changes = append(changes, diffKeyed(SettingsSectionEnvironments, cur, want)...)
+	}
+
+	if desired.BranchProtection != nil {
+		cur := make(map[string]interface{}, len(current.BranchProtection))
+		for k, v := range current.BranchProtection {
+			cur[k] = v
+		}
+		want := make(map[string]interface{}, len(desired.BranchProtection))
+		for k, v := range desired.BranchProtection {
+			want[k] = v
+		}
+		changes = append(changes, diffKeyed(SettingsSectionBranchProtection, cur, want)...)
+	}
+
+	if desired.Rulesets != nil {
+		cur := make(map[string]interface{}, len(current.Rulesets))
+		for _, rs := range current.Rulesets {
+			cur[rs.Name] = rs
+		}
+		want := make(map[string]interface{}, len(desired.Rulesets))
+		for _, rs := range desired.Rulesets {
+			want[rs.Name] = rs
+		}
+		changes = append(changes, diffKeyed(SettingsSectionRulesets, cur, want)...)
+	}
+
+	if archive != nil {
+		changes = append(changes, archive)
+	}
+	return changes, nil
+}
+
+// ApplySettings applies changes produced by DiffRepositorySettings to a
+// repository. Changes are applied in order and the first failure stops
+// the run; the error names the change that failed. Collaborators that
+// only have a pending invitation are updated or removed through their
+// invitation.
+//
+// GitHub API docs: https://docs.github.com/rest/actions/permissions#set-github-actions-permissions-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/branches/branch-protection#delete-branch-protection
+// GitHub API docs: https://docs.github.com/rest/branches/branch-protection#update-branch-protection
+// GitHub API docs: https://docs.github.com/rest/collaborators/collaborators#add-a-repository-collaborator
+// GitHub API docs: https://docs.github.com/rest/collaborators/collaborators#remove-a-repository-collaborator
+// GitHub API docs: https://docs.github.com/rest/collaborators/invitations#delete-a-repository-invitation
+// GitHub API docs: https://docs.github.com/rest/collaborators/invitations#list-repository-invitations
+// GitHub API docs: https://docs.github.com/rest/collaborators/invitations#update-a-repository-invitation
+// GitHub API docs: https://docs.github.com/rest/deployments/environments#create-or-update-an-environment
+// GitHub API docs: https://docs.github.com/rest/deployments/environments#delete-an-environment
+// GitHub API docs: https://docs.github.com/rest/repos/autolinks#create-an-autolink-reference-for-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/autolinks#delete-an-autolink-reference-from-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/autolinks#list-all-autolinks-of-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/repos#replace-all-repository-topics
+// GitHub API docs: https://docs.github.com/rest/repos/repos#update-a-repository
+// GitHub API docs: https://docs.github.com/rest/repos/rules#create-a-repository-ruleset
+// GitHub API docs: https://docs.github.com/rest/repos/rules#delete-a-repository-ruleset
+// GitHub API docs: https://docs.github.com/rest/repos/rules#update-a-repository-ruleset
+// GitHub API docs: https://docs.github.com/rest/teams/teams#add-or-update-team-repository-permissions
+// GitHub API docs: https://docs.github.com/rest/teams/teams#remove-a-repository-from-a-team
+//
+//meta:operation DELETE /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}
+//meta:operation PUT /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}
+//meta:operation PATCH /repos/{owner}/{repo}
+//meta:operation PUT /repos/{owner}/{repo}/actions/permissions
+//meta:operation GET /repos/{owner}/{repo}/autolinks
+//meta:operation POST /repos/{owner}/{repo}/autolinks
+//meta:operation DELETE /repos/{owner}/{repo}/autolinks/{autolink_id}
+//meta:operation DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+//meta:operation PUT /repos/{owner}/{repo}/branches/{branch}/protection
+//meta:operation DELETE /repos/{owner}/{repo}/collaborators/{username}
+//meta:operation PUT /repos/{owner}/{repo}/collaborators/{username}
+//meta:operation DELETE /repos/{owner}/{repo}/environments/{environment_name}
+//meta:operation PUT /repos/{owner}/{repo}/environments/{environment_name}
+//meta:operation GET /repos/{owner}/{repo}/invitations
+//meta:operation DELETE /repos/{owner}/{repo}/invitations/{invitation_id}
+//meta:operation PATCH /repos/{owner}/{repo}/invitations/{invitation_id}
+//meta:operation POST /repos/{owner}/{repo}/rulesets
+//meta:operation DELETE /repos/{owner}/{repo}/rulesets/{ruleset_id}
+//meta:operation PUT /repos/{owner}/{repo}/rulesets/{ruleset_id}
+//meta:operation PUT /repos/{owner}/{repo}/topics
+func (s *RepositoriesService) ApplySettings(ctx context.Context, owner, repo string, changes []*RepositorySettingsChange) error {
+	for _, c := range changes {
+		if err := s.applySettingsChange(ctx, owner, repo, c); err != nil {
+			return fmt.Errorf("%v: %w", c.describe(), err)
+		}
+	}
+	return nil
+}
+
+func (s *RepositoriesService) applySettingsChange(ctx context.Context, owner, repo string, c *RepositorySettingsChange) error {
+	var err error
+	switch c.Section {
+	case SettingsSectionRepository:
+		_, _, err = s.Edit(ctx, owner, repo, c.Desired.(*Repository))
+	case SettingsSectionArchived:
+		_, _, err = s.Edit(ctx, owner, repo, &Repository{Archived: Bool(c.Desired.(bool))})
+	case SettingsSectionTopics:
+		_, _, err = s.ReplaceAllTopics(ctx, owner, repo, c.Desired.([]string))
+	case SettingsSectionActionsPermissions:
+		_, _, err = s.EditActionsPermissions(ctx, owner, repo, *c.Desired.(*ActionsPermissionsRepository))
+	case SettingsSectionCollaborators:
+		err = s.applyCollaboratorChange(ctx, owner, repo, c)
+	case SettingsSectionTeams:
+		if c.Action == SyncActionDelete {
+			_, err = s.client.Teams.RemoveTeamRepoBySlug(ctx, owner, c.Key, owner, repo)
+			break
+		}
+		_, err = s.client.Teams.AddTeamRepoBySlug(ctx, owner, c.Key, owner, repo, &TeamAddTeamRepoOptions{Permission: c.Desired.(string)})
+	case SettingsSectionAutolinks:
+		// Autolinks cannot be edited, so updates are a delete followed by an add.
+		if c.Action != SyncActionCreate {
+			if err = s.deleteAutolinkByPrefix(ctx, owner, repo, c.Key); err != nil {
+				break
+			}
+		}
+		if c.Action != SyncActionDelete {
+			_, _, err = s.AddAutolink(ctx, owner, repo, c.Desired.(*AutolinkOptions))
+		}
+	case SettingsSectionEnvironments:
+		if c.Action == SyncActionDelete {
+			_, err = s.DeleteEnvironment(ctx, owner, repo, c.Key)
+			break
+		}
+		_, _, err = s.CreateUpdateEnvironment(ctx, owner, repo, c.Key, c.Desired.(*CreateUpdateEnvironment))
+	case SettingsSectionBranchProtection:
+		if c.Action == SyncActionDelete {
+			_, err = s.RemoveBranchProtection(ctx, owner, repo, c.Key)
+			break
+		}
+		_, _, err = s.UpdateBranchProtection(ctx, owner, repo, c.Key, c.Desired.(*ProtectionRequest))
+	case SettingsSectionRulesets:
+		switch c.Action {
+		case SyncActionCreate:
+			_, _, err = s.CreateRuleset(ctx, owner, repo, c.Desired.(*Ruleset))
+		case SyncActionUpdate:
+			_, _, err = s.UpdateRuleset(ctx, owner, repo, c.Current.(*Ruleset).GetID(), c.Desired.(*Ruleset))
+		case SyncActionDelete:
+			_, err = s.DeleteRuleset(ctx, owner, repo, c.Current.(*Ruleset).GetID())
+		}
+	default:
+		err = fmt.Errorf("unknown section %q", c.Section)
+	}
+	return err
+}
+
+func (s *RepositoriesService) deleteAutolinkByPrefix(ctx context.Context, owner, repo, prefix string) error {
+	autolinks, _, err := s.ListAutolinks(ctx, owner, repo, nil)
+	if err != nil {
+		return err
+	}
+	for _, a := range autolinks {
+		if a.GetKeyPrefix() == prefix {
+			_, err := s.DeleteAutolink(ctx, owner, repo, a.GetID())
+			return err
+		}
+	}
+	return nil
+}
+
+// applyCollaboratorChange applies a change to a collaborator, or to their
+// pending invitation if they have not accepted it yet.
+func (s *RepositoriesService) applyCollaboratorChange(ctx context.Context, owner, repo string, c *RepositorySettingsChange) error {
+	if c.Action != SyncActionCreate {
+		inv, err := s.findInvitation(ctx, owner, repo, c.Key)
+		if err != nil {
+			return err
+		}
+		if inv != nil {
+			if c.Action == SyncActionDelete {
+				_, err = s.DeleteInvitation(ctx, owner, repo, inv.GetID())
+				return err
+			}
+			_, _, err = s.UpdateInvitation(ctx, owner, repo, inv.GetID(), invitationPermission(c.Desired.(string)))
+			return err
+		}
+	}
+
+	if c.Action == SyncActionDelete {
+		_, err := s.RemoveCollaborator(ctx, owner, repo, c.Key)
+		return err
+	}
+	_, _, err := s.AddCollaborator(ctx, owner, repo, c.Key, &RepositoryAddCollaboratorOptions{Permission: c.Desired.(string)})
+	return err
+}
+
+// findInvitation returns the pending invitation of the user login, or nil
+// if there is none.
+func (s *RepositoriesService) findInvitation(ctx context.Context, owner, repo, login string) (*RepositoryInvitation, error) {
+	opts := &ListOptions{PerPage: 100}
+	for {
+		invites, resp, err := s.ListInvitations(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invites {
+			if inv.GetInvitee().GetLogin() == login {
+				return inv, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// repositoryPatch returns a Repository holding only the fields of desired
+// that differ from current, or nil if there are none.
+func repositoryPatch(current, desired *Repository) (*Repository, error) {
+	var cur, want map[string]json.RawMessage
+	if err := roundTripJSON(current, &cur); err != nil {
+		return nil, err
+	}
+	if err := roundTripJSON(desired, &want); err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]json.RawMessage)
+	for k, v := range want {
+		if !bytes.Equal(cur[k], v) {
+			diff[k] = v
+		}
+	}
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	patch := new(Repository)
+	if err := roundTripJSON(diff, patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+func diffPermissions(section string, current, desired map[string]string) []*RepositorySettingsChange {
+	var changes []*RepositorySettingsChange
+	for _, k := range sortedKeys(desired) {
+		want := normalizeRepoPermission(desired[k])
+		cur, ok := current[k]
+		switch {
+		case !ok:
+			changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionCreate, Desired: want})
+		case normalizeRepoPermission(cur) != want:
+			changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionUpdate, Current: cur, Desired: want})
+		}
+	}
+	for _, k := range sortedKeys(current) {
+		if _, ok := desired[k]; !ok {
+			changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionDelete, Current: current[k]})
+		}
+	}
+	return changes
+}
+
+// diffKeyed compares two keyed collections by their JSON representation,
+// ignoring server-assigned fields of rulesets.
+func diffKeyed(section string, current, desired map[string]interface{}) []*RepositorySettingsChange {
+	var changes []*RepositorySettingsChange
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cur, ok := current[k]
+		switch {
+		case !ok:
+			changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionCreate, Desired: desired[k]})
+		case !jsonEqual(comparableSetting(cur), comparableSetting(desired[k])):
+			changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionUpdate, Current: cur, Desired: desired[k]})
+		}
+	}
+
+	keys = keys[:0]
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		changes = append(changes, &RepositorySettingsChange{Section: section, Key: k, Action: SyncActionDelete, Current: current[k]})
+	}
+	return changes
+}
+
+func comparableSetting(v interface{}) interface{} {
+	rs, ok := v.(*Ruleset)
+	if !ok {
+		return v
+	}
+	c := *rs
+	c.ID = nil
+	c.NodeID = nil
+	c.Links = nil
+	c.Source = ""
+	c.SourceType = nil
+	return &c
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeRepoPermission maps the role names reported by the API to the
+// permission names accepted when granting access.
+func normalizeRepoPermission(p string) string {
+	switch p {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	}
+	return p
+}
+
+// invitationPermission maps a permission name accepted when granting
+// access to the name accepted by invitations.
+func invitationPermission(p string) string {
+	switch p {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	}
+	return p
+}
+
+// protectionRequest converts a branch's protection, as returned by the API,
+// into the request that would recreate it.
+func protectionRequest(p *Protection) *ProtectionRequest {
+	req := &ProtectionRequest{
+		RequiredStatusChecks: p.RequiredStatusChecks,
+	}
+	if p.EnforceAdmins != nil {
+		req.EnforceAdmins = p.EnforceAdmins.Enabled
+	}
+	if rsc := req.RequiredStatusChecks; rsc != nil {
+		req.RequiredStatusChecks = &RequiredStatusChecks{Strict: rsc.Strict, Contexts: rsc.Contexts, Checks: rsc.Checks}
+		if len(rsc.Checks) > 0 {
+			// Only one of Contexts and Checks may be sent.
+			req.RequiredStatusChecks.Contexts = nil
+		}
+	}
+	if r := p.RequiredPullRequestReviews; r != nil {
+		req.RequiredPullRequestReviews = &PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          r.DismissStaleReviews,
+			RequireCodeOwnerReviews:      r.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: r.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      Bool(r.RequireLastPushApproval),
+		}
+		if d := r.DismissalRestrictions; d != nil {
+			users, teams, apps := actorSlugs(d.Users, d.Teams, d.Apps)
+			req.RequiredPullRequestReviews.DismissalRestrictionsRequest = &DismissalRestrictionsRequest{Users: &users, Teams: &teams, Apps: &apps}
+		}
+		if b := r.BypassPullRequestAllowances; b != nil {
+			users, teams, apps := actorSlugs(b.Users, b.Teams, b.Apps)
+			req.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &BypassPullRequestAllowancesRequest{Users: users, Teams: teams, Apps: apps}
+		}
+	}
+	if r := p.Restrictions; r != nil {
+		users, teams, apps := actorSlugs(r.Users, r.Teams, r.Apps)
+		req.Restrictions = &BranchRestrictionsRequest{Users: users, Teams: teams, Apps: apps}
+	}
+	if p.RequireLinearHistory != nil {
+		req.RequireLinearHistory = Bool(p.RequireLinearHistory.Enabled)
+	}
+	if p.AllowForcePushes != nil {
+		req.AllowForcePushes = Bool(p.AllowForcePushes.Enabled)
+	}
+	if p.AllowDeletions != nil {
+		req.AllowDeletions = Bool(p.AllowDeletions.Enabled)
+	}
+	if p.RequiredConversationResolution != nil {
+		req.RequiredConversationResolution = Bool(p.RequiredConversationResolution.Enabled)
+	}
+	if p.BlockCreations != nil {
+		req.BlockCreations = p.BlockCreations.Enabled
+	}
+	if p.LockBranch != nil {
+		req.LockBranch = p.LockBranch.Enabled
+	}
+	if p.AllowForkSyncing != nil {
+		req.AllowForkSyncing = p.AllowForkSyncing.Enabled
+	}
+	return req
+}
+
+func actorSlugs(users []*User, teams []*Team, apps []*App) (userLogins, teamSlugs, appSlugs []string) {
+	userLogins, teamSlugs, appSlugs = []string{}, []string{}, []string{}
+	for _, u := range users {
+		userLogins = append(userLogins, u.GetLogin())
+	}
+	for _, t := range teams {
+		teamSlugs = append(teamSlugs, t.GetSlug())
+	}
+	for _, a := range apps {
+		appSlugs = append(appSlugs, a.GetSlug())
+	}
+	return userLogins, teamSlugs, appSlugs
+}
+
+// environmentRequest converts an environment, as returned by the API, into
+// the request that would recreate it.
+func environmentRequest(e *Environment) *CreateUpdateEnvironment {
+	req := &CreateUpdateEnvironment{
+		CanAdminsBypass:        e.CanAdminsBypass,
+		DeploymentBranchPolicy: e.DeploymentBranchPolicy,
+	}
+	for _, rule := range e.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			req.WaitTimer = rule.WaitTimer
+		case "required_reviewers":
+			req.PreventSelfReview = rule.PreventSelfReview
+			for _, r := range rule.Reviewers {
+				switch v := r.Reviewer.(type) {
+				case *User:
+					req.Reviewers = append(req.Reviewers, &EnvReviewers{Type: String("User"), ID: v.ID})
+				case *Team:
+					req.Reviewers = append(req.Reviewers, &EnvReviewers{Type: String("Team"), ID: v.ID})
+				}
+			}
+		}
+	}
+	return req
+}
+
+func roundTripJSON(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
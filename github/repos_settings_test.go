@@ -0,0 +1,337 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRepositoriesService_ExportSettings(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 1, "name": "r", "description": "d", "delete_branch_on_merge": true, "allow_squash_merge": true, "topics": ["go"], "stargazers_count": 10}`)
+	})
+	mux.HandleFunc("/repos/o/r/actions/permissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"enabled": true, "allowed_actions": "selected", "selected_actions_url": "u"}`)
+	})
+	mux.HandleFunc("/repos/o/r/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"affiliation": "direct", "per_page": "100"})
+		fmt.Fprint(w, `[{"login": "alice", "role_name": "write"}, {"login": "bob", "role_name": "admin"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/invitations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"per_page": "100"})
+		fmt.Fprint(w, `[{"id": 1, "invitee": {"login": "carol"}, "permissions": "read"}, {"id": 2, "invitee": {"login": "bob"}, "permissions": "write"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"slug": "core", "permission": "maintain"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/autolinks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": 1, "key_prefix": "JIRA-", "url_template": "https://j/<num>", "is_alphanumeric": false}]`)
+	})
+	mux.HandleFunc("/repos/o/r/environments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"total_count": 1, "environments": [{"name": "prod", "can_admins_bypass": false, "protection_rules": [
+			{"type": "wait_timer", "wait_timer": 30},
+			{"type": "required_reviewers", "prevent_self_review": true, "reviewers": [{"type": "Team", "reviewer": {"id": 5, "slug": "core"}}]}
+		]}]}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"protected": "true", "per_page": "100"})
+		fmt.Fprint(w, `[{"name": "main", "protected": true}]`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"required_status_checks": {"strict": true, "contexts": ["ci"], "checks": [{"context": "ci"}]},
+			"required_pull_request_reviews": {"required_approving_review_count": 2, "dismissal_restrictions": {"users": [{"login": "bob"}], "teams": [], "apps": []}},
+			"enforce_admins": {"enabled": true},
+			"required_linear_history": {"enabled": true}
+		}`)
+	})
+	mux.HandleFunc("/repos/o/r/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": 7, "name": "tags", "source": "o/r", "enforcement": "active"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/rulesets/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 7, "name": "tags", "target": "tag", "source": "o/r", "enforcement": "active", "rules": [{"type": "deletion"}]}`)
+	})
+
+	ctx := context.Background()
+	got, err := client.Repositories.ExportSettings(ctx, "o", "r")
+	if err != nil {
+		t.Fatalf("Repositories.ExportSettings returned error: %v", err)
+	}
+
+	want := &RepositorySettings{
+		Repository: &Repository{
+			Description:         String("d"),
+			AllowSquashMerge:    Bool(true),
+			DeleteBranchOnMerge: Bool(true),
+		},
+		Topics:             []string{"go"},
+		ActionsPermissions: &ActionsPermissionsRepository{Enabled: Bool(true), AllowedActions: String("selected")},
+		Collaborators:      map[string]string{"alice": "push", "bob": "admin", "carol": "pull"},
+		Teams:              map[string]string{"core": "maintain"},
+		Autolinks: []*AutolinkOptions{
+			{KeyPrefix: String("JIRA-"), URLTemplate: String("https://j/<num>"), IsAlphanumeric: Bool(false)},
+		},
+		Environments: map[string]*CreateUpdateEnvironment{
+			"prod": {
+				WaitTimer:         Int(30),
+				Reviewers:         []*EnvReviewers{{Type: String("Team"), ID: Int64(5)}},
+				CanAdminsBypass:   Bool(false),
+				PreventSelfReview: Bool(true),
+			},
+		},
+		BranchProtection: map[string]*ProtectionRequest{
+			"main": {
+				RequiredStatusChecks: &RequiredStatusChecks{Strict: true, Checks: []*RequiredStatusCheck{{Context: "ci"}}},
+				RequiredPullRequestReviews: &PullRequestReviewsEnforcementRequest{
+					RequiredApprovingReviewCount: 2,
+					RequireLastPushApproval:      Bool(false),
+					DismissalRestrictionsRequest: &DismissalRestrictionsRequest{
+						Users: &[]string{"bob"},
+						Teams: &[]string{},
+						Apps:  &[]string{},
+					},
+				},
+				EnforceAdmins:        true,
+				RequireLinearHistory: Bool(true),
+			},
+		},
+		Rulesets: []*Ruleset{
+			{ID: Int64(7), Name: "tags", Target: String("tag"), Source: "o/r", Enforcement: "active", Rules: []*RepositoryRule{NewDeletionRule()}},
+		},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Repositories.ExportSettings returned diff (-want +got):\n%v", cmp.Diff(want, got))
+	}
+}
+
+func TestDiffRepositorySettings(t *testing.T) {
+	current := &RepositorySettings{
+		Repository:         &Repository{Description: String("d"), DeleteBranchOnMerge: Bool(false), AllowSquashMerge: Bool(true)},
+		Topics:             []string{"b", "a"},
+		ActionsPermissions: &ActionsPermissionsRepository{Enabled: Bool(true), AllowedActions: String("all"), SelectedActionsURL: String("u")},
+		Collaborators:      map[string]string{"alice": "push", "carol": "pull"},
+		Teams:              map[string]string{"core": "maintain"},
+		Autolinks:          []*AutolinkOptions{{KeyPrefix: String("JIRA-"), URLTemplate: String("https://j/<num>")}},
+		BranchProtection: map[string]*ProtectionRequest{
+			"main":    {EnforceAdmins: true},
+			"release": {EnforceAdmins: true},
+		},
+		Rulesets: []*Ruleset{
+			{ID: Int64(7), Name: "tags", Source: "o/r", Enforcement: "active"},
+			{ID: Int64(8), Name: "old", Source: "o/r", Enforcement: "active"},
+		},
+	}
+	desired := &RepositorySettings{
+		Repository:         &Repository{Description: String("d"), DeleteBranchOnMerge: Bool(true)},
+		Topics:             []string{"a", "b"},
+		ActionsPermissions: &ActionsPermissionsRepository{Enabled: Bool(true), AllowedActions: String("all")},
+		Collaborators:      map[string]string{"alice": "write", "bob": "admin"},
+		Autolinks:          []*AutolinkOptions{{KeyPrefix: String("JIRA-"), URLTemplate: String("https://jira/<num>")}},
+		BranchProtection: map[string]*ProtectionRequest{
+			"main": {EnforceAdmins: false},
+		},
+		Rulesets: []*Ruleset{
+			{Name: "tags", Enforcement: "active"},
+			{Name: "new", Enforcement: "evaluate"},
+		},
+	}
+
+	got, err := DiffRepositorySettings(current, desired)
+	if err != nil {
+		t.Fatalf("DiffRepositorySettings returned error: %v", err)
+	}
+
+	var gotSummary []string
+	for _, c := range got {
+		gotSummary = append(gotSummary, c.describe())
+	}
+	want := []string{
+		"update repository",
+		`create collaborators "bob"`,
+		`delete collaborators "carol"`,
+		`update autolinks "JIRA-"`,
+		`update branch_protection "main"`,
+		`delete branch_protection "release"`,
+		`create rulesets "new"`,
+		`delete rulesets "old"`,
+	}
+	if !cmp.Equal(gotSummary, want) {
+		t.Errorf("DiffRepositorySettings returned diff (-want +got):\n%v", cmp.Diff(want, gotSummary))
+	}
+
+	if patch := got[0].Desired; !cmp.Equal(patch, &Repository{DeleteBranchOnMerge: Bool(true)}) {
+		t.Errorf("DiffRepositorySettings repository patch is %+v, want only DeleteBranchOnMerge", patch)
+	}
+
+	if _, err := DiffRepositorySettings(nil, desired); err == nil {
+		t.Error("DiffRepositorySettings returned nil error, want error")
+	}
+}
+
+func TestDiffRepositorySettings_archived(t *testing.T) {
+	current := &RepositorySettings{
+		Repository: &Repository{Description: String("d"), Archived: Bool(false)},
+		Topics:     []string{"a"},
+	}
+	desired := &RepositorySettings{
+		Repository: &Repository{Description: String("new"), Archived: Bool(true)},
+		Topics:     []string{"b"},
+	}
+
+	describe := func(changes []*RepositorySettingsChange) []string {
+		var summary []string
+		for _, c := range changes {
+			summary = append(summary, c.describe())
+		}
+		return summary
+	}
+
+	// An archived repository is read-only, so it is archived last.
+	got, err := DiffRepositorySettings(current, desired)
+	if err != nil {
+		t.Fatalf("DiffRepositorySettings returned error: %v", err)
+	}
+	want := []string{"update repository", "update topics", "update archived"}
+	if !cmp.Equal(describe(got), want) {
+		t.Errorf("DiffRepositorySettings returned diff (-want +got):\n%v", cmp.Diff(want, describe(got)))
+	}
+	if patch := got[0].Desired; !cmp.Equal(patch, &Repository{Description: String("new")}) {
+		t.Errorf("DiffRepositorySettings repository patch is %+v, want only Description", patch)
+	}
+	if got[2].Desired != true {
+		t.Errorf("DiffRepositorySettings archived change is %+v, want true", got[2].Desired)
+	}
+
+	// It is unarchived before anything else is changed.
+	got, err = DiffRepositorySettings(desired, current)
+	if err != nil {
+		t.Fatalf("DiffRepositorySettings returned error: %v", err)
+	}
+	want = []string{"update archived", "update repository", "update topics"}
+	if !cmp.Equal(describe(got), want) {
+		t.Errorf("DiffRepositorySettings returned diff (-want +got):\n%v", cmp.Diff(want, describe(got)))
+	}
+	if got[0].Desired != false {
+		t.Errorf("DiffRepositorySettings archived change is %+v, want false", got[0].Desired)
+	}
+}
+
+func TestRepositoriesService_ApplySettings(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls []string
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		switch string(body) {
+		case `{"delete_branch_on_merge":true}` + "\n":
+			calls = append(calls, "edit")
+		case `{"archived":true}` + "\n":
+			calls = append(calls, "archive")
+		default:
+			t.Errorf("unexpected repository edit %s", body)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/repos/o/r/collaborators/bob", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"permission":"admin"}`+"\n")
+		calls = append(calls, "add bob")
+	})
+	mux.HandleFunc("/repos/o/r/collaborators/erin", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		calls = append(calls, "remove erin")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/invitations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": 1, "invitee": {"login": "carol"}, "permissions": "read"}, {"id": 2, "invitee": {"login": "dave"}, "permissions": "read"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/invitations/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		calls = append(calls, "uninvite carol")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/invitations/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"permissions":"write"}`+"\n")
+		calls = append(calls, "update dave")
+		fmt.Fprint(w, `{"id": 2}`)
+	})
+	mux.HandleFunc("/orgs/o/teams/core/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		calls = append(calls, "remove core")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/autolinks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"id": 3, "key_prefix": "JIRA-"}]`)
+		case "POST":
+			testBody(t, r, `{"key_prefix":"JIRA-","url_template":"https://jira/<num>"}`+"\n")
+			calls = append(calls, "add autolink")
+			fmt.Fprint(w, `{"id": 4}`)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/autolinks/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		calls = append(calls, "delete autolink")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/rulesets/8", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		calls = append(calls, "delete ruleset")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	changes := []*RepositorySettingsChange{
+		{Section: SettingsSectionRepository, Action: SyncActionUpdate, Desired: &Repository{DeleteBranchOnMerge: Bool(true)}},
+		{Section: SettingsSectionCollaborators, Key: "bob", Action: SyncActionCreate, Desired: "admin"},
+		{Section: SettingsSectionCollaborators, Key: "carol", Action: SyncActionDelete, Current: "pull"},
+		{Section: SettingsSectionCollaborators, Key: "dave", Action: SyncActionUpdate, Current: "pull", Desired: "push"},
+		{Section: SettingsSectionCollaborators, Key: "erin", Action: SyncActionDelete, Current: "push"},
+		{Section: SettingsSectionTeams, Key: "core", Action: SyncActionDelete, Current: "maintain"},
+		{Section: SettingsSectionAutolinks, Key: "JIRA-", Action: SyncActionUpdate, Desired: &AutolinkOptions{KeyPrefix: String("JIRA-"), URLTemplate: String("https://jira/<num>")}},
+		{Section: SettingsSectionRulesets, Key: "old", Action: SyncActionDelete, Current: &Ruleset{ID: Int64(8), Name: "old"}},
+		{Section: SettingsSectionArchived, Action: SyncActionUpdate, Current: false, Desired: true},
+	}
+
+	ctx := context.Background()
+	if err := client.Repositories.ApplySettings(ctx, "o", "r", changes); err != nil {
+		t.Fatalf("Repositories.ApplySettings returned error: %v", err)
+	}
+
+	want := []string{"edit", "add bob", "uninvite carol", "update dave", "remove erin", "remove core", "delete autolink", "add autolink", "delete ruleset", "archive"}
+	if !cmp.Equal(calls, want) {
+		t.Errorf("Repositories.ApplySettings made calls %v, want %v", calls, want)
+	}
+
+	err := client.Repositories.ApplySettings(ctx, "o", "r", []*RepositorySettingsChange{{Section: "bogus"}})
+	if err == nil {
+		t.Error("Repositories.ApplySettings returned nil error for unknown section, want error")
+	}
+}
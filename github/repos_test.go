@@ -389,6 +389,30 @@ func TestRepositoriesService_Get(t *testing.T) {
 	})
 }
 
+func TestRepositoriesService_Get_customProperties(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1,"custom_properties":{"environment":"production","teams":["web","infra"]}}`)
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Repositories.Get(ctx, "o", "r")
+	if err != nil {
+		t.Fatalf("Repositories.Get returned error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"environment": "production",
+		"teams":       []interface{}{"web", "infra"},
+	}
+	if !cmp.Equal(got.CustomProperties, want) {
+		t.Errorf("Repositories.Get returned custom properties %+v, want %+v", got.CustomProperties, want)
+	}
+}
+
 func TestRepositoriesService_GetCodeOfConduct(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
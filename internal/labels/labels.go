@@ -0,0 +1,20 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package labels holds helpers for runner and issue labels shared by the
+// packages of this module. GitHub compares label names case-insensitively.
+package labels
+
+import "strings"
+
+// ContainsFold reports whether labels contains name, ignoring case.
+func ContainsFold(labels []string, name string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labels
+
+import "testing"
+
+func TestContainsFold(t *testing.T) {
+	labels := []string{"self-hosted", "Linux", "GPU"}
+	for _, name := range []string{"linux", "LINUX", "gpu", "self-hosted"} {
+		if !ContainsFold(labels, name) {
+			t.Errorf("ContainsFold(%q, %q) = false, want true", labels, name)
+		}
+	}
+	for _, name := range []string{"windows", "", "gp"} {
+		if ContainsFold(labels, name) {
+			t.Errorf("ContainsFold(%q, %q) = true, want false", labels, name)
+		}
+	}
+}
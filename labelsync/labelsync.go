@@ -0,0 +1,72 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package labelsync parses label and milestone specs written in YAML for
+// IssuesService.SyncLabels. It is a separate module so that the github
+// package does not depend on a YAML parser.
+package labelsync
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/v56/github"
+	"gopkg.in/yaml.v3"
+)
+
+// Parse parses a YAML or JSON encoded github.LabelSyncSpec and validates
+// it. The fields have the same names as in JSON, for example:
+//
+//	delete_unmanaged_labels: true
+//	labels:
+//	  - name: bug
+//	    color: d73a4a
+//	    aliases: [defect]
+//	milestones:
+//	  - title: v1.0
+//	    due_on: 2024-01-31
+func Parse(data []byte) (*github.LabelSyncSpec, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if err := checkKeys(v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return github.ParseLabelSyncSpec(b)
+}
+
+// checkKeys returns an error if a mapping in v, as decoded from YAML, has
+// keys that are not strings and so cannot be encoded as JSON.
+func checkKeys(v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, e := range v {
+			if err := checkKeys(e); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		for k := range v {
+			if _, ok := k.(string); !ok {
+				return fmt.Errorf("mapping key %v is not a string", k)
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := checkKeys(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labelsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v56/github"
+)
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte(`
+delete_unmanaged_labels: true
+labels:
+  - name: bug
+    color: "#D73A4A"
+    description: Something isn't working
+    aliases: [defect]
+  - name: docs
+    color: 0075ca
+milestones:
+  - title: v1.0
+    state: open
+    due_on: 2024-01-31
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	want := &github.LabelSyncSpec{
+		Labels: []*github.LabelSpec{
+			{Name: "bug", Color: "#D73A4A", Description: github.String("Something isn't working"), Aliases: []string{"defect"}},
+			{Name: "docs", Color: "0075ca"},
+		},
+		Milestones: []*github.MilestoneSpec{
+			{Title: "v1.0", State: github.String("open"), DueOn: &github.Timestamp{Time: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)}},
+		},
+		DeleteUnmanagedLabels: true,
+	}
+	if !cmp.Equal(spec, want) {
+		t.Errorf("Parse returned diff (-want +got):\n%v", cmp.Diff(want, spec))
+	}
+}
+
+func TestParse_json(t *testing.T) {
+	spec, err := Parse([]byte(`{"labels": [{"name": "bug", "color": "d73a4a"}]}`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(spec.Labels) != 1 || spec.Labels[0].Name != "bug" {
+		t.Errorf("Parse returned %+v, want the bug label", spec)
+	}
+}
+
+func TestParse_empty(t *testing.T) {
+	spec, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if !cmp.Equal(spec, &github.LabelSyncSpec{}) {
+		t.Errorf("Parse returned %+v, want an empty spec", spec)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	tests := map[string]string{
+		"syntax":         "labels: [",
+		"duplicate key":  "labels: []\nlabels: []\n",
+		"non-string key": "labels:\n  - {1: x}\n",
+		"validation":     "labels:\n  - name: bug\n    color: red\n",
+		"wrong type":     "labels: bug\n",
+	}
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(in)); err == nil {
+				t.Errorf("Parse(%q) returned nil error", in)
+			}
+		})
+	}
+}
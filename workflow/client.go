@@ -0,0 +1,142 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v56/github"
+)
+
+// AuditOptions specifies optional parameters to AuditUnpinnedActions.
+type AuditOptions struct {
+	// TrustedOwners are the owners whose actions may be referenced by a
+	// tag or branch. If nil, it defaults to "actions", "github" and the
+	// organization itself.
+	TrustedOwners []string
+
+	// IncludeArchived includes archived repositories in the audit.
+	IncludeArchived bool
+}
+
+// UnpinnedActionsReport is the result of AuditUnpinnedActions.
+type UnpinnedActionsReport struct {
+	Uses []*UnpinnedActionUse
+
+	// Errors holds the errors reading or parsing workflow files, keyed by
+	// "<repository full name>/<path>".
+	Errors map[string]error
+}
+
+// Get fetches and parses a workflow file. fileName is the name of a file
+// in .github/workflows, such as "ci.yml", or a path in the repository.
+func Get(ctx context.Context, client *github.Client, owner, repo, fileName string, opts *github.RepositoryContentGetOptions) (*File, *github.Response, error) {
+	p := fileName
+	if !strings.Contains(p, "/") {
+		p = ".github/workflows/" + p
+	}
+	file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, p, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	if file == nil {
+		return nil, resp, fmt.Errorf("%v is not a file", p)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	w, err := Parse([]byte(content))
+	if err != nil {
+		return nil, resp, fmt.Errorf("%v: %w", p, err)
+	}
+	w.Path = p
+	return w, resp, nil
+}
+
+// Dispatch manually triggers a GitHub Actions workflow run like
+// ActionsService.CreateWorkflowDispatchEventByFileName, after checking
+// event.Inputs against the inputs declared by the workflow file at
+// event.Ref. See File.CheckDispatchInputs.
+func Dispatch(ctx context.Context, client *github.Client, owner, repo, fileName string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error) {
+	w, resp, err := Get(ctx, client, owner, repo, fileName, &github.RepositoryContentGetOptions{Ref: event.Ref})
+	if err != nil {
+		return resp, err
+	}
+	if err := w.CheckDispatchInputs(event.Inputs); err != nil {
+		return nil, err
+	}
+	return client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, path.Base(w.Path), event)
+}
+
+// AuditUnpinnedActions lists the actions and reusable workflows used by
+// the workflow files on the default branch of every repository of org
+// that are not pinned to a commit SHA. See File.UnpinnedActions. Workflow
+// files that cannot be read or parsed are reported in the result rather
+// than failing the audit.
+func AuditUnpinnedActions(ctx context.Context, client *github.Client, org string, opts *AuditOptions) (*UnpinnedActionsReport, error) {
+	if opts == nil {
+		opts = &AuditOptions{}
+	}
+	trusted := opts.TrustedOwners
+	if trusted == nil {
+		trusted = []string{"actions", "github", org}
+	}
+
+	var repos []*github.Repository
+	listOpts := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := client.Repositories.ListByOrg(ctx, org, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	report := &UnpinnedActionsReport{Errors: make(map[string]error)}
+	for _, r := range repos {
+		if r.GetArchived() && !opts.IncludeArchived {
+			continue
+		}
+		owner, name := r.GetOwner().GetLogin(), r.GetName()
+		if owner == "" {
+			owner = org
+		}
+		_, dir, resp, err := client.Repositories.GetContents(ctx, owner, name, ".github/workflows", nil)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, f := range dir {
+			ext := path.Ext(f.GetName())
+			if f.GetType() != "file" || (ext != ".yml" && ext != ".yaml") {
+				continue
+			}
+			w, _, err := Get(ctx, client, owner, name, f.GetPath(), nil)
+			if err != nil {
+				report.Errors[r.GetFullName()+"/"+f.GetPath()] = err
+				continue
+			}
+			for _, u := range w.UnpinnedActions(trusted...) {
+				u.Repository = r.GetFullName()
+				report.Uses = append(report.Uses, u)
+			}
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v56/github"
+)
+
+// setup sets up a test HTTP server along with a github.Client that is
+// configured to talk to it.
+func setup(t *testing.T) (*github.Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = u
+	return client, mux
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	if got := r.Method; got != want {
+		t.Errorf("Request method: %v, want %v", got, want)
+	}
+}
+
+func testFormValues(t *testing.T, r *http.Request, want map[string]string) {
+	t.Helper()
+	got := make(map[string]string)
+	if err := r.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+	for k := range r.Form {
+		got[k] = r.Form.Get(k)
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Request parameters: %v, want %v", got, want)
+	}
+}
+
+func testBody(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Error reading request body: %v", err)
+	}
+	if got := string(b); got != want {
+		t.Errorf("request Body is %s, want %s", got, want)
+	}
+}
+
+// testWorkflowContent returns the contents API response for a file.
+func testWorkflowContent(path, content string) string {
+	return fmt.Sprintf(`{"type":"file","encoding":"base64","name":%q,"path":%q,"content":%q}`,
+		path[strings.LastIndex(path, "/")+1:], path, base64.StdEncoding.EncodeToString([]byte(content)))
+}
+
+func TestGet(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/repos/o/r/contents/.github/workflows/deploy.yml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, map[string]string{"ref": "v1"})
+		fmt.Fprint(w, testWorkflowContent(".github/workflows/deploy.yml", testWorkflowFile))
+	})
+
+	ctx := context.Background()
+	w, _, err := Get(ctx, client, "o", "r", "deploy.yml", &github.RepositoryContentGetOptions{Ref: "v1"})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if w.Path != ".github/workflows/deploy.yml" || w.Name != "Deploy" || len(w.Jobs) != 3 {
+		t.Errorf("Get returned %+v", w)
+	}
+
+	if _, _, err := Get(ctx, client, "o", "r", "missing.yml", nil); err == nil {
+		t.Error("Get returned nil error for a missing file")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/repos/o/r/contents/.github/workflows/deploy.yml", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, map[string]string{"ref": "main"})
+		fmt.Fprint(w, testWorkflowContent(".github/workflows/deploy.yml", testWorkflowFile))
+	})
+	dispatched := 0
+	mux.HandleFunc("/repos/o/r/actions/workflows/deploy.yml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"ref":"main","inputs":{"environment":"staging"}}`+"\n")
+		dispatched++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	event := github.CreateWorkflowDispatchEventRequest{Ref: "main", Inputs: map[string]interface{}{"environment": "staging"}}
+	if _, err := Dispatch(ctx, client, "o", "r", "deploy.yml", event); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+
+	event.Inputs = map[string]interface{}{"environment": "moon"}
+	if _, err := Dispatch(ctx, client, "o", "r", "deploy.yml", event); err == nil {
+		t.Error("Dispatch returned nil error for invalid inputs")
+	}
+	if dispatched != 1 {
+		t.Errorf("workflow was dispatched %v times, want 1", dispatched)
+	}
+}
+
+func TestAuditUnpinnedActions(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/orgs/o/repos", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, map[string]string{"per_page": "100"})
+		fmt.Fprint(w, `[
+			{"name":"a","full_name":"o/a","owner":{"login":"o"}},
+			{"name":"b","full_name":"o/b","owner":{"login":"o"}},
+			{"name":"old","full_name":"o/old","owner":{"login":"o"},"archived":true}
+		]`)
+	})
+	mux.HandleFunc("/repos/o/a/contents/.github/workflows", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"type":"file","name":"ci.yml","path":".github/workflows/ci.yml"},
+			{"type":"file","name":"broken.yaml","path":".github/workflows/broken.yaml"},
+			{"type":"file","name":"README.md","path":".github/workflows/README.md"}
+		]`)
+	})
+	mux.HandleFunc("/repos/o/a/contents/.github/workflows/ci.yml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testWorkflowContent(".github/workflows/ci.yml", `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: o/internal-action@main
+      - uses: third/party@v1
+`))
+	})
+	mux.HandleFunc("/repos/o/a/contents/.github/workflows/broken.yaml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testWorkflowContent(".github/workflows/broken.yaml", "on: [push\n"))
+	})
+	mux.HandleFunc("/repos/o/b/contents/.github/workflows", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/old/contents/.github/workflows", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("archived repository was audited")
+	})
+
+	report, err := AuditUnpinnedActions(context.Background(), client, "o", nil)
+	if err != nil {
+		t.Fatalf("AuditUnpinnedActions returned error: %v", err)
+	}
+	want := []*UnpinnedActionUse{
+		{Repository: "o/a", Path: ".github/workflows/ci.yml", Line: 8, Job: "a", Uses: "third/party@v1"},
+	}
+	if !cmp.Equal(report.Uses, want) {
+		t.Errorf("AuditUnpinnedActions returned diff (-want +got):\n%v", cmp.Diff(want, report.Uses))
+	}
+	if len(report.Errors) != 1 || report.Errors["o/a/.github/workflows/broken.yaml"] == nil {
+		t.Errorf("AuditUnpinnedActions returned errors %v, want one for broken.yaml", report.Errors)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/google/go-github/v56/workflow
+
+go 1.17
+
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/google/go-github/v56 v56.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/google/go-querystring v1.1.0 // indirect
+
+// Use version at HEAD, not the latest published.
+replace github.com/google/go-github/v56 => ../
@@ -0,0 +1,930 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package workflow parses GitHub Actions workflow files, checks them for
+// common mistakes and finds the actions they use that are not pinned to a
+// commit.
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxDispatchInputs is the maximum number of inputs of a
+// workflow_dispatch event.
+const maxDispatchInputs = 25
+
+// File is a parsed workflow file.
+type File struct {
+	// Path is the path of the file in its repository, if it was loaded
+	// from one.
+	Path string
+
+	Name    string
+	RunName string
+
+	// On lists the events that trigger the workflow, in the order they
+	// are declared.
+	On []*Trigger
+
+	Permissions *Permissions
+	Concurrency *Concurrency
+	Env         map[string]string
+
+	// Jobs are the jobs of the workflow, in the order they are declared.
+	Jobs []*Job
+
+	// problems are the problems found while decoding the file.
+	problems []*Problem
+}
+
+// Trigger is an event that triggers a workflow, with its filters.
+type Trigger struct {
+	Event string
+
+	// Types are the activity types of the event, such as "opened".
+	Types []string
+
+	Branches       []string
+	BranchesIgnore []string
+	Tags           []string
+	TagsIgnore     []string
+	Paths          []string
+	PathsIgnore    []string
+
+	// Workflows are the workflows whose runs trigger a workflow_run event.
+	Workflows []string
+
+	// Cron are the schedules of a schedule event.
+	Cron []string
+
+	// Inputs are the inputs of a workflow_dispatch or workflow_call event.
+	Inputs []*Input
+
+	Line int
+}
+
+// Input is an input of a workflow_dispatch or workflow_call event.
+type Input struct {
+	Name        string
+	Description string
+	Required    bool
+
+	// Type is "string", "boolean", "number", "choice" or "environment".
+	// It is empty if the input does not declare a type, in which case it
+	// is a string.
+	Type string
+
+	// Default is the default value, if HasDefault is set.
+	Default    string
+	HasDefault bool
+
+	// Options are the allowed values of a choice input.
+	Options []string
+
+	Line int
+}
+
+// Permissions are the permissions granted to the GITHUB_TOKEN of a
+// workflow or job.
+type Permissions struct {
+	// All is "read-all" or "write-all" when the permissions are given as a
+	// single value for all scopes.
+	All string
+
+	// Scopes maps scopes, such as "contents", to "read", "write" or
+	// "none". Scopes that are not listed have no access.
+	Scopes map[string]string
+
+	Line int
+}
+
+// Concurrency is the concurrency group of a workflow or job.
+type Concurrency struct {
+	Group string
+
+	// CancelInProgress is "true", "false" or an expression.
+	CancelInProgress string
+
+	Line int
+}
+
+// Job is a job declared in a workflow file.
+type Job struct {
+	ID   string
+	Name string
+
+	// Needs are the IDs of the jobs that must complete first.
+	Needs []string
+
+	If string
+
+	// RunsOn are the labels of the runners the job runs on, and
+	// RunsOnGroup is the runner group, if any.
+	RunsOn      []string
+	RunsOnGroup string
+
+	Environment string
+	Permissions *Permissions
+	Concurrency *Concurrency
+	Env         map[string]string
+
+	// Uses is the reusable workflow the job calls, and With are its
+	// inputs.
+	Uses string
+	With map[string]string
+
+	Steps []*Step
+
+	Line int
+}
+
+// Step is a step of a job declared in a workflow file.
+type Step struct {
+	ID               string
+	Name             string
+	If               string
+	Uses             string
+	With             map[string]string
+	Run              string
+	Shell            string
+	WorkingDirectory string
+	Env              map[string]string
+
+	Line int
+}
+
+// Problem is a problem found in a workflow file. It implements error so
+// that problems can be returned as errors.
+type Problem struct {
+	Line int
+
+	// Job is the ID of the job the problem was found in, if any.
+	Job string
+
+	Message string
+}
+
+func (p *Problem) Error() string {
+	if p.Job != "" {
+		return fmt.Sprintf("line %v: job %v: %v", p.Line, p.Job, p.Message)
+	}
+	return fmt.Sprintf("line %v: %v", p.Line, p.Message)
+}
+
+// UnpinnedActionUse is a reference to an action or reusable workflow that
+// is not pinned to a commit SHA.
+type UnpinnedActionUse struct {
+	// Repository is the full name of the repository of the workflow
+	// file, if known.
+	Repository string
+
+	Path string
+	Line int
+
+	// Job is the ID of the job that uses the action.
+	Job string
+
+	// Uses is the reference, such as "octo-org/action@v1".
+	Uses string
+}
+
+var (
+	events = map[string]bool{
+		"branch_protection_rule": true, "check_run": true, "check_suite": true, "create": true,
+		"delete": true, "deployment": true, "deployment_status": true, "discussion": true,
+		"discussion_comment": true, "fork": true, "gollum": true, "issue_comment": true,
+		"issues": true, "label": true, "merge_group": true, "milestone": true,
+		"page_build": true, "project": true, "project_card": true, "project_column": true,
+		"public": true, "pull_request": true, "pull_request_review": true,
+		"pull_request_review_comment": true, "pull_request_target": true, "push": true,
+		"registry_package": true, "release": true, "repository_dispatch": true, "schedule": true,
+		"status": true, "watch": true, "workflow_call": true, "workflow_dispatch": true,
+		"workflow_run": true,
+	}
+
+	permissionScopes = map[string]bool{
+		"actions": true, "attestations": true, "checks": true, "contents": true,
+		"deployments": true, "discussions": true, "id-token": true, "issues": true,
+		"models": true, "packages": true, "pages": true, "pull-requests": true,
+		"repository-projects": true, "security-events": true, "statuses": true,
+	}
+
+	inputTypes = map[string]bool{
+		"string": true, "boolean": true, "number": true, "choice": true, "environment": true,
+	}
+
+	idPattern      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	fullSHAPattern = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+)
+
+// Parse parses the content of a workflow file. An error is returned if
+// data is not a valid YAML document. Other problems are reported by
+// File.Validate.
+func Parse(data []byte) (*File, error) {
+	root, err := parseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil || root.kind != mappingNode {
+		return nil, errors.New("workflow file must contain a mapping")
+	}
+
+	d := &decoder{}
+	w := &File{
+		Name:        d.scalar(root.get("name"), "name"),
+		RunName:     d.scalar(root.get("run-name"), "run-name"),
+		On:          d.triggers(root.get("on")),
+		Permissions: d.permissions(root.get("permissions")),
+		Concurrency: d.concurrency(root.get("concurrency")),
+		Env:         d.stringMap(root.get("env"), "env"),
+	}
+	if jobs := root.get("jobs"); !jobs.isNull() {
+		if jobs.kind != mappingNode {
+			d.problemf(jobs, "jobs must be a mapping")
+		} else {
+			for i, id := range jobs.keys {
+				w.Jobs = append(w.Jobs, d.job(id, jobs.keyLines[i], jobs.items[i]))
+			}
+		}
+	}
+	w.problems = d.problems
+	return w, nil
+}
+
+// Trigger returns the trigger for event, or nil if event does not trigger
+// the workflow.
+func (w *File) Trigger(event string) *Trigger {
+	for _, t := range w.On {
+		if t.Event == event {
+			return t
+		}
+	}
+	return nil
+}
+
+// Job returns the job with the given ID, or nil.
+func (w *File) Job(id string) *Job {
+	for _, j := range w.Jobs {
+		if j.ID == id {
+			return j
+		}
+	}
+	return nil
+}
+
+// Validate checks the workflow for common mistakes, such as jobs that need
+// unknown jobs, steps that both run a command and use an action, actions
+// without a version, and invalid workflow_dispatch inputs. The problems
+// found are returned ordered by line.
+func (w *File) Validate() []*Problem {
+	d := &decoder{problems: append([]*Problem(nil), w.problems...)}
+	at := func(line int, job, format string, args ...interface{}) {
+		d.problems = append(d.problems, &Problem{Line: line, Job: job, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(w.On) == 0 {
+		at(1, "", "workflow has no triggers")
+	}
+	for _, t := range w.On {
+		validateTrigger(t, at)
+	}
+	validatePermissions(w.Permissions, "", at)
+	if w.Concurrency != nil && w.Concurrency.Group == "" {
+		at(w.Concurrency.Line, "", "concurrency must have a group")
+	}
+
+	if len(w.Jobs) == 0 {
+		at(1, "", "workflow has no jobs")
+	}
+	ids := make(map[string]*Job)
+	for _, j := range w.Jobs {
+		ids[j.ID] = j
+	}
+	for _, j := range w.Jobs {
+		if !idPattern.MatchString(j.ID) {
+			at(j.Line, j.ID, "job ID must start with a letter or _ and contain only alphanumeric characters, - or _")
+		}
+		for _, need := range j.Needs {
+			if ids[need] == nil {
+				at(j.Line, j.ID, "needs unknown job %q", need)
+			}
+		}
+		validatePermissions(j.Permissions, j.ID, at)
+		if j.Concurrency != nil && j.Concurrency.Group == "" {
+			at(j.Concurrency.Line, j.ID, "concurrency must have a group")
+		}
+		validateExpression(j.If, j.Line, j.ID, at)
+
+		if j.Uses != "" {
+			if len(j.Steps) > 0 {
+				at(j.Line, j.ID, "a job that calls a reusable workflow cannot have steps")
+			}
+			if !isReusableWorkflowRef(j.Uses) {
+				at(j.Line, j.ID, "uses %q is not a reusable workflow, such as owner/repo/.github/workflows/file.yml@ref", j.Uses)
+			}
+			continue
+		}
+		if len(j.RunsOn) == 0 && j.RunsOnGroup == "" {
+			at(j.Line, j.ID, "job must have runs-on or uses")
+		}
+		if len(j.Steps) == 0 {
+			at(j.Line, j.ID, "job has no steps")
+		}
+
+		stepIDs := make(map[string]bool)
+		for _, st := range j.Steps {
+			switch {
+			case st.Uses != "" && st.Run != "":
+				at(st.Line, j.ID, "step cannot both use an action and run a command")
+			case st.Uses == "" && st.Run == "":
+				at(st.Line, j.ID, "step must use an action or run a command")
+			case st.Uses != "":
+				if _, err := parseActionRef(st.Uses); err != nil {
+					at(st.Line, j.ID, "%v", err)
+				}
+			}
+			if st.ID != "" {
+				if !idPattern.MatchString(st.ID) {
+					at(st.Line, j.ID, "step ID %q must start with a letter or _ and contain only alphanumeric characters, - or _", st.ID)
+				}
+				if stepIDs[st.ID] {
+					at(st.Line, j.ID, "duplicate step ID %q", st.ID)
+				}
+				stepIDs[st.ID] = true
+			}
+			validateExpression(st.If, st.Line, j.ID, at)
+			validateExpression(st.Run, st.Line, j.ID, at)
+			for _, v := range st.With {
+				validateExpression(v, st.Line, j.ID, at)
+			}
+		}
+	}
+
+	if cycle := needsCycle(w.Jobs, ids); len(cycle) > 0 {
+		at(ids[cycle[0]].Line, cycle[0], "jobs need each other in a cycle: %v", strings.Join(cycle, " -> "))
+	}
+
+	sort.SliceStable(d.problems, func(i, j int) bool { return d.problems[i].Line < d.problems[j].Line })
+	return d.problems
+}
+
+// CheckDispatchInputs checks the inputs of a workflow_dispatch event
+// against the inputs the workflow declares. It reports unknown and missing
+// inputs, and values that do not match the type of their input.
+func (w *File) CheckDispatchInputs(inputs map[string]interface{}) error {
+	t := w.Trigger("workflow_dispatch")
+	if t == nil {
+		return fmt.Errorf("workflow %v does not have a workflow_dispatch trigger", w.Path)
+	}
+
+	var msgs []string
+	declared := make(map[string]*Input)
+	for _, in := range t.Inputs {
+		declared[in.Name] = in
+		if _, ok := inputs[in.Name]; !ok && in.Required && !in.HasDefault {
+			msgs = append(msgs, fmt.Sprintf("input %q is required", in.Name))
+		}
+	}
+	if len(inputs) > maxDispatchInputs {
+		msgs = append(msgs, fmt.Sprintf("at most %v inputs can be given", maxDispatchInputs))
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		in := declared[name]
+		if in == nil {
+			msgs = append(msgs, fmt.Sprintf("input %q is not declared", name))
+			continue
+		}
+		if msg := checkInputValue(in, inputs[name]); msg != "" {
+			msgs = append(msgs, fmt.Sprintf("input %q %v", name, msg))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("invalid inputs for workflow %v: %v", w.Path, strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// UnpinnedActions returns the actions and reusable workflows the workflow
+// uses that are not pinned to a full commit SHA, or, for Docker images, to
+// a digest. Local actions and actions owned by trustedOwners are ignored.
+func (w *File) UnpinnedActions(trustedOwners ...string) []*UnpinnedActionUse {
+	trusted := make(map[string]bool)
+	for _, o := range trustedOwners {
+		trusted[strings.ToLower(o)] = true
+	}
+
+	var uses []*UnpinnedActionUse
+	add := func(job *Job, line int, ref string) {
+		// References without a version are unpinned too.
+		a, err := parseActionRef(ref)
+		if err == nil && (a.local || a.pinned() || trusted[strings.ToLower(a.owner)]) {
+			return
+		}
+		uses = append(uses, &UnpinnedActionUse{Path: w.Path, Line: line, Job: job.ID, Uses: ref})
+	}
+	for _, j := range w.Jobs {
+		if j.Uses != "" {
+			add(j, j.Line, j.Uses)
+		}
+		for _, st := range j.Steps {
+			if st.Uses != "" {
+				add(j, st.Line, st.Uses)
+			}
+		}
+	}
+	return uses
+}
+
+// decoder decodes the nodes of a workflow file, collecting the
+// problems it finds.
+type decoder struct {
+	problems []*Problem
+	// jobID is the ID of the job being decoded.
+	jobID string
+}
+
+func (d *decoder) problemf(n *node, format string, args ...interface{}) {
+	d.problems = append(d.problems, &Problem{Line: n.line, Job: d.jobID, Message: fmt.Sprintf(format, args...)})
+}
+
+// scalar returns the value of the scalar n, or "" if n is missing, null
+// or not a scalar.
+func (d *decoder) scalar(n *node, what string) string {
+	switch {
+	case n.isNull():
+		return ""
+	case n.kind != scalarNode:
+		d.problemf(n, "%v must be a single value", what)
+		return ""
+	}
+	return n.value
+}
+
+// list returns the values of n, which is a scalar or a sequence of
+// scalars.
+func (d *decoder) list(n *node, what string) []string {
+	switch {
+	case n.isNull():
+		return nil
+	case n.kind == scalarNode:
+		return []string{n.value}
+	case n.kind != sequenceNode:
+		d.problemf(n, "%v must be a value or a list of values", what)
+		return nil
+	}
+	var values []string
+	for _, item := range n.items {
+		if v := d.scalar(item, what); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// stringMap returns the mapping n with scalar values.
+func (d *decoder) stringMap(n *node, what string) map[string]string {
+	switch {
+	case n.isNull():
+		return nil
+	case n.kind != mappingNode:
+		d.problemf(n, "%v must be a mapping", what)
+		return nil
+	}
+	m := make(map[string]string, len(n.keys))
+	for i, k := range n.keys {
+		m[k] = d.scalar(n.items[i], what+"."+k)
+	}
+	return m
+}
+
+// boolean returns the value of the boolean n.
+func (d *decoder) boolean(n *node, what string) bool {
+	switch v := strings.ToLower(d.scalar(n, what)); v {
+	case "true":
+		return true
+	case "", "false":
+		return false
+	}
+	d.problemf(n, "%v must be true or false", what)
+	return false
+}
+
+func (d *decoder) triggers(n *node) []*Trigger {
+	switch {
+	case n.isNull():
+		return nil
+	case n.kind == scalarNode, n.kind == sequenceNode:
+		var triggers []*Trigger
+		for _, event := range d.list(n, "on") {
+			triggers = append(triggers, &Trigger{Event: event, Line: n.line})
+		}
+		return triggers
+	}
+
+	var triggers []*Trigger
+	for i, event := range n.keys {
+		c := n.items[i]
+		t := &Trigger{Event: event, Line: n.keyLines[i]}
+		triggers = append(triggers, t)
+		if c.isNull() {
+			continue
+		}
+
+		if event == "schedule" {
+			if c.kind != sequenceNode {
+				d.problemf(c, "schedule must be a list of cron schedules")
+				continue
+			}
+			for _, item := range c.items {
+				if cron := d.scalar(item.get("cron"), "cron"); cron != "" {
+					t.Cron = append(t.Cron, cron)
+				} else {
+					d.problemf(item, "schedule entries must have a cron schedule")
+				}
+			}
+			continue
+		}
+		if c.kind != mappingNode {
+			d.problemf(c, "configuration of event %v must be a mapping", event)
+			continue
+		}
+
+		t.Types = d.list(c.get("types"), "types")
+		t.Branches = d.list(c.get("branches"), "branches")
+		t.BranchesIgnore = d.list(c.get("branches-ignore"), "branches-ignore")
+		t.Tags = d.list(c.get("tags"), "tags")
+		t.TagsIgnore = d.list(c.get("tags-ignore"), "tags-ignore")
+		t.Paths = d.list(c.get("paths"), "paths")
+		t.PathsIgnore = d.list(c.get("paths-ignore"), "paths-ignore")
+		t.Workflows = d.list(c.get("workflows"), "workflows")
+
+		inputs := c.get("inputs")
+		switch {
+		case inputs.isNull():
+		case inputs.kind != mappingNode:
+			d.problemf(inputs, "inputs must be a mapping")
+		default:
+			for j, name := range inputs.keys {
+				t.Inputs = append(t.Inputs, d.input(name, inputs.keyLines[j], inputs.items[j]))
+			}
+		}
+	}
+	return triggers
+}
+
+func (d *decoder) input(name string, line int, n *node) *Input {
+	in := &Input{Name: name, Line: line}
+	if n.isNull() {
+		return in
+	}
+	if n.kind != mappingNode {
+		d.problemf(n, "input %v must be a mapping", name)
+		return in
+	}
+	in.Description = d.scalar(n.get("description"), "description")
+	in.Required = d.boolean(n.get("required"), "required")
+	in.Type = d.scalar(n.get("type"), "type")
+	if def := n.get("default"); !def.isNull() {
+		in.Default = d.scalar(def, "default")
+		in.HasDefault = true
+	}
+	in.Options = d.list(n.get("options"), "options")
+	return in
+}
+
+func (d *decoder) permissions(n *node) *Permissions {
+	switch {
+	case n.isNull():
+		return nil
+	case n.kind == scalarNode:
+		return &Permissions{All: n.value, Line: n.line}
+	case n.kind != mappingNode:
+		d.problemf(n, "permissions must be read-all, write-all or a mapping of scopes")
+		return nil
+	}
+	return &Permissions{Scopes: d.stringMap(n, "permissions"), Line: n.line}
+}
+
+func (d *decoder) concurrency(n *node) *Concurrency {
+	switch {
+	case n.isNull():
+		return nil
+	case n.kind == scalarNode:
+		return &Concurrency{Group: n.value, Line: n.line}
+	case n.kind != mappingNode:
+		d.problemf(n, "concurrency must be a group or a mapping")
+		return nil
+	}
+	return &Concurrency{
+		Group:            d.scalar(n.get("group"), "group"),
+		CancelInProgress: d.scalar(n.get("cancel-in-progress"), "cancel-in-progress"),
+		Line:             n.line,
+	}
+}
+
+func (d *decoder) job(id string, line int, n *node) *Job {
+	d.jobID = id
+	defer func() { d.jobID = "" }()
+
+	j := &Job{ID: id, Line: line}
+	if n.kind != mappingNode {
+		d.problemf(n, "job must be a mapping")
+		return j
+	}
+
+	j.Name = d.scalar(n.get("name"), "name")
+	j.Needs = d.list(n.get("needs"), "needs")
+	j.If = d.scalar(n.get("if"), "if")
+	j.Permissions = d.permissions(n.get("permissions"))
+	j.Concurrency = d.concurrency(n.get("concurrency"))
+	j.Env = d.stringMap(n.get("env"), "env")
+	j.Uses = d.scalar(n.get("uses"), "uses")
+	j.With = d.stringMap(n.get("with"), "with")
+
+	if runsOn := n.get("runs-on"); runsOn != nil && runsOn.kind == mappingNode {
+		j.RunsOnGroup = d.scalar(runsOn.get("group"), "group")
+		j.RunsOn = d.list(runsOn.get("labels"), "labels")
+	} else {
+		j.RunsOn = d.list(runsOn, "runs-on")
+	}
+	if env := n.get("environment"); env != nil && env.kind == mappingNode {
+		j.Environment = d.scalar(env.get("name"), "environment name")
+	} else {
+		j.Environment = d.scalar(env, "environment")
+	}
+
+	steps := n.get("steps")
+	switch {
+	case steps.isNull():
+	case steps.kind != sequenceNode:
+		d.problemf(steps, "steps must be a list")
+	default:
+		for _, item := range steps.items {
+			if item.kind != mappingNode {
+				d.problemf(item, "step must be a mapping")
+				continue
+			}
+			j.Steps = append(j.Steps, &Step{
+				ID:               d.scalar(item.get("id"), "id"),
+				Name:             d.scalar(item.get("name"), "name"),
+				If:               d.scalar(item.get("if"), "if"),
+				Uses:             d.scalar(item.get("uses"), "uses"),
+				With:             d.stringMap(item.get("with"), "with"),
+				Run:              d.scalar(item.get("run"), "run"),
+				Shell:            d.scalar(item.get("shell"), "shell"),
+				WorkingDirectory: d.scalar(item.get("working-directory"), "working-directory"),
+				Env:              d.stringMap(item.get("env"), "env"),
+				Line:             item.line,
+			})
+		}
+	}
+	return j
+}
+
+// validateTrigger checks the configuration of a trigger.
+func validateTrigger(t *Trigger, at func(line int, job, format string, args ...interface{})) {
+	if !events[t.Event] {
+		at(t.Line, "", "unknown event %q", t.Event)
+	}
+	for _, f := range []struct {
+		name            string
+		include, ignore []string
+	}{
+		{"branches", t.Branches, t.BranchesIgnore},
+		{"tags", t.Tags, t.TagsIgnore},
+		{"paths", t.Paths, t.PathsIgnore},
+	} {
+		if len(f.include) > 0 && len(f.ignore) > 0 {
+			at(t.Line, "", "event %v cannot use both %v and %v-ignore", t.Event, f.name, f.name)
+		}
+	}
+	for _, cron := range t.Cron {
+		if len(strings.Fields(cron)) != 5 {
+			at(t.Line, "", "cron schedule %q must have 5 fields", cron)
+		}
+	}
+
+	if t.Event == "workflow_dispatch" && len(t.Inputs) > maxDispatchInputs {
+		at(t.Line, "", "workflow_dispatch can have at most %v inputs", maxDispatchInputs)
+	}
+	for _, in := range t.Inputs {
+		if in.Type != "" && !inputTypes[in.Type] {
+			at(in.Line, "", "input %v has unknown type %q", in.Name, in.Type)
+			continue
+		}
+		if in.Type == "choice" && len(in.Options) == 0 {
+			at(in.Line, "", "choice input %v has no options", in.Name)
+		}
+		if !in.HasDefault {
+			continue
+		}
+		if msg := checkInputValue(in, in.Default); msg != "" {
+			at(in.Line, "", "default of input %v %v", in.Name, msg)
+		}
+	}
+}
+
+// validatePermissions checks the permissions of a workflow or job.
+func validatePermissions(p *Permissions, job string, at func(line int, job, format string, args ...interface{})) {
+	if p == nil {
+		return
+	}
+	if p.Scopes == nil {
+		if p.All != "read-all" && p.All != "write-all" {
+			at(p.Line, job, "permissions must be read-all, write-all or a mapping of scopes, not %q", p.All)
+		}
+		return
+	}
+
+	scopes := make([]string, 0, len(p.Scopes))
+	for scope := range p.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	for _, scope := range scopes {
+		level := p.Scopes[scope]
+		switch {
+		case !permissionScopes[scope]:
+			at(p.Line, job, "unknown permission scope %q", scope)
+		case level != "read" && level != "write" && level != "none":
+			at(p.Line, job, "permission %v must be read, write or none, not %q", scope, level)
+		case scope == "id-token" && level == "read":
+			at(p.Line, job, "permission id-token must be write or none")
+		}
+	}
+}
+
+// validateExpression checks that the expressions in s are closed.
+func validateExpression(s string, line int, job string, at func(line int, job, format string, args ...interface{})) {
+	for {
+		i := strings.Index(s, "${{")
+		if i < 0 {
+			return
+		}
+		s = s[i+3:]
+		end := strings.Index(s, "}}")
+		if end < 0 {
+			at(line, job, "expression %q is not closed with }}", "${{"+s)
+			return
+		}
+		s = s[end+2:]
+	}
+}
+
+// needsCycle returns the IDs of jobs that need each other in a
+// cycle, or nil.
+func needsCycle(jobs []*Job, ids map[string]*Job) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		switch state[id] {
+		case visiting:
+			for i, s := range stack {
+				if s == id {
+					return append(append([]string(nil), stack[i:]...), id)
+				}
+			}
+		case done:
+			return nil
+		}
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, need := range ids[id].Needs {
+			if ids[need] == nil {
+				continue
+			}
+			if cycle := visit(need); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+	for _, j := range jobs {
+		if cycle := visit(j.ID); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// checkInputValue checks value against the type of in, and
+// returns a description of the problem, if any.
+func checkInputValue(in *Input, value interface{}) string {
+	s, isString := value.(string)
+	switch in.Type {
+	case "boolean":
+		if _, ok := value.(bool); ok || (isString && (s == "true" || s == "false")) {
+			return ""
+		}
+		return "must be true or false"
+	case "number":
+		switch value.(type) {
+		case int, int32, int64, float32, float64:
+			return ""
+		}
+		if _, err := strconv.ParseFloat(s, 64); isString && err == nil {
+			return ""
+		}
+		return "must be a number"
+	case "choice":
+		for _, o := range in.Options {
+			if isString && s == o {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %v", strings.Join(in.Options, ", "))
+	}
+	if !isString {
+		return "must be a string"
+	}
+	return ""
+}
+
+// actionRef is a parsed "uses" reference.
+type actionRef struct {
+	local  bool
+	docker bool
+	owner  string
+	ref    string
+}
+
+// parseActionRef parses a reference to an action or reusable workflow,
+// such as "owner/repo/path@ref", "./path" or "docker://image:tag".
+func parseActionRef(uses string) (*actionRef, error) {
+	switch {
+	case strings.HasPrefix(uses, "./"):
+		return &actionRef{local: true}, nil
+	case strings.HasPrefix(uses, "docker://"):
+		image := strings.TrimPrefix(uses, "docker://")
+		if image == "" {
+			return nil, fmt.Errorf("uses %q does not name an image", uses)
+		}
+		a := &actionRef{docker: true}
+		if i := strings.LastIndex(image, "@"); i >= 0 {
+			a.ref = image[i+1:]
+		}
+		return a, nil
+	}
+
+	i := strings.LastIndex(uses, "@")
+	if i < 0 || i == len(uses)-1 {
+		return nil, fmt.Errorf("uses %q must specify a version, such as %v@v1 or a commit SHA", uses, uses)
+	}
+	parts := strings.Split(uses[:i], "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("uses %q must be of the form owner/repo@ref", uses)
+	}
+	return &actionRef{owner: parts[0], ref: uses[i+1:]}, nil
+}
+
+// pinned reports whether the reference is immutable.
+func (a *actionRef) pinned() bool {
+	if a.docker {
+		return strings.HasPrefix(a.ref, "sha256:")
+	}
+	return fullSHAPattern.MatchString(a.ref)
+}
+
+// isReusableWorkflowRef reports whether uses refers to a reusable
+// workflow.
+func isReusableWorkflowRef(uses string) bool {
+	p := uses
+	if strings.HasPrefix(uses, "./") {
+		p = strings.TrimPrefix(uses, "./")
+	} else {
+		if _, err := parseActionRef(uses); err != nil {
+			return false
+		}
+		parts := strings.SplitN(uses[:strings.LastIndex(uses, "@")], "/", 3)
+		if len(parts) < 3 {
+			return false
+		}
+		p = parts[2]
+	}
+	ext := path.Ext(p)
+	return strings.HasPrefix(p, ".github/workflows/") && (ext == ".yml" || ext == ".yaml")
+}
@@ -0,0 +1,403 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testWorkflowFile = `name: Deploy
+on:
+  push:
+    branches: [main]
+    paths-ignore: ['docs/**']
+  schedule:
+    - cron: '0 4 * * 1'
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Where to deploy
+        type: choice
+        options: [staging, production]
+        required: true
+      dry-run:
+        type: boolean
+        default: false
+      replicas:
+        type: number
+      note:
+        description: Free text
+permissions:
+  contents: read
+  id-token: write
+concurrency:
+  group: deploy-${{ github.ref }}
+  cancel-in-progress: true
+env:
+  GOFLAGS: -mod=readonly
+jobs:
+  build:
+    runs-on: [self-hosted, linux]
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: |
+          go test ./...
+        shell: bash
+  deploy:
+    needs: build
+    runs-on:
+      group: deployers
+      labels: ubuntu-latest
+    environment:
+      name: production
+      url: https://example.com
+    if: github.ref == 'refs/heads/main'
+    steps:
+      - uses: octo-org/deploy@0123456789abcdef0123456789abcdef01234567
+        with:
+          replicas: 3
+  call:
+    uses: octo-org/workflows/.github/workflows/notify.yml@v2
+    with:
+      channel: releases
+`
+
+func TestParse(t *testing.T) {
+	w, err := Parse([]byte(testWorkflowFile))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	want := &File{
+		Name: "Deploy",
+		On: []*Trigger{
+			{Event: "push", Branches: []string{"main"}, PathsIgnore: []string{"docs/**"}, Line: 3},
+			{Event: "schedule", Cron: []string{"0 4 * * 1"}, Line: 6},
+			{Event: "workflow_dispatch", Line: 8, Inputs: []*Input{
+				{Name: "environment", Description: "Where to deploy", Type: "choice", Options: []string{"staging", "production"}, Required: true, Line: 10},
+				{Name: "dry-run", Type: "boolean", Default: "false", HasDefault: true, Line: 15},
+				{Name: "replicas", Type: "number", Line: 18},
+				{Name: "note", Description: "Free text", Line: 20},
+			}},
+		},
+		Permissions: &Permissions{Scopes: map[string]string{"contents": "read", "id-token": "write"}, Line: 23},
+		Concurrency: &Concurrency{Group: "deploy-${{ github.ref }}", CancelInProgress: "true", Line: 26},
+		Env:         map[string]string{"GOFLAGS": "-mod=readonly"},
+		Jobs: []*Job{
+			{
+				ID:     "build",
+				RunsOn: []string{"self-hosted", "linux"},
+				Steps: []*Step{
+					{Uses: "actions/checkout@v4", Line: 34},
+					{ID: "test", Run: "go test ./...\n", Shell: "bash", Line: 35},
+				},
+				Line: 31,
+			},
+			{
+				ID:          "deploy",
+				Needs:       []string{"build"},
+				RunsOn:      []string{"ubuntu-latest"},
+				RunsOnGroup: "deployers",
+				Environment: "production",
+				If:          "github.ref == 'refs/heads/main'",
+				Steps: []*Step{
+					{Uses: "octo-org/deploy@0123456789abcdef0123456789abcdef01234567", With: map[string]string{"replicas": "3"}, Line: 49},
+				},
+				Line: 39,
+			},
+			{
+				ID:   "call",
+				Uses: "octo-org/workflows/.github/workflows/notify.yml@v2",
+				With: map[string]string{"channel": "releases"},
+				Line: 52,
+			},
+		},
+	}
+	if !cmp.Equal(w, want, cmp.AllowUnexported(File{})) {
+		t.Errorf("Parse returned diff (-want +got):\n%v", cmp.Diff(want, w, cmp.AllowUnexported(File{})))
+	}
+	if got := w.Validate(); len(got) != 0 {
+		t.Errorf("Validate returned %v, want no problems", got)
+	}
+	if w.Trigger("workflow_dispatch") == nil || w.Trigger("pull_request") != nil {
+		t.Error("Trigger returned unexpected results")
+	}
+	if w.Job("deploy").RunsOnGroup != "deployers" || w.Job("missing") != nil {
+		t.Error("Job returned unexpected results")
+	}
+}
+
+func TestParse_shortForms(t *testing.T) {
+	w, err := Parse([]byte("on: [push, pull_request]\npermissions: read-all\nconcurrency: ci\njobs:\n  a:\n    runs-on: ubuntu-latest\n    needs: [b]\n    steps: [{run: make}]\n  b: {runs-on: ubuntu-latest, steps: [{run: make}]}\n"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(w.On) != 2 || w.On[1].Event != "pull_request" {
+		t.Errorf("On = %+v, want push and pull_request", w.On)
+	}
+	if w.Permissions.All != "read-all" || w.Concurrency.Group != "ci" {
+		t.Errorf("Permissions = %+v, Concurrency = %+v", w.Permissions, w.Concurrency)
+	}
+	if got := w.Job("b"); got.Line != 9 || len(got.Steps) != 1 {
+		t.Errorf("Job(b) = %+v", got)
+	}
+	if got := w.Validate(); len(got) != 0 {
+		t.Errorf("Validate returned %v, want no problems", got)
+	}
+}
+
+func TestParse_anchors(t *testing.T) {
+	w, err := Parse([]byte(`on: push
+x-defaults: &defaults
+  runs-on: ubuntu-latest
+  env: &env
+    GOFLAGS: -mod=readonly
+jobs:
+  test:
+    <<: *defaults
+    steps:
+      - &checkout
+        uses: actions/checkout@v4
+      - run: "go test
+          ./..."
+  lint:
+    <<: *defaults
+    runs-on: macos-latest
+    env: *env
+    steps:
+      - *checkout
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	test, lint := w.Job("test"), w.Job("lint")
+	if got, want := test.RunsOn, []string{"ubuntu-latest"}; !cmp.Equal(got, want) {
+		t.Errorf("test RunsOn = %v, want %v", got, want)
+	}
+	if got, want := lint.RunsOn, []string{"macos-latest"}; !cmp.Equal(got, want) {
+		t.Errorf("lint RunsOn = %v, want %v", got, want)
+	}
+	if got, want := lint.Env, map[string]string{"GOFLAGS": "-mod=readonly"}; !cmp.Equal(got, want) {
+		t.Errorf("lint Env = %v, want %v", got, want)
+	}
+	if len(lint.Steps) != 1 || lint.Steps[0].Uses != "actions/checkout@v4" {
+		t.Errorf("lint Steps = %+v, want the checkout step", lint.Steps)
+	}
+	if len(test.Steps) != 2 || test.Steps[1].Run != "go test ./..." {
+		t.Errorf("test Steps = %+v, want a run step of go test ./...", test.Steps)
+	}
+	if got := w.Validate(); len(got) != 0 {
+		t.Errorf("Validate returned %v, want no problems", got)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	for _, in := range []string{"- a\n- b\n", "on: push\non: pull_request\n", "", "on: [push\n"} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) returned nil error", in)
+		}
+	}
+}
+
+func TestFile_Validate(t *testing.T) {
+	w, err := Parse([]byte(`on:
+  push:
+    branches: [main]
+    branches-ignore: [wip]
+  pull_requests:
+  schedule:
+    - cron: '0 4 * *'
+  workflow_dispatch:
+    inputs:
+      env:
+        type: choice
+      level:
+        type: number
+        default: high
+      mode:
+        type: list
+permissions:
+  contents: admin
+  id-token: read
+  secrets: read
+jobs:
+  a:
+    needs: [c, missing]
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout
+      - run: make
+        uses: actions/setup-go@v5
+      - name: nothing
+      - id: x
+        run: echo ${{ github.sha
+      - id: x
+        run: make
+  c:
+    needs: a
+    concurrency:
+      cancel-in-progress: true
+    steps:
+      - run: make
+  bad id:
+    uses: octo-org/repo@v1
+    steps:
+      - run: make
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	var got []string
+	for _, p := range w.Validate() {
+		got = append(got, p.Error())
+	}
+	want := []string{
+		`line 2: event push cannot use both branches and branches-ignore`,
+		`line 5: unknown event "pull_requests"`,
+		`line 6: cron schedule "0 4 * *" must have 5 fields`,
+		`line 10: choice input env has no options`,
+		`line 12: default of input level must be a number`,
+		`line 15: input mode has unknown type "list"`,
+		`line 18: permission contents must be read, write or none, not "admin"`,
+		`line 18: permission id-token must be write or none`,
+		`line 18: unknown permission scope "secrets"`,
+		`line 22: job a: needs unknown job "missing"`,
+		`line 22: job a: jobs need each other in a cycle: a -> c -> a`,
+		`line 26: job a: uses "actions/checkout" must specify a version, such as actions/checkout@v1 or a commit SHA`,
+		`line 27: job a: step cannot both use an action and run a command`,
+		`line 29: job a: step must use an action or run a command`,
+		`line 30: job a: expression "${{ github.sha" is not closed with }}`,
+		`line 32: job a: duplicate step ID "x"`,
+		`line 34: job c: job must have runs-on or uses`,
+		`line 37: job c: concurrency must have a group`,
+		`line 40: job bad id: job ID must start with a letter or _ and contain only alphanumeric characters, - or _`,
+		`line 40: job bad id: a job that calls a reusable workflow cannot have steps`,
+		`line 40: job bad id: uses "octo-org/repo@v1" is not a reusable workflow, such as owner/repo/.github/workflows/file.yml@ref`,
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Validate returned diff (-want +got):\n%v", cmp.Diff(want, got))
+	}
+
+	empty, err := Parse([]byte("name: empty\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, p := range empty.Validate() {
+		got = append(got, p.Message)
+	}
+	if want := []string{"workflow has no triggers", "workflow has no jobs"}; !cmp.Equal(got, want) {
+		t.Errorf("Validate of an empty workflow = %v, want %v", got, want)
+	}
+}
+
+func TestFile_Validate_dispatchInputs(t *testing.T) {
+	workflow := func(n int) []byte {
+		var b strings.Builder
+		b.WriteString("on:\n  workflow_dispatch:\n    inputs:\n")
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "      in%v: {}\n", i)
+		}
+		b.WriteString("jobs:\n  a: {runs-on: ubuntu-latest, steps: [{run: make}]}\n")
+		return []byte(b.String())
+	}
+
+	w, err := Parse(workflow(25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Validate(); len(got) != 0 {
+		t.Errorf("Validate with 25 inputs returned %v, want no problems", got)
+	}
+
+	w, err = Parse(workflow(26))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range w.Validate() {
+		got = append(got, p.Error())
+	}
+	if want := []string{"line 2: workflow_dispatch can have at most 25 inputs"}; !cmp.Equal(got, want) {
+		t.Errorf("Validate with 26 inputs returned %v, want %v", got, want)
+	}
+}
+
+func TestFile_CheckDispatchInputs(t *testing.T) {
+	w, err := Parse([]byte(testWorkflowFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := []map[string]interface{}{
+		{"environment": "staging"},
+		{"environment": "production", "dry-run": true, "replicas": 3, "note": "hi"},
+		{"environment": "production", "dry-run": "false", "replicas": "2.5"},
+	}
+	for _, inputs := range valid {
+		if err := w.CheckDispatchInputs(inputs); err != nil {
+			t.Errorf("CheckDispatchInputs(%v) returned error: %v", inputs, err)
+		}
+	}
+
+	err = w.CheckDispatchInputs(map[string]interface{}{"dry-run": "yes", "replicas": "many", "note": 1, "extra": "x"})
+	want := `invalid inputs for workflow : input "environment" is required; input "dry-run" must be true or false; ` +
+		`input "extra" is not declared; input "note" must be a string; input "replicas" must be a number`
+	if err == nil || err.Error() != want {
+		t.Errorf("CheckDispatchInputs returned %v, want %v", err, want)
+	}
+
+	err = w.CheckDispatchInputs(map[string]interface{}{"environment": "dev"})
+	if err == nil || !strings.Contains(err.Error(), "must be one of staging, production") {
+		t.Errorf("CheckDispatchInputs returned %v, want a choice error", err)
+	}
+
+	noDispatch, _ := Parse([]byte("on: push\n"))
+	if err := noDispatch.CheckDispatchInputs(nil); err == nil {
+		t.Error("CheckDispatchInputs returned nil error for a workflow without workflow_dispatch")
+	}
+}
+
+func TestFile_UnpinnedActions(t *testing.T) {
+	w, err := Parse([]byte(`on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: ./local-action
+      - uses: octo-org/tool@main
+      - uses: other/tool@0123456789abcdef0123456789abcdef01234567
+      - uses: other/tool/sub@v1.2.3
+      - uses: docker://alpine:3
+      - uses: docker://alpine@sha256:abcd
+      - uses: unversioned/tool
+  b:
+    uses: other/workflows/.github/workflows/x.yml@main
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Path = ".github/workflows/ci.yml"
+
+	got := w.UnpinnedActions("actions", "octo-org")
+	want := []*UnpinnedActionUse{
+		{Path: ".github/workflows/ci.yml", Line: 10, Job: "a", Uses: "other/tool/sub@v1.2.3"},
+		{Path: ".github/workflows/ci.yml", Line: 11, Job: "a", Uses: "docker://alpine:3"},
+		{Path: ".github/workflows/ci.yml", Line: 13, Job: "a", Uses: "unversioned/tool"},
+		{Path: ".github/workflows/ci.yml", Line: 14, Job: "b", Uses: "other/workflows/.github/workflows/x.yml@main"},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("UnpinnedActions returned diff (-want +got):\n%v", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type nodeKind int
+
+const (
+	nullNode nodeKind = iota
+	scalarNode
+	mappingNode
+	sequenceNode
+)
+
+// node is a node of a YAML document with its aliases and merge keys
+// resolved.
+type node struct {
+	kind nodeKind
+	line int
+
+	// value is the value of a scalar.
+	value string
+
+	// keys are the keys of a mapping, in order, and keyLines their lines.
+	// items are the values of a mapping, parallel to keys, or the entries
+	// of a sequence.
+	keys     []string
+	keyLines []int
+	items    []*node
+}
+
+// get returns the value of key in a mapping, or nil.
+func (n *node) get(key string) *node {
+	if n == nil || n.kind != mappingNode {
+		return nil
+	}
+	for i, k := range n.keys {
+		if k == key {
+			return n.items[i]
+		}
+	}
+	return nil
+}
+
+// isNull reports whether n is missing or null.
+func (n *node) isNull() bool {
+	return n == nil || n.kind == nullNode
+}
+
+// parseYAML parses a single YAML document. It returns nil if the document
+// is empty.
+func parseYAML(data []byte) (*node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var next yaml.Node
+	if err := dec.Decode(&next); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("line %v: multiple documents are not supported", next.Line)
+	}
+
+	c := &yamlConverter{
+		done:       make(map[*yaml.Node]*node),
+		converting: make(map[*yaml.Node]bool),
+	}
+	return c.convert(&doc)
+}
+
+// yamlConverter converts yaml.Nodes to nodes. Nodes that are referenced by
+// several aliases are converted once and shared.
+type yamlConverter struct {
+	done       map[*yaml.Node]*node
+	converting map[*yaml.Node]bool
+}
+
+func (c *yamlConverter) convert(y *yaml.Node) (*node, error) {
+	switch y.Kind {
+	case yaml.DocumentNode:
+		if len(y.Content) == 0 {
+			return nil, nil
+		}
+		return c.convert(y.Content[0])
+	case yaml.AliasNode:
+		return c.convert(y.Alias)
+	}
+
+	if n := c.done[y]; n != nil {
+		return n, nil
+	}
+	if c.converting[y] {
+		return nil, fmt.Errorf("line %v: anchor %q refers to itself", y.Line, y.Anchor)
+	}
+	c.converting[y] = true
+	defer delete(c.converting, y)
+
+	n := &node{line: y.Line}
+	switch y.Kind {
+	case yaml.ScalarNode:
+		if y.Tag != "!!null" {
+			n.kind = scalarNode
+			n.value = y.Value
+		}
+	case yaml.SequenceNode:
+		n.kind = sequenceNode
+		for _, item := range y.Content {
+			v, err := c.convert(item)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, v)
+		}
+	case yaml.MappingNode:
+		n.kind = mappingNode
+		if err := c.mapping(n, y); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("line %v: unexpected YAML node", y.Line)
+	}
+	c.done[y] = n
+	return n, nil
+}
+
+// mapping converts the entries of the mapping y into n. The entries of
+// mappings merged with a "<<" key are added in place of the merge key,
+// unless the mapping sets them itself.
+func (c *yamlConverter) mapping(n *node, y *yaml.Node) error {
+	explicit := make(map[string]bool)
+	for i := 0; i+1 < len(y.Content); i += 2 {
+		if k := y.Content[i]; k.Tag != "!!merge" {
+			if explicit[k.Value] {
+				return fmt.Errorf("line %v: duplicate key %q", k.Line, k.Value)
+			}
+			explicit[k.Value] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	add := func(key string, line int, value *node) {
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		n.keys = append(n.keys, key)
+		n.keyLines = append(n.keyLines, line)
+		n.items = append(n.items, value)
+	}
+	for i := 0; i+1 < len(y.Content); i += 2 {
+		k := y.Content[i]
+		v, err := c.convert(y.Content[i+1])
+		if err != nil {
+			return err
+		}
+		if k.Tag != "!!merge" {
+			if k.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %v: mapping keys must be scalars", k.Line)
+			}
+			add(k.Value, k.Line, v)
+			continue
+		}
+
+		merged := []*node{v}
+		if v.kind == sequenceNode {
+			merged = v.items
+		}
+		for _, m := range merged {
+			if m.kind != mappingNode {
+				return fmt.Errorf("line %v: only mappings can be merged", k.Line)
+			}
+			for j, key := range m.keys {
+				if !explicit[key] {
+					add(key, m.keyLines[j], m.items[j])
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseYAML_merge(t *testing.T) {
+	n, err := parseYAML([]byte("a: &a {x: 1, y: 2}\nb: &b {z: 3}\nc:\n  y: 4\n  <<: [*a, *b]\n  w: ~\n"))
+	if err != nil {
+		t.Fatalf("parseYAML returned error: %v", err)
+	}
+	c := n.get("c")
+	if got, want := c.keys, []string{"y", "x", "z", "w"}; !cmp.Equal(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+	if got := c.get("y").value; got != "4" {
+		t.Errorf("y = %q, want 4", got)
+	}
+	if !c.get("w").isNull() {
+		t.Errorf("w = %+v, want null", c.get("w"))
+	}
+}
+
+func TestParseYAML_errors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"a: 1\na: 2\n", `line 2: duplicate key "a"`},
+		{"a: &a [*a]\n", `line 1: anchor "a" refers to itself`},
+		{"a: 1\n---\nb: 2\n", "line 2: multiple documents are not supported"},
+		{"a: &a [1]\nb:\n  <<: *a\n", "line 3: only mappings can be merged"},
+		{"? [a]\n: 1\n", "line 1: mapping keys must be scalars"},
+	}
+
+	for _, tt := range tests {
+		_, err := parseYAML([]byte(tt.in))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("parseYAML(%q) returned %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}